@@ -0,0 +1,35 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/AlyxPink/meower/internal/cli"
+	"github.com/AlyxPink/meower/internal/templates"
+	"github.com/AlyxPink/meower/internal/templates/templatetest"
+)
+
+// TestNewProjectGolden renders `meower new` into memory and compares the
+// result against testdata/golden/new. Run with -update to accept changes.
+func TestNewProjectGolden(t *testing.T) {
+	templates.EmbeddedFiles = embeddedTemplateFiles
+
+	out := templates.NewMemoryWriter()
+	generator := cli.NewProjectGeneratorWithWriter(&cli.ProjectConfig{
+		ProjectName: "test-project",
+		ModulePath:  "github.com/test/test-project",
+	}, out)
+
+	steps := []func() error{
+		generator.ValidateAndPrepare,
+		generator.CreateProjectStructure,
+		generator.ProcessTemplates,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("failed to render project: %v", err)
+		}
+	}
+
+	templatetest.AssertGolden(t, filepath.Join("testdata", "golden", "new"), out.Files)
+}
@@ -0,0 +1,13 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/AlyxPink/meower/internal/templates/templatetest"
+)
+
+// TestEmbeddedTemplateLint ensures the embedded template only uses known
+// placeholders and was committed in template mode.
+func TestEmbeddedTemplateLint(t *testing.T) {
+	templatetest.AssertLintClean(t, embeddedTemplateFiles, templatetest.ProjectVars(t))
+}
@@ -0,0 +1,301 @@
+// Package config loads the settings of the API and web servers.
+//
+// Every field names its environment variable with an env tag. A value is
+// read, by order of precedence, from:
+//
+//   - the environment
+//   - a .env file
+//   - a YAML file, config.yaml or the one named by CONFIG_FILE, whose keys are
+//     the lowercase variable names (database_url: ...)
+//   - the default tag
+//
+// Files are looked up in the working directory, then in its parent, so the
+// project root files apply when a server runs from api/ or web/. The validate
+// tag lists comma-separated rules checked at startup:
+//
+//	required     the value is not empty
+//	url          an absolute URL, such as postgres://localhost:5432/db
+//	hostport     a host:port address, the host may be empty (:50051)
+//	oneof=a|b    one of the listed values
+//	key          a base64 encoded 16, 24 or 32 bytes key
+//
+// `meower config check` reads these tags to report missing or invalid values
+// without starting the servers: keep them as string literals.
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// API configures the gRPC API server
+type API struct {
+	Env         string `env:"ENV" default:"development" validate:"oneof=development|production"`
+	Addr        string `env:"API_ADDR" default:":50051" validate:"required,hostport"`
+	DatabaseURL string `env:"DATABASE_URL" validate:"required,url"`
+	// DatabaseTimeout bounds the wait for the database at startup
+	DatabaseTimeout time.Duration `env:"DATABASE_TIMEOUT" default:"30s"`
+	// HealthCheckInterval is the time between database pings reported to
+	// health checks
+	HealthCheckInterval time.Duration `env:"HEALTH_CHECK_INTERVAL" default:"10s"`
+	// ShutdownTimeout bounds the wait for in-flight calls on SIGINT or SIGTERM
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" default:"10s"`
+	// TokenSigningKey signs the session tokens issued by Login
+	TokenSigningKey string `env:"TOKEN_SIGNING_KEY" validate:"required,key"`
+	// TokenTTL is the lifetime of session tokens
+	TokenTTL time.Duration `env:"TOKEN_TTL" default:"720h"`
+	// GRPCWebEnabled also serves the API to browsers over gRPC-Web
+	GRPCWebEnabled bool   `env:"GRPC_WEB_ENABLED"`
+	GRPCWebAddr    string `env:"GRPC_WEB_ADDR" default:":8080" validate:"hostport"`
+	// AllowedOrigins may call the gRPC-Web API, * allows any origin
+	AllowedOrigins []string `env:"CORS_ALLOWED_ORIGINS"`
+}
+
+// Web configures the web server
+type Web struct {
+	Env             string `env:"ENV" default:"development" validate:"oneof=development|production"`
+	Addr            string `env:"WEB_ADDR" default:"0.0.0.0:3000" validate:"required,hostport"`
+	APIEndpoint     string `env:"API_ENDPOINT" default:"localhost:50051" validate:"required,hostport"`
+	RedisURL        string `env:"REDIS_URL" default:"redis://localhost:6379" validate:"required,url"`
+	CookieSecretKey string `env:"COOKIE_SECRET_KEY" validate:"required,key"`
+	// GatewayEnabled mounts the JSON gateway under /api
+	GatewayEnabled bool `env:"GATEWAY_ENABLED"`
+	// DocsEnabled serves the OpenAPI document and its viewer at /api/docs
+	DocsEnabled bool `env:"DOCS_ENABLED"`
+}
+
+// IsProduction reports whether the API runs in production
+func (c *API) IsProduction() bool {
+	return c.Env == "production"
+}
+
+// IsProduction reports whether the web server runs in production
+func (c *Web) IsProduction() bool {
+	return c.Env == "production"
+}
+
+// lookupFunc returns the value of a variable and whether it is set
+type lookupFunc func(name string) (string, bool)
+
+// Load fills cfg, a pointer to API or Web, from the environment, the .env
+// and YAML files and the defaults. It reports every missing or invalid value
+// at once.
+func Load(cfg any) error {
+	lookup, err := sources()
+	if err != nil {
+		return err
+	}
+	return decode(cfg, lookup)
+}
+
+// sources chains the environment, the .env file and the YAML file
+func sources() (lookupFunc, error) {
+	dotenv := map[string]string{}
+	if path := findFile(".env"); path != "" {
+		values, err := godotenv.Read(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		dotenv = values
+	}
+
+	path, required := os.Getenv("CONFIG_FILE"), true
+	if path == "" {
+		path, required = findFile("config.yaml"), false
+	}
+	file := map[string]string{}
+	if path != "" {
+		values, err := readYAML(path)
+		if err != nil && (required || !errors.Is(err, os.ErrNotExist)) {
+			return nil, err
+		}
+		file = values
+	}
+
+	return func(name string) (string, bool) {
+		if value, ok := os.LookupEnv(name); ok {
+			return value, true
+		}
+		if value, ok := dotenv[name]; ok {
+			return value, true
+		}
+		value, ok := file[strings.ToLower(name)]
+		return value, ok
+	}, nil
+}
+
+// findFile returns the path of name in the working directory or its parent,
+// or "" when neither has it
+func findFile(name string) string {
+	for _, path := range []string{name, filepath.Join("..", name)} {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// readYAML reads a YAML mapping of scalars or lists of scalars, lists being
+// joined with commas like their environment variables
+func readYAML(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var raw map[string]any
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	values := make(map[string]string, len(raw))
+	for key, value := range raw {
+		switch value := value.(type) {
+		case nil:
+			values[key] = ""
+		case map[string]any:
+			return nil, fmt.Errorf("%s: %s must be a value or a list, not a mapping", path, key)
+		case []any:
+			items := make([]string, len(value))
+			for i, item := range value {
+				items[i] = fmt.Sprint(item)
+			}
+			values[key] = strings.Join(items, ",")
+		default:
+			values[key] = fmt.Sprint(value)
+		}
+	}
+	return values, nil
+}
+
+// decode sets the fields of cfg from lookup and their tags
+func decode(cfg any, lookup lookupFunc) error {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config: %T is not a pointer to a struct", cfg)
+	}
+	v = v.Elem()
+
+	var errs []error
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		name := field.Tag.Get("env")
+		if name == "" {
+			continue
+		}
+
+		value, ok := lookup(name)
+		if !ok {
+			value = field.Tag.Get("default")
+		}
+		if err := validate(value, field.Tag.Get("validate")); err != nil {
+			errs = append(errs, fmt.Errorf("%s %w", name, err))
+			continue
+		}
+		if err := set(v.Field(i), value); err != nil {
+			errs = append(errs, fmt.Errorf("%s %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validate checks value against comma-separated rules. Empty values only
+// fail the required rule.
+func validate(value, rules string) error {
+	for _, rule := range strings.Split(rules, ",") {
+		rule, arg, _ := strings.Cut(rule, "=")
+		if rule == "required" && value == "" {
+			return errors.New("is required")
+		}
+		if value == "" {
+			continue
+		}
+
+		switch rule {
+		case "url":
+			if u, err := url.Parse(value); err != nil || u.Scheme == "" || u.Host == "" {
+				return fmt.Errorf("must be an absolute URL, got %q", value)
+			}
+		case "hostport":
+			if _, port, err := net.SplitHostPort(value); err != nil || port == "" {
+				return fmt.Errorf("must be a host:port address, got %q", value)
+			}
+		case "oneof":
+			if values := strings.Split(arg, "|"); !slices.Contains(values, value) {
+				return fmt.Errorf("must be one of %s, got %q", strings.Join(values, ", "), value)
+			}
+		case "key":
+			key, err := base64.StdEncoding.DecodeString(value)
+			if err != nil || (len(key) != 16 && len(key) != 24 && len(key) != 32) {
+				return errors.New("must be a base64 encoded 16, 24 or 32 bytes key, generate one with `openssl rand -base64 32`")
+			}
+		case "", "required":
+		default:
+			return fmt.Errorf("has unknown validation rule %q", rule)
+		}
+	}
+	return nil
+}
+
+// set parses value into a string, bool, int, duration or comma-separated
+// string slice field
+func set(field reflect.Value, value string) error {
+	if field.Type() == reflect.TypeFor[time.Duration]() {
+		if value == "" {
+			return nil
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("must be a duration such as 30s, got %q", value)
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		if value == "" {
+			return nil
+		}
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("must be true or false, got %q", value)
+		}
+		field.SetBool(b)
+	case reflect.Int:
+		if value == "" {
+			return nil
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("must be an integer, got %q", value)
+		}
+		field.SetInt(int64(n))
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("has unsupported type %s", field.Type())
+	}
+	return nil
+}
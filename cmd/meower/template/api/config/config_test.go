@@ -0,0 +1,146 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]string
+		want    Web
+		wantErr []string
+	}{
+		{
+			name:   "defaults",
+			values: map[string]string{"COOKIE_SECRET_KEY": "5TIyDD81Laz/xdxEw2yJVPKdJYyPqxmyONaSVJHs6jY="},
+			want: Web{
+				Env:             "development",
+				Addr:            "0.0.0.0:3000",
+				APIEndpoint:     "localhost:50051",
+				RedisURL:        "redis://localhost:6379",
+				CookieSecretKey: "5TIyDD81Laz/xdxEw2yJVPKdJYyPqxmyONaSVJHs6jY=",
+			},
+		},
+		{
+			name: "values override defaults",
+			values: map[string]string{
+				"ENV":               "production",
+				"WEB_ADDR":          ":8000",
+				"API_ENDPOINT":      "api:50051",
+				"REDIS_URL":         "redis://redis:6379",
+				"COOKIE_SECRET_KEY": "MDEyMzQ1Njc4OWFiY2RlZg==",
+				"GATEWAY_ENABLED":   "true",
+				"DOCS_ENABLED":      "1",
+			},
+			want: Web{
+				Env:             "production",
+				Addr:            ":8000",
+				APIEndpoint:     "api:50051",
+				RedisURL:        "redis://redis:6379",
+				CookieSecretKey: "MDEyMzQ1Njc4OWFiY2RlZg==",
+				GatewayEnabled:  true,
+				DocsEnabled:     true,
+			},
+		},
+		{
+			name: "every invalid value is reported",
+			values: map[string]string{
+				"ENV":             "staging",
+				"API_ENDPOINT":    "api",
+				"REDIS_URL":       "redis",
+				"GATEWAY_ENABLED": "yes",
+			},
+			wantErr: []string{
+				`ENV must be one of development, production, got "staging"`,
+				`API_ENDPOINT must be a host:port address, got "api"`,
+				`REDIS_URL must be an absolute URL, got "redis"`,
+				"COOKIE_SECRET_KEY is required",
+				`GATEWAY_ENABLED must be true or false, got "yes"`,
+			},
+		},
+		{
+			name:    "invalid key",
+			values:  map[string]string{"COOKIE_SECRET_KEY": "secret"},
+			wantErr: []string{"COOKIE_SECRET_KEY must be a base64 encoded 16, 24 or 32 bytes key"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Web
+			err := decode(&got, func(name string) (string, bool) {
+				value, ok := tt.values[name]
+				return value, ok
+			})
+
+			if len(tt.wantErr) > 0 {
+				if err == nil {
+					t.Fatal("decode() error = nil, want an error")
+				}
+				for _, want := range tt.wantErr {
+					if !strings.Contains(err.Error(), want) {
+						t.Errorf("decode() error = %v, want it to contain %q", err, want)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decode() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decode() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "api"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		".env":        "DATABASE_URL=postgres://meower@localhost:5432/meower\nGRPC_WEB_ENABLED=true\nTOKEN_SIGNING_KEY=MDEyMzQ1Njc4OWFiY2RlZg==\n",
+		"config.yaml": "database_url: postgres://ignored@localhost:5432/meower\ngrpc_web_addr: :9090\ncors_allowed_origins:\n  - http://localhost:3000\n  - http://localhost:7331\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(filepath.Join(dir, "api"))
+	// Values set by docker compose would take precedence over the files
+	for _, name := range []string{"CONFIG_FILE", "ENV", "DATABASE_URL", "GRPC_WEB_ENABLED", "GRPC_WEB_ADDR", "CORS_ALLOWED_ORIGINS", "TOKEN_SIGNING_KEY", "TOKEN_TTL"} {
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+	t.Setenv("API_ADDR", ":50052")
+
+	var got API
+	if err := Load(&got); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	want := API{
+		Env:                 "development",
+		Addr:                ":50052",
+		DatabaseURL:         "postgres://meower@localhost:5432/meower",
+		DatabaseTimeout:     30 * time.Second,
+		HealthCheckInterval: 10 * time.Second,
+		ShutdownTimeout:     10 * time.Second,
+		TokenSigningKey:     "MDEyMzQ1Njc4OWFiY2RlZg==",
+		TokenTTL:            720 * time.Hour,
+		GRPCWebEnabled:      true,
+		GRPCWebAddr:         ":9090",
+		AllowedOrigins:      []string{"http://localhost:3000", "http://localhost:7331"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Load() = %+v, want %+v", got, want)
+	}
+}
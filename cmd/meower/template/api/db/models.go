@@ -8,24 +8,63 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type Follow struct {
+	FollowerID pgtype.UUID
+	FollowedID pgtype.UUID
+	CreatedAt  pgtype.Timestamp
+}
+
+type Like struct {
+	UserID    pgtype.UUID
+	MeowID    pgtype.UUID
+	CreatedAt pgtype.Timestamp
+}
+
 type Meow struct {
 	ID        pgtype.UUID
 	UserID    pgtype.UUID
 	Content   string
 	CreatedAt pgtype.Timestamp
+	ParentID  pgtype.UUID
+	Search    interface{}
+}
+
+type MeowMention struct {
+	MeowID pgtype.UUID
+	UserID pgtype.UUID
+}
+
+type MeowTag struct {
+	MeowID pgtype.UUID
+	Tag    string
+}
+
+type Repost struct {
+	UserID    pgtype.UUID
+	MeowID    pgtype.UUID
+	CreatedAt pgtype.Timestamp
+}
+
+type Token struct {
+	ID        pgtype.UUID
+	UserID    pgtype.UUID
+	CreatedAt pgtype.Timestamp
+	ExpiresAt pgtype.Timestamp
+	RevokedAt pgtype.Timestamp
 }
 
 type User struct {
-	ID                   pgtype.UUID
-	Username             string
-	DisplayName          string
-	Email                string
-	EmailVerified        pgtype.Bool
-	PasswordHash         string
-	ResetPasswordToken   pgtype.Text
-	ResetPasswordExpires pgtype.Timestamp
-	CreatedAt            pgtype.Timestamp
-	LastLoginAt          pgtype.Timestamp
-	AccountLocked        pgtype.Bool
-	FailedLoginAttempts  pgtype.Int4
+	ID                     pgtype.UUID
+	Username               string
+	DisplayName            string
+	Email                  string
+	EmailVerified          pgtype.Bool
+	EmailVerificationToken pgtype.Text
+	PasswordHash           string
+	ResetPasswordToken     pgtype.Text
+	ResetPasswordExpires   pgtype.Timestamp
+	CreatedAt              pgtype.Timestamp
+	LastLoginAt            pgtype.Timestamp
+	AccountLocked          pgtype.Bool
+	FailedLoginAttempts    pgtype.Int4
 }
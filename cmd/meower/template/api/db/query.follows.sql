@@ -0,0 +1,41 @@
+-- name: FollowUser :exec
+INSERT INTO follows (follower_id, followed_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+-- name: UnfollowUser :exec
+DELETE FROM follows
+WHERE follower_id = $1
+  AND followed_id = $2;
+-- name: IsFollowing :one
+SELECT EXISTS (
+  SELECT 1 FROM follows
+  WHERE follower_id = $1
+    AND followed_id = $2
+);
+-- name: ListFollowedIDs :many
+SELECT followed_id FROM follows
+WHERE follower_id = $1;
+-- name: CountFollowers :one
+SELECT COUNT(*) FROM follows
+WHERE followed_id = $1;
+-- name: CountFollowing :one
+SELECT COUNT(*) FROM follows
+WHERE follower_id = $1;
+-- name: ListFollowers :many
+SELECT users.id, users.username, users.display_name, follows.created_at
+FROM follows
+JOIN users ON users.id = follows.follower_id
+WHERE follows.followed_id = sqlc.arg(user_id)
+  AND (sqlc.narg(before_created_at)::timestamp IS NULL
+    OR (follows.created_at, users.id) < (sqlc.narg(before_created_at), sqlc.narg(before_id)::uuid))
+ORDER BY follows.created_at DESC, users.id DESC
+LIMIT sqlc.arg(page_size);
+-- name: ListFollowing :many
+SELECT users.id, users.username, users.display_name, follows.created_at
+FROM follows
+JOIN users ON users.id = follows.followed_id
+WHERE follows.follower_id = sqlc.arg(user_id)
+  AND (sqlc.narg(before_created_at)::timestamp IS NULL
+    OR (follows.created_at, users.id) < (sqlc.narg(before_created_at), sqlc.narg(before_id)::uuid))
+ORDER BY follows.created_at DESC, users.id DESC
+LIMIT sqlc.arg(page_size);
@@ -40,8 +40,10 @@ WHERE id = $1;
 
 -- name: IndexUsers :many
 SELECT * FROM users
-ORDER BY created_at DESC
-LIMIT $1 OFFSET $2;
+WHERE sqlc.narg(before_created_at)::timestamp IS NULL
+  OR (created_at, id) < (sqlc.narg(before_created_at), sqlc.narg(before_id)::uuid)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(page_size);
 
 -- name: UpdatePasswordResetToken :one
 UPDATE users
@@ -69,8 +71,15 @@ SET last_login_at = $2
 WHERE id = $1
 RETURNING *;
 
--- name: VerifyEmail :one
+-- name: UpdateEmailVerificationToken :one
 UPDATE users
-SET email_verified = true
+SET email_verification_token = $2
 WHERE id = $1
 RETURNING *;
+
+-- name: VerifyEmail :one
+UPDATE users
+SET email_verified = true,
+  email_verification_token = NULL
+WHERE email_verification_token = $1
+RETURNING *;
@@ -5,6 +5,7 @@ CREATE TABLE
     display_name text NOT NULL,
     email text NOT NULL UNIQUE,
     email_verified boolean DEFAULT false,
+    email_verification_token text,
     password_hash text NOT NULL,
     reset_password_token text,
     reset_password_expires timestamp,
@@ -14,10 +15,93 @@ CREATE TABLE
     failed_login_attempts integer DEFAULT 0
   );
 
+CREATE INDEX users_created_at_id_idx ON users (created_at DESC, id DESC);
+
 CREATE TABLE
   meows (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
-    user_id UUID REFERENCES users (id),
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
     content text NOT NULL,
-    created_at timestamp NOT NULL DEFAULT NOW ()
+    created_at timestamp NOT NULL DEFAULT NOW (),
+    -- The meow replied to, NULL for meows starting a thread
+    parent_id UUID REFERENCES meows (id) ON DELETE CASCADE,
+    -- Full-text search document of the content
+    search tsvector GENERATED ALWAYS AS (to_tsvector('english', content)) STORED
+  );
+
+-- Keyset pagination of the timelines, newest first
+CREATE INDEX meows_created_at_id_idx ON meows (created_at DESC, id DESC);
+
+CREATE INDEX meows_user_id_created_at_id_idx ON meows (user_id, created_at DESC, id DESC);
+
+CREATE INDEX meows_parent_id_created_at_id_idx ON meows (parent_id, created_at DESC, id DESC);
+
+CREATE INDEX meows_search_idx ON meows USING GIN (search);
+
+-- #tags of meows, parsed from their content in lowercase
+CREATE TABLE
+  meow_tags (
+    meow_id UUID NOT NULL REFERENCES meows (id) ON DELETE CASCADE,
+    tag text NOT NULL,
+    PRIMARY KEY (meow_id, tag)
+  );
+
+CREATE INDEX meow_tags_tag_idx ON meow_tags (tag);
+
+-- Users @mentioned in meows, parsed from their content
+CREATE TABLE
+  meow_mentions (
+    meow_id UUID NOT NULL REFERENCES meows (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    PRIMARY KEY (meow_id, user_id)
+  );
+
+CREATE INDEX meow_mentions_user_id_idx ON meow_mentions (user_id);
+
+-- Likes of meows, the primary key allows one per user and meow
+CREATE TABLE
+  likes (
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    meow_id UUID NOT NULL REFERENCES meows (id) ON DELETE CASCADE,
+    created_at timestamp NOT NULL DEFAULT NOW (),
+    PRIMARY KEY (user_id, meow_id)
   );
+
+CREATE INDEX likes_meow_id_idx ON likes (meow_id);
+
+-- Reposts of meows, the primary key allows one per user and meow
+CREATE TABLE
+  reposts (
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    meow_id UUID NOT NULL REFERENCES meows (id) ON DELETE CASCADE,
+    created_at timestamp NOT NULL DEFAULT NOW (),
+    PRIMARY KEY (user_id, meow_id)
+  );
+
+CREATE INDEX reposts_meow_id_idx ON reposts (meow_id);
+
+-- Users following other users, whose meows make up their home timeline
+CREATE TABLE
+  follows (
+    follower_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    followed_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at timestamp NOT NULL DEFAULT NOW (),
+    PRIMARY KEY (follower_id, followed_id),
+    CHECK (follower_id <> followed_id)
+  );
+
+-- The primary key lists who a user follows, this index their followers
+CREATE INDEX follows_followed_id_created_at_idx ON follows (followed_id, created_at DESC);
+
+-- Session tokens issued by Login, identified by the jti claim of the JWT. A
+-- token is valid until it expires or is revoked.
+CREATE TABLE
+  tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at timestamp NOT NULL DEFAULT NOW (),
+    expires_at timestamp NOT NULL,
+    revoked_at timestamp
+  );
+
+CREATE INDEX tokens_user_id_idx ON tokens (user_id);
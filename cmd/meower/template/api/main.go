@@ -1,13 +1,27 @@
 package main
 
 import (
-	"TEMPLATE_MODULE_PATH/api/server"
-)
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
-const (
-	apiEndpoint = "localhost:50051"
+	"TEMPLATE_MODULE_PATH/api/config"
+	"TEMPLATE_MODULE_PATH/api/server"
 )
 
 func main() {
-	server.Serve()
+	var cfg config.API
+	if err := config.Load(&cfg); err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
+	}
+
+	// Shut down gracefully on Ctrl+C and `docker compose stop`
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := server.Serve(ctx, &cfg); err != nil {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+
+	authV1 "TEMPLATE_MODULE_PATH/api/proto/auth/v1"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// publicServices accept anonymous calls to all their methods, so that health
+// probes and tools such as grpcui work without a session
+var publicServices = []string{
+	grpc_health_v1.Health_ServiceDesc.ServiceName,
+	"grpc.reflection.v1.ServerReflection",
+	"grpc.reflection.v1alpha.ServerReflection",
+}
+
+type sessionKey struct{}
+
+// NewContext returns a copy of ctx carrying session
+func NewContext(ctx context.Context, session Session) context.Context {
+	return context.WithValue(ctx, sessionKey{}, session)
+}
+
+// FromContext returns the session of the caller, if authenticated
+func FromContext(ctx context.Context) (Session, bool) {
+	session, ok := ctx.Value(sessionKey{}).(Session)
+	return session, ok
+}
+
+// UserID returns the ID of the authenticated caller. It is always set in
+// private RPCs.
+func UserID(ctx context.Context) (pgtype.UUID, bool) {
+	session, ok := FromContext(ctx)
+	return session.UserID, ok
+}
+
+// UnaryInterceptor authenticates unary calls
+func (t *Tokens) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := t.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor authenticates streams
+func (t *Tokens) StreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := t.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticate adds the session of the token sent with a call to ctx. Calls
+// to private RPCs fail without a valid token; calls to public RPCs with an
+// invalid one are anonymous.
+func (t *Tokens) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	public := isPublic(fullMethod)
+
+	token := bearerToken(ctx)
+	if token == "" {
+		if public {
+			return ctx, nil
+		}
+		return nil, status.Error(codes.Unauthenticated, "missing session token")
+	}
+
+	session, err := t.Verify(ctx, token)
+	switch {
+	case err == nil:
+		return NewContext(ctx, session), nil
+	case public:
+		return ctx, nil
+	case errors.Is(err, ErrInvalidToken), errors.Is(err, ErrExpiredToken), errors.Is(err, ErrRevokedToken):
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	default:
+		return nil, status.Error(codes.Unavailable, "failed to check session token")
+	}
+}
+
+// bearerToken returns the token of the "authorization: Bearer <token>"
+// metadata of ctx, or ""
+func bearerToken(ctx context.Context) string {
+	for _, value := range metadata.ValueFromIncomingContext(ctx, "authorization") {
+		if scheme, token, ok := strings.Cut(value, " "); ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+	return ""
+}
+
+// public caches isPublic by full method name
+var public sync.Map
+
+// isPublic reports whether the RPC named fullMethod, such as
+// /user.v1.UserService/Login, is annotated with option (auth.v1.public) = true
+func isPublic(fullMethod string) bool {
+	if cached, ok := public.Load(fullMethod); ok {
+		return cached.(bool)
+	}
+
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	result := slices.Contains(publicServices, service)
+	if !result {
+		desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service + "." + method))
+		if md, ok := desc.(protoreflect.MethodDescriptor); err == nil && ok {
+			result, _ = proto.GetExtension(md.Options(), authV1.E_Public).(bool)
+		}
+	}
+	public.Store(fullMethod, result)
+	return result
+}
+
+// serverStream replaces the context of a stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,170 @@
+// Package auth issues the session tokens of the API and authenticates calls
+// with them.
+//
+// A session token is a JWT signed with HMAC-SHA256 by TOKEN_SIGNING_KEY. Its
+// jti claim is the ID of a row of the tokens table, so a token can be revoked
+// before it expires: Logout revokes the token of the caller, a password reset
+// every token of the user.
+//
+// Clients send the token in the authorization metadata, as "Bearer <token>".
+// RPCs are private unless annotated with option (auth.v1.public) = true: the
+// interceptor rejects calls to private RPCs without a valid token, and adds
+// the Session of authenticated callers to the context of every call.
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"TEMPLATE_MODULE_PATH/api/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	// ErrInvalidToken is returned for tokens that are malformed or not signed
+	// with the signing key
+	ErrInvalidToken = errors.New("invalid session token")
+	// ErrExpiredToken is returned for tokens past their expiration
+	ErrExpiredToken = errors.New("session token expired")
+	// ErrRevokedToken is returned for tokens revoked by a logout
+	ErrRevokedToken = errors.New("session token revoked")
+)
+
+// Session is the authenticated caller of an RPC
+type Session struct {
+	UserID    pgtype.UUID
+	TokenID   pgtype.UUID
+	ExpiresAt time.Time
+}
+
+// Tokens issues, verifies and revokes session tokens
+type Tokens struct {
+	db  db.DBTX
+	key []byte
+	ttl time.Duration
+}
+
+// NewTokens returns Tokens signed with key, a base64 encoded key such as
+// TOKEN_SIGNING_KEY, valid for ttl and recorded in dbtx
+func NewTokens(dbtx db.DBTX, key string, ttl time.Duration) (*Tokens, error) {
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(decoded) < 16 {
+		return nil, errors.New("token signing key must be a base64 encoded key of at least 16 bytes")
+	}
+	return &Tokens{db: dbtx, key: decoded, ttl: ttl}, nil
+}
+
+// claims is the payload of a session token
+type claims struct {
+	ID        string `json:"jti"`
+	Subject   string `json:"sub"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// header is the only JOSE header of session tokens, checked as is so that no
+// other algorithm is ever accepted
+var header = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Issue records a new token for the user and returns it signed
+func (t *Tokens) Issue(ctx context.Context, userID pgtype.UUID) (string, error) {
+	now := time.Now()
+	expiresAt := now.Add(t.ttl)
+	row, err := db.New(t.db).CreateToken(ctx, db.CreateTokenParams{
+		UserID:    userID,
+		ExpiresAt: pgtype.Timestamp{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to record token: %w", err)
+	}
+
+	payload, err := json.Marshal(claims{
+		ID:        hex.EncodeToString(row.ID.Bytes[:]),
+		Subject:   hex.EncodeToString(userID.Bytes[:]),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	unsigned := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + t.sign(unsigned), nil
+}
+
+// Verify checks the signature and expiration of token, then that it was not
+// revoked, and returns its session
+func (t *Tokens) Verify(ctx context.Context, token string) (Session, error) {
+	c, err := t.parse(token)
+	if err != nil {
+		return Session{}, err
+	}
+	expiresAt := time.Unix(c.ExpiresAt, 0)
+	if !time.Now().Before(expiresAt) {
+		return Session{}, ErrExpiredToken
+	}
+
+	var session Session
+	if session.TokenID.Scan(c.ID) != nil || session.UserID.Scan(c.Subject) != nil {
+		return Session{}, ErrInvalidToken
+	}
+	session.ExpiresAt = expiresAt
+
+	row, err := db.New(t.db).GetActiveToken(ctx, session.TokenID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Session{}, ErrRevokedToken
+	}
+	if err != nil {
+		return Session{}, fmt.Errorf("failed to look up token: %w", err)
+	}
+	if row.UserID != session.UserID {
+		return Session{}, ErrInvalidToken
+	}
+	return session, nil
+}
+
+// Revoke revokes a token, such as the one of the current session
+func (t *Tokens) Revoke(ctx context.Context, tokenID pgtype.UUID) error {
+	return db.New(t.db).RevokeToken(ctx, tokenID)
+}
+
+// RevokeUser revokes every token of a user, logging out all their sessions
+func (t *Tokens) RevokeUser(ctx context.Context, userID pgtype.UUID) error {
+	return db.New(t.db).RevokeUserTokens(ctx, userID)
+}
+
+// sign returns the encoded HS256 signature of unsigned
+func (t *Tokens) sign(unsigned string) string {
+	mac := hmac.New(sha256.New, t.key)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// parse returns the claims of a token signed with the key
+func (t *Tokens) parse(token string) (claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != header {
+		return claims{}, ErrInvalidToken
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(t.sign(parts[0]+"."+parts[1]))) {
+		return claims{}, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return claims{}, ErrInvalidToken
+	}
+	var c claims
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return claims{}, ErrInvalidToken
+	}
+	return c, nil
+}
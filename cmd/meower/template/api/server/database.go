@@ -0,0 +1,42 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/charmbracelet/log"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// pingTimeout bounds each attempt to reach the database
+const pingTimeout = 5 * time.Second
+
+// connectDB opens a connection pool to url and pings the database until it
+// answers, retrying with exponential backoff for up to timeout. The database
+// container usually starts along with the API and takes a few seconds to
+// accept connections. Failed attempts are logged to logger.
+func connectDB(ctx context.Context, logger *log.Logger, url string, timeout time.Duration) (*pgxpool.Pool, error) {
+	db, err := pgxpool.New(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database configuration: %w", err)
+	}
+
+	ping := func() error {
+		ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+		defer cancel()
+		return db.Ping(ctx)
+	}
+	notify := func(err error, wait time.Duration) {
+		logger.Warn("Database not ready, retrying", "in", wait.Round(time.Millisecond), "err", err)
+	}
+
+	policy := backoff.NewExponentialBackOff()
+	policy.MaxElapsedTime = timeout
+	if err := backoff.RetryNotify(ping, backoff.WithContext(policy, ctx), notify); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to reach the database within %v: %w", timeout, err)
+	}
+	return db, nil
+}
@@ -0,0 +1,727 @@
+package handlers
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"TEMPLATE_MODULE_PATH/api/db"
+	meowV1 "TEMPLATE_MODULE_PATH/api/proto/meow/v1"
+	"TEMPLATE_MODULE_PATH/api/server/pagination"
+	"TEMPLATE_MODULE_PATH/api/server/pubsub"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newMeowClient(t *testing.T, fake *fakeDB) meowV1.MeowServiceClient {
+	return newMeowClientWithBroker(t, fake, pubsub.NewBroker[MeowEvent]())
+}
+
+// newMeowClientWithBroker returns a client of a server publishing the meows
+// created to meows
+func newMeowClientWithBroker(t *testing.T, fake *fakeDB, meows *pubsub.Broker[MeowEvent]) meowV1.MeowServiceClient {
+	conn := startServer(t, func(g *grpc.Server) {
+		meowV1.RegisterMeowServiceServer(g, NewMeowerServer(fake, meows))
+	})
+	return meowV1.NewMeowServiceClient(conn)
+}
+
+// meowRow returns a meows table row written by the user testUUID(1)
+func meowRow(id byte, content string) []any {
+	return []any{testUUID(id), testUUID(1), content, testTimestamp(), nil, nil}
+}
+
+// authorRow returns a row of meowRow joined with the name of its author, as
+// selected by the queries listing meows, without likes, replies or reposts
+func authorRow(id byte, content string) []any {
+	return append(meowRow(id, content), "meower", "Test User", int64(0), int64(0), int64(0), false, false)
+}
+
+func TestMeowServiceCreateMeow(t *testing.T) {
+	fake := &fakeDB{rows: map[string][][]any{
+		"GetUserById": {userRow(t, "meower", "secret")},
+		"CreateMeow":  {meowRow(1, "Hello, world!")},
+	}}
+	client := newMeowClient(t, fake)
+
+	resp, err := client.CreateMeow(asUser(context.Background(), testUUID(1)), &meowV1.CreateMeowRequest{Content: "Hello, world!"})
+	if err != nil {
+		t.Fatalf("CreateMeow() error = %v", err)
+	}
+
+	meow := resp.GetMeow()
+	if meow.GetContent() != "Hello, world!" {
+		t.Errorf("Content = %q, want %q", meow.GetContent(), "Hello, world!")
+	}
+	if meow.GetId() != "01010101010101010101010101010101" {
+		t.Errorf("Id = %q", meow.GetId())
+	}
+	if !meow.GetCreatedAt().AsTime().Equal(testTimestamp().Time) {
+		t.Errorf("CreatedAt = %v, want %v", meow.GetCreatedAt().AsTime(), testTimestamp().Time)
+	}
+	if meow.GetAuthorUsername() != "meower" || meow.GetAuthorDisplayName() != "Test User" {
+		t.Errorf("author = %q (%q), want meower (Test User)", meow.GetAuthorUsername(), meow.GetAuthorDisplayName())
+	}
+}
+
+func TestMeowServiceCreateMeowAnonymous(t *testing.T) {
+	client := newMeowClient(t, &fakeDB{})
+
+	_, err := client.CreateMeow(context.Background(), &meowV1.CreateMeowRequest{Content: "Hello"})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("CreateMeow() code = %v, want %v", status.Code(err), codes.Unauthenticated)
+	}
+}
+
+func TestMeowServiceCreateMeowDBError(t *testing.T) {
+	client := newMeowClient(t, &fakeDB{err: errors.New("connection refused")})
+
+	_, err := client.CreateMeow(asUser(context.Background(), testUUID(1)), &meowV1.CreateMeowRequest{Content: "Hello"})
+	if err == nil {
+		t.Fatal("CreateMeow() error = nil, want database error")
+	}
+}
+
+func TestMeowServiceIndexMeow(t *testing.T) {
+	fake := &fakeDB{rows: map[string][][]any{
+		"IndexMeows": {
+			authorRow(2, "second"),
+			authorRow(1, "first"),
+		},
+	}}
+	client := newMeowClient(t, fake)
+
+	resp, err := client.IndexMeow(context.Background(), &meowV1.IndexMeowRequest{})
+	if err != nil {
+		t.Fatalf("IndexMeow() error = %v", err)
+	}
+
+	meows := resp.GetMeows()
+	if len(meows) != 2 {
+		t.Fatalf("got %d meows, want 2", len(meows))
+	}
+	if meows[0].GetContent() != "second" || meows[1].GetContent() != "first" {
+		t.Errorf("meows out of order: %q, %q", meows[0].GetContent(), meows[1].GetContent())
+	}
+	if meows[0].GetAuthorUsername() != "meower" {
+		t.Errorf("AuthorUsername = %q, want meower", meows[0].GetAuthorUsername())
+	}
+}
+
+func TestMeowServiceIndexMeowPages(t *testing.T) {
+	fake := &fakeDB{rows: map[string][][]any{
+		"IndexMeows": {authorRow(3, "third"), authorRow(2, "second"), authorRow(1, "first")},
+	}}
+	client := newMeowClient(t, fake)
+
+	resp, err := client.IndexMeow(context.Background(), &meowV1.IndexMeowRequest{PageSize: 2})
+	if err != nil {
+		t.Fatalf("IndexMeow() error = %v", err)
+	}
+	if len(resp.GetMeows()) != 2 {
+		t.Errorf("got %d meows, want a page of 2", len(resp.GetMeows()))
+	}
+	if resp.GetNextPageToken() == "" {
+		t.Error("NextPageToken is empty, want the token of the next page")
+	}
+
+	_, err = client.IndexMeow(context.Background(), &meowV1.IndexMeowRequest{PageToken: "meow"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("IndexMeow() with an invalid token code = %v, want %v", status.Code(err), codes.InvalidArgument)
+	}
+}
+
+func TestMeowServiceOwnership(t *testing.T) {
+	const meowID = "02020202020202020202020202020202"
+
+	tests := []struct {
+		name string
+		// caller is the user calling, the author is testUUID(1)
+		caller    byte
+		call      func(ctx context.Context, c meowV1.MeowServiceClient) error
+		wantCode  codes.Code
+		wantQuery string
+	}{
+		{
+			name:   "author updates",
+			caller: 1,
+			call: func(ctx context.Context, c meowV1.MeowServiceClient) error {
+				_, err := c.UpdateMeow(ctx, &meowV1.UpdateMeowRequest{Id: meowID, Content: "edited"})
+				return err
+			},
+			wantQuery: "UpdateMeow",
+		},
+		{
+			name:   "author deletes",
+			caller: 1,
+			call: func(ctx context.Context, c meowV1.MeowServiceClient) error {
+				_, err := c.DeleteMeow(ctx, &meowV1.DeleteMeowRequest{Id: meowID})
+				return err
+			},
+			wantQuery: "DeleteMeow",
+		},
+		{
+			name:   "other user updates",
+			caller: 3,
+			call: func(ctx context.Context, c meowV1.MeowServiceClient) error {
+				_, err := c.UpdateMeow(ctx, &meowV1.UpdateMeowRequest{Id: meowID, Content: "edited"})
+				return err
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:   "other user deletes",
+			caller: 3,
+			call: func(ctx context.Context, c meowV1.MeowServiceClient) error {
+				_, err := c.DeleteMeow(ctx, &meowV1.DeleteMeowRequest{Id: meowID})
+				return err
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:   "unknown meow",
+			caller: 1,
+			call: func(ctx context.Context, c meowV1.MeowServiceClient) error {
+				_, err := c.DeleteMeow(ctx, &meowV1.DeleteMeowRequest{Id: "09090909090909090909090909090909"})
+				return err
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:   "invalid ID",
+			caller: 1,
+			call: func(ctx context.Context, c meowV1.MeowServiceClient) error {
+				_, err := c.DeleteMeow(ctx, &meowV1.DeleteMeowRequest{Id: "meow"})
+				return err
+			},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeDB{rows: map[string][][]any{
+				"UpdateMeow": {meowRow(2, "edited")},
+			}}
+			if tt.wantCode != codes.NotFound {
+				fake.rows["ShowMeow"] = [][]any{authorRow(2, "Hello")}
+			}
+			client := newMeowClient(t, fake)
+
+			err := tt.call(asUser(context.Background(), testUUID(tt.caller)), client)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v: %v", code, tt.wantCode, err)
+			}
+			if tt.wantQuery != "" && !slices.Contains(fake.queries, tt.wantQuery) {
+				t.Errorf("queries = %v, want %s", fake.queries, tt.wantQuery)
+			}
+			if tt.wantQuery == "" && slices.ContainsFunc(fake.queries, func(q string) bool { return q == "UpdateMeow" || q == "DeleteMeow" }) {
+				t.Errorf("queries = %v, want no change", fake.queries)
+			}
+		})
+	}
+}
+
+func TestMeowServiceGetMeow(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		rows     map[string][][]any
+		wantCode codes.Code
+	}{
+		{
+			name: "found",
+			id:   "02020202020202020202020202020202",
+			rows: map[string][][]any{"ShowMeow": {authorRow(2, "Hello")}},
+		},
+		{name: "not found", id: "02020202020202020202020202020202", wantCode: codes.NotFound},
+		{name: "invalid ID", id: "1", wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newMeowClient(t, &fakeDB{rows: tt.rows})
+
+			resp, err := client.GetMeow(context.Background(), &meowV1.GetMeowRequest{Id: tt.id})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("GetMeow() code = %v, want %v", code, tt.wantCode)
+			}
+			if err == nil && (resp.GetMeow().GetId() != tt.id || resp.GetMeow().GetAuthorUsername() != "meower") {
+				t.Errorf("GetMeow() = %v", resp.GetMeow())
+			}
+		})
+	}
+}
+
+func TestMeowServiceListMeowsByUser(t *testing.T) {
+	fake := &fakeDB{rows: map[string][][]any{
+		"GetUserByUsername": {userRow(t, "meower", "secret")},
+		"ListMeowsByUser":   {authorRow(2, "second"), authorRow(1, "first")},
+	}}
+	client := newMeowClient(t, fake)
+
+	resp, err := client.ListMeowsByUser(context.Background(), &meowV1.ListMeowsByUserRequest{Username: "meower"})
+	if err != nil {
+		t.Fatalf("ListMeowsByUser() error = %v", err)
+	}
+	if len(resp.GetMeows()) != 2 || resp.GetMeows()[0].GetContent() != "second" {
+		t.Errorf("ListMeowsByUser() = %v", resp.GetMeows())
+	}
+
+	_, err = newMeowClient(t, &fakeDB{}).ListMeowsByUser(context.Background(), &meowV1.ListMeowsByUserRequest{Username: "nobody"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("ListMeowsByUser() unknown user code = %v, want %v", status.Code(err), codes.NotFound)
+	}
+}
+
+func TestMeowServiceHomeTimeline(t *testing.T) {
+	fake := &fakeDB{rows: map[string][][]any{
+		"HomeTimeline": {authorRow(2, "second"), authorRow(1, "first")},
+	}}
+	client := newMeowClient(t, fake)
+
+	resp, err := client.HomeTimeline(asUser(context.Background(), testUUID(1)), &meowV1.HomeTimelineRequest{})
+	if err != nil {
+		t.Fatalf("HomeTimeline() error = %v", err)
+	}
+	if len(resp.GetMeows()) != 2 || resp.GetMeows()[0].GetContent() != "second" {
+		t.Errorf("HomeTimeline() = %v", resp.GetMeows())
+	}
+
+	_, err = client.HomeTimeline(context.Background(), &meowV1.HomeTimelineRequest{})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("HomeTimeline() anonymous code = %v, want %v", status.Code(err), codes.Unauthenticated)
+	}
+}
+
+func TestMeowServiceGetMeowCounts(t *testing.T) {
+	row := authorRow(2, "Hello")
+	copy(row[len(row)-5:], []any{int64(3), int64(2), int64(1), true, false})
+	client := newMeowClient(t, &fakeDB{rows: map[string][][]any{"ShowMeow": {row}}})
+
+	resp, err := client.GetMeow(asUser(context.Background(), testUUID(1)), &meowV1.GetMeowRequest{Id: "02020202020202020202020202020202"})
+	if err != nil {
+		t.Fatalf("GetMeow() error = %v", err)
+	}
+	meow := resp.GetMeow()
+	if meow.GetLikeCount() != 3 || meow.GetReplyCount() != 2 || meow.GetRepostCount() != 1 {
+		t.Errorf("counts = %d likes, %d replies, %d reposts, want 3, 2, 1", meow.GetLikeCount(), meow.GetReplyCount(), meow.GetRepostCount())
+	}
+	if !meow.GetLikedByMe() || meow.GetRepostedByMe() {
+		t.Errorf("LikedByMe, RepostedByMe = %v, %v, want true, false", meow.GetLikedByMe(), meow.GetRepostedByMe())
+	}
+}
+
+func TestMeowServiceInteractions(t *testing.T) {
+	const meowID = "02020202020202020202020202020202"
+
+	tests := []struct {
+		name      string
+		anonymous bool
+		// err is returned by the database
+		err       error
+		call      func(ctx context.Context, c meowV1.MeowServiceClient) error
+		wantCode  codes.Code
+		wantQuery string
+	}{
+		{
+			name: "like",
+			call: func(ctx context.Context, c meowV1.MeowServiceClient) error {
+				_, err := c.LikeMeow(ctx, &meowV1.LikeMeowRequest{Id: meowID})
+				return err
+			},
+			wantQuery: "LikeMeow",
+		},
+		{
+			name: "unlike",
+			call: func(ctx context.Context, c meowV1.MeowServiceClient) error {
+				_, err := c.UnlikeMeow(ctx, &meowV1.UnlikeMeowRequest{Id: meowID})
+				return err
+			},
+			wantQuery: "UnlikeMeow",
+		},
+		{
+			name: "repost",
+			call: func(ctx context.Context, c meowV1.MeowServiceClient) error {
+				_, err := c.RepostMeow(ctx, &meowV1.RepostMeowRequest{Id: meowID})
+				return err
+			},
+			wantQuery: "RepostMeow",
+		},
+		{
+			name: "unrepost",
+			call: func(ctx context.Context, c meowV1.MeowServiceClient) error {
+				_, err := c.UnrepostMeow(ctx, &meowV1.UnrepostMeowRequest{Id: meowID})
+				return err
+			},
+			wantQuery: "UnrepostMeow",
+		},
+		{
+			name:      "like anonymously",
+			anonymous: true,
+			call: func(ctx context.Context, c meowV1.MeowServiceClient) error {
+				_, err := c.LikeMeow(ctx, &meowV1.LikeMeowRequest{Id: meowID})
+				return err
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "like unknown meow",
+			err:  errors.New(`insert or update on table "likes" violates foreign key constraint "likes_meow_id_fkey"`),
+			call: func(ctx context.Context, c meowV1.MeowServiceClient) error {
+				_, err := c.LikeMeow(ctx, &meowV1.LikeMeowRequest{Id: meowID})
+				return err
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeDB{rows: map[string][][]any{"ShowMeow": {authorRow(2, "Hello")}}, err: tt.err}
+			client := newMeowClient(t, fake)
+
+			ctx := context.Background()
+			if !tt.anonymous {
+				ctx = asUser(ctx, testUUID(3))
+			}
+			err := tt.call(ctx, client)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("code = %v, want %v (%v)", status.Code(err), tt.wantCode, err)
+			}
+			if tt.wantQuery != "" && !slices.Equal(fake.queries, []string{tt.wantQuery, "ShowMeow"}) {
+				t.Errorf("queries = %v, want [%s ShowMeow]", fake.queries, tt.wantQuery)
+			}
+		})
+	}
+}
+
+func TestMeowServiceReplies(t *testing.T) {
+	reply := meowRow(3, "reply")
+	reply[4] = testUUID(2)
+	fake := &fakeDB{rows: map[string][][]any{
+		"GetUserById":       {userRow(t, "meower", "secret")},
+		"CreateMeow":        {reply},
+		"ListMeowsByParent": {authorRow(3, "reply")},
+	}}
+	client := newMeowClient(t, fake)
+	ctx := asUser(context.Background(), testUUID(1))
+
+	resp, err := client.CreateMeow(ctx, &meowV1.CreateMeowRequest{Content: "reply", ParentId: "02020202020202020202020202020202"})
+	if err != nil {
+		t.Fatalf("CreateMeow() error = %v", err)
+	}
+	if resp.GetMeow().GetParentId() != "02020202020202020202020202020202" {
+		t.Errorf("ParentId = %q, want the parent meow", resp.GetMeow().GetParentId())
+	}
+
+	_, err = client.CreateMeow(ctx, &meowV1.CreateMeowRequest{Content: "reply", ParentId: "meow"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CreateMeow() with an invalid parent code = %v, want %v", status.Code(err), codes.InvalidArgument)
+	}
+
+	replies, err := client.ListMeowsByParent(context.Background(), &meowV1.ListMeowsByParentRequest{ParentId: "02020202020202020202020202020202"})
+	if err != nil {
+		t.Fatalf("ListMeowsByParent() error = %v", err)
+	}
+	if len(replies.GetMeows()) != 1 || replies.GetMeows()[0].GetContent() != "reply" {
+		t.Errorf("ListMeowsByParent() = %v", replies.GetMeows())
+	}
+}
+
+func TestMeowServiceCreateMeowLinks(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantQueries []string
+	}{
+		{
+			name:        "plain meow",
+			content:     "Hello, world!",
+			wantQueries: []string{"GetUserById", "CreateMeow", "DeleteMeowTags", "DeleteMeowMentions", "COMMIT"},
+		},
+		{
+			name:        "tags and mentions",
+			content:     "#Cats are great, right @kitten?",
+			wantQueries: []string{"GetUserById", "CreateMeow", "DeleteMeowTags", "AddMeowTags", "DeleteMeowMentions", "AddMeowMentions", "COMMIT"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeDB{rows: map[string][][]any{
+				"GetUserById": {userRow(t, "meower", "secret")},
+				"CreateMeow":  {meowRow(1, tt.content)},
+			}}
+			client := newMeowClient(t, fake)
+
+			_, err := client.CreateMeow(asUser(context.Background(), testUUID(1)), &meowV1.CreateMeowRequest{Content: tt.content})
+			if err != nil {
+				t.Fatalf("CreateMeow() error = %v", err)
+			}
+			if !slices.Equal(fake.queries, tt.wantQueries) {
+				t.Errorf("queries = %v, want %v", fake.queries, tt.wantQueries)
+			}
+		})
+	}
+}
+
+func TestMeowServiceListMeowsByTag(t *testing.T) {
+	fake := &fakeDB{rows: map[string][][]any{
+		"ListMeowsByTag": {authorRow(2, "second #cats"), authorRow(1, "first #cats")},
+	}}
+	client := newMeowClient(t, fake)
+
+	resp, err := client.ListMeowsByTag(context.Background(), &meowV1.ListMeowsByTagRequest{Tag: "#Cats"})
+	if err != nil {
+		t.Fatalf("ListMeowsByTag() error = %v", err)
+	}
+	if len(resp.GetMeows()) != 2 || resp.GetMeows()[0].GetContent() != "second #cats" {
+		t.Errorf("ListMeowsByTag() = %v", resp.GetMeows())
+	}
+
+	_, err = client.ListMeowsByTag(context.Background(), &meowV1.ListMeowsByTagRequest{Tag: "#"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListMeowsByTag() without a tag code = %v, want %v", status.Code(err), codes.InvalidArgument)
+	}
+}
+
+func TestMeowServiceSearchMeows(t *testing.T) {
+	fake := &fakeDB{rows: map[string][][]any{
+		"SearchMeows": {
+			append(authorRow(2, "cats cats"), float32(0.2)),
+			append(authorRow(1, "cats"), float32(0.1)),
+		},
+	}}
+	client := newMeowClient(t, fake)
+
+	resp, err := client.SearchMeows(context.Background(), &meowV1.SearchMeowsRequest{Query: "cats", PageSize: 1})
+	if err != nil {
+		t.Fatalf("SearchMeows() error = %v", err)
+	}
+	if len(resp.GetMeows()) != 1 || resp.GetMeows()[0].GetContent() != "cats cats" {
+		t.Errorf("SearchMeows() = %v, want the best match", resp.GetMeows())
+	}
+	if resp.GetNextPageToken() == "" {
+		t.Fatal("NextPageToken is empty, want the token of the next page")
+	}
+
+	_, err = client.SearchMeows(context.Background(), &meowV1.SearchMeowsRequest{Query: "cats", PageToken: resp.GetNextPageToken()})
+	if err != nil {
+		t.Errorf("SearchMeows() with the next page token error = %v", err)
+	}
+
+	_, err = client.SearchMeows(context.Background(), &meowV1.SearchMeowsRequest{Query: "dogs", PageToken: resp.GetNextPageToken()})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("SearchMeows() with the token of another query code = %v, want %v", status.Code(err), codes.InvalidArgument)
+	}
+
+	_, err = client.SearchMeows(context.Background(), &meowV1.SearchMeowsRequest{Query: "  "})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("SearchMeows() without a query code = %v, want %v", status.Code(err), codes.InvalidArgument)
+	}
+}
+
+func TestMeowServiceCreateMeowPublishes(t *testing.T) {
+	fake := &fakeDB{rows: map[string][][]any{
+		"GetUserById": {userRow(t, "meower", "secret")},
+		"CreateMeow":  {meowRow(1, "Hello, world!")},
+	}}
+	meows := pubsub.NewBroker[MeowEvent]()
+	events, unsubscribe := meows.Subscribe()
+	defer unsubscribe()
+	client := newMeowClientWithBroker(t, fake, meows)
+
+	if _, err := client.CreateMeow(asUser(context.Background(), testUUID(1)), &meowV1.CreateMeowRequest{Content: "Hello, world!"}); err != nil {
+		t.Fatalf("CreateMeow() error = %v", err)
+	}
+	if event := <-events; event.Meow.ID != testUUID(1) || event.Username != "meower" {
+		t.Errorf("published meow %v by %q, want %v by meower", event.Meow.ID, event.Username, testUUID(1))
+	}
+}
+
+func TestMeowServiceCreateMeowRollsBack(t *testing.T) {
+	fake := &fakeDB{
+		rows: map[string][][]any{
+			"GetUserById": {userRow(t, "meower", "secret")},
+			"CreateMeow":  {meowRow(1, "#cats")},
+		},
+		err:       errors.New("connection reset"),
+		failQuery: "AddMeowTags",
+	}
+	meows := pubsub.NewBroker[MeowEvent]()
+	events, unsubscribe := meows.Subscribe()
+	defer unsubscribe()
+	client := newMeowClientWithBroker(t, fake, meows)
+
+	_, err := client.CreateMeow(asUser(context.Background(), testUUID(1)), &meowV1.CreateMeowRequest{Content: "#cats"})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("CreateMeow() code = %v, want %v", status.Code(err), codes.Internal)
+	}
+
+	want := []string{"GetUserById", "CreateMeow", "DeleteMeowTags", "AddMeowTags", "ROLLBACK"}
+	if !slices.Equal(fake.queries, want) {
+		t.Errorf("queries = %v, want %v", fake.queries, want)
+	}
+	select {
+	case event := <-events:
+		t.Errorf("published meow %v of a rolled back transaction", event.Meow.ID)
+	default:
+	}
+}
+
+func TestMeowServiceWatchMeows(t *testing.T) {
+	later := testTimestamp()
+	later.Time = later.Time.Add(time.Second)
+
+	tests := []struct {
+		name   string
+		caller byte
+		home   bool
+		// published is the meow created once the replay is received
+		published db.Meow
+		following bool
+		wantLive  bool
+	}{
+		{
+			name:      "all meows",
+			published: db.Meow{ID: testUUID(3), UserID: testUUID(5), CreatedAt: later},
+			wantLive:  true,
+		},
+		{
+			name:   "home, own meow",
+			caller: 1, home: true,
+			published: db.Meow{ID: testUUID(3), UserID: testUUID(1), CreatedAt: later},
+			wantLive:  true,
+		},
+		{
+			name:   "home, followed user",
+			caller: 1, home: true,
+			published: db.Meow{ID: testUUID(3), UserID: testUUID(5), CreatedAt: later},
+			following: true,
+			wantLive:  true,
+		},
+		{
+			name:   "home, other user",
+			caller: 1, home: true,
+			published: db.Meow{ID: testUUID(3), UserID: testUUID(5), CreatedAt: later},
+		},
+		{
+			name:      "already replayed",
+			published: db.Meow{ID: testUUID(2), UserID: testUUID(1), CreatedAt: testTimestamp()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeDB{rows: map[string][][]any{
+				"ListMeowsSince": {authorRow(2, "missed")},
+			}}
+			if tt.following {
+				fake.rows["ListFollowedIDs"] = [][]any{{testUUID(5)}}
+			}
+			meows := pubsub.NewBroker[MeowEvent]()
+			client := newMeowClientWithBroker(t, fake, meows)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			if tt.caller != 0 {
+				ctx = asUser(ctx, testUUID(tt.caller))
+			}
+			after := pagination.Cursor{CreatedAt: testTimestamp(), ID: testUUID(1)}.Token()
+			stream, err := client.WatchMeows(ctx, &meowV1.WatchMeowsRequest{Home: tt.home, AfterEventId: after})
+			if err != nil {
+				t.Fatalf("WatchMeows() error = %v", err)
+			}
+
+			// The stream subscribed before replaying the meows since the last event
+			replayed, err := stream.Recv()
+			if err != nil {
+				t.Fatalf("Recv() replay error = %v", err)
+			}
+			if replayed.GetMeow().GetContent() != "missed" || replayed.GetEventId() == "" {
+				t.Errorf("replayed %v, want the missed meow and its event ID", replayed)
+			}
+
+			tt.published.Content = "live"
+			meows.Publish(MeowEvent{Meow: tt.published, Username: "cat", DisplayName: "Cat"})
+			live, err := stream.Recv()
+			if !tt.wantLive {
+				if status.Code(err) != codes.DeadlineExceeded {
+					t.Errorf("Recv() = %v, %v, want no meow until the deadline", live, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Recv() live error = %v", err)
+			}
+			if live.GetMeow().GetContent() != "live" || live.GetMeow().GetAuthorUsername() != "cat" {
+				t.Errorf("streamed %v, want the live meow by cat", live.GetMeow())
+			}
+		})
+	}
+}
+
+func TestMeowServiceWatchMeowsOutOfOrder(t *testing.T) {
+	later := testTimestamp()
+	later.Time = later.Time.Add(time.Second)
+
+	fake := &fakeDB{rows: map[string][][]any{
+		"ListMeowsSince": {authorRow(2, "missed")},
+	}}
+	meows := pubsub.NewBroker[MeowEvent]()
+	client := newMeowClientWithBroker(t, fake, meows)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	after := pagination.Cursor{CreatedAt: testTimestamp(), ID: testUUID(1)}.Token()
+	stream, err := client.WatchMeows(ctx, &meowV1.WatchMeowsRequest{AfterEventId: after})
+	if err != nil {
+		t.Fatalf("WatchMeows() error = %v", err)
+	}
+	// The stream subscribed before replaying
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv() replay error = %v", err)
+	}
+
+	// Committed in the opposite order of their creation
+	meows.Publish(MeowEvent{Meow: db.Meow{ID: testUUID(4), UserID: testUUID(5), CreatedAt: later}})
+	meows.Publish(MeowEvent{Meow: db.Meow{ID: testUUID(3), UserID: testUUID(5), CreatedAt: testTimestamp()}})
+	for _, want := range []pgtype.UUID{testUUID(4), testUUID(3)} {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv() error = %v, want meow %x", err, want.Bytes)
+		}
+		if got := resp.GetMeow().GetId(); got != hex.EncodeToString(want.Bytes[:]) {
+			t.Errorf("streamed meow %s, want %x", got, want.Bytes)
+		}
+	}
+}
+
+func TestMeowServiceWatchMeowsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *meowV1.WatchMeowsRequest
+		wantCode codes.Code
+	}{
+		{name: "anonymous home", req: &meowV1.WatchMeowsRequest{Home: true}, wantCode: codes.Unauthenticated},
+		{name: "invalid event ID", req: &meowV1.WatchMeowsRequest{AfterEventId: "meow"}, wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := newMeowClient(t, &fakeDB{}).WatchMeows(context.Background(), tt.req)
+			if err == nil {
+				_, err = stream.Recv()
+			}
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("code = %v, want %v (%v)", status.Code(err), tt.wantCode, err)
+			}
+		})
+	}
+}
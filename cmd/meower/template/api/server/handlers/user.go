@@ -10,8 +10,9 @@ import (
 
 	"TEMPLATE_MODULE_PATH/api/db"
 	userV1 "TEMPLATE_MODULE_PATH/api/proto/user/v1"
+	"TEMPLATE_MODULE_PATH/api/server/auth"
+	"TEMPLATE_MODULE_PATH/api/server/pagination"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -20,11 +21,12 @@ import (
 
 type userServiceServer struct {
 	userV1.UnimplementedUserServiceServer
-	db *pgxpool.Pool
+	db     db.DBTX
+	tokens *auth.Tokens
 }
 
-func NewUserServer(db *pgxpool.Pool) userV1.UserServiceServer {
-	return &userServiceServer{db: db}
+func NewUserServer(dbtx db.DBTX, tokens *auth.Tokens) userV1.UserServiceServer {
+	return &userServiceServer{db: dbtx, tokens: tokens}
 }
 
 // Helper function to convert DB user to proto user
@@ -50,6 +52,21 @@ func (s *userServiceServer) dbUserToProto(user db.User) *userV1.User {
 	return protoUser
 }
 
+// Helper function to convert DB user to the proto profile anyone can see
+func (s *userServiceServer) dbUserToProfile(user db.User) *userV1.Profile {
+	profile := &userV1.Profile{
+		Id:          hex.EncodeToString(user.ID.Bytes[:]),
+		Username:    user.Username,
+		DisplayName: user.DisplayName,
+	}
+
+	if user.CreatedAt.Valid {
+		profile.CreatedAt = timestamppb.New(user.CreatedAt.Time)
+	}
+
+	return profile
+}
+
 // Helper function to hash password
 func hashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -71,6 +88,27 @@ func generateToken(length int) (string, error) {
 	return hex.EncodeToString(bytes), nil
 }
 
+// Helper function to store a new email verification token for user and send
+// it to their email
+func (s *userServiceServer) sendEmailVerification(ctx context.Context, user db.User) error {
+	token, err := generateToken(32)
+	if err != nil {
+		return fmt.Errorf("failed to generate verification token: %w", err)
+	}
+
+	_, err = db.New(s.db).UpdateEmailVerificationToken(ctx, db.UpdateEmailVerificationTokenParams{
+		ID:                     user.ID,
+		EmailVerificationToken: pgtype.Text{String: token, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save verification token: %w", err)
+	}
+
+	// In a real implementation, send email with token
+	fmt.Printf("Email verification token for %s: %s\n", user.Email, token)
+	return nil
+}
+
 // Helper function to parse UUID string to pgtype.UUID
 func parseUUID(uuidStr string) (pgtype.UUID, error) {
 	var uuid pgtype.UUID
@@ -78,6 +116,15 @@ func parseUUID(uuidStr string) (pgtype.UUID, error) {
 	return uuid, err
 }
 
+// Helper function to check that the caller is the user, the only one allowed
+// to change their account
+func requireSelf(ctx context.Context, userID pgtype.UUID) error {
+	if callerID, ok := auth.UserID(ctx); !ok || callerID != userID {
+		return status.Errorf(codes.PermissionDenied, "you can only change your own account")
+	}
+	return nil
+}
+
 // CreateUser creates a new user
 func (s *userServiceServer) CreateUser(ctx context.Context, req *userV1.CreateUserRequest) (*userV1.CreateUserResponse, error) {
 	// Hash the password
@@ -105,12 +152,17 @@ func (s *userServiceServer) CreateUser(ctx context.Context, req *userV1.CreateUs
 		return nil, status.Errorf(codes.Internal, "failed to create user: %v", err)
 	}
 
+	if err := s.sendEmailVerification(ctx, user); err != nil {
+		// Log error but don't fail the sign up, it can be requested again
+		fmt.Printf("Failed to send email verification: %v\n", err)
+	}
+
 	return &userV1.CreateUserResponse{
 		User: s.dbUserToProto(user),
 	}, nil
 }
 
-// GetUser gets a user by ID
+// GetUser gets the public profile of a user by ID
 func (s *userServiceServer) GetUser(ctx context.Context, req *userV1.GetUserRequest) (*userV1.GetUserResponse, error) {
 	uuid, err := parseUUID(req.Id)
 	if err != nil {
@@ -123,15 +175,16 @@ func (s *userServiceServer) GetUser(ctx context.Context, req *userV1.GetUserRequ
 	}
 
 	return &userV1.GetUserResponse{
-		User: s.dbUserToProto(user),
+		Profile: s.dbUserToProfile(user),
 	}, nil
 }
 
-// GetUserByEmail gets a user by email
+// GetUserByEmail gets the account of the caller by email. Other accounts are
+// reported as not found, so that it doesn't reveal which emails are registered.
 func (s *userServiceServer) GetUserByEmail(ctx context.Context, req *userV1.GetUserByEmailRequest) (*userV1.GetUserByEmailResponse, error) {
 	user, err := db.New(s.db).GetUserByEmail(ctx, req.Email)
-	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "user not found: %v", err)
+	if callerID, _ := auth.UserID(ctx); err != nil || user.ID != callerID {
+		return nil, status.Errorf(codes.NotFound, "user not found")
 	}
 
 	return &userV1.GetUserByEmailResponse{
@@ -139,7 +192,7 @@ func (s *userServiceServer) GetUserByEmail(ctx context.Context, req *userV1.GetU
 	}, nil
 }
 
-// GetUserByUsername gets a user by username
+// GetUserByUsername gets the public profile of a user by username
 func (s *userServiceServer) GetUserByUsername(ctx context.Context, req *userV1.GetUserByUsernameRequest) (*userV1.GetUserByUsernameResponse, error) {
 	user, err := db.New(s.db).GetUserByUsername(ctx, req.Username)
 	if err != nil {
@@ -147,7 +200,7 @@ func (s *userServiceServer) GetUserByUsername(ctx context.Context, req *userV1.G
 	}
 
 	return &userV1.GetUserByUsernameResponse{
-		User: s.dbUserToProto(user),
+		Profile: s.dbUserToProfile(user),
 	}, nil
 }
 
@@ -157,6 +210,9 @@ func (s *userServiceServer) UpdateUser(ctx context.Context, req *userV1.UpdateUs
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID: %v", err)
 	}
+	if err := requireSelf(ctx, uuid); err != nil {
+		return nil, err
+	}
 
 	// Prepare update parameters
 	updateParams := db.UpdateUserParams{
@@ -168,12 +224,10 @@ func (s *userServiceServer) UpdateUser(ctx context.Context, req *userV1.UpdateUs
 		updateParams.DisplayName = req.DisplayName.Value
 	}
 
+	// A new email needs to be verified again
 	if req.Email != nil {
 		updateParams.Email = req.Email.Value
-	}
-
-	if req.EmailVerified != nil {
-		updateParams.EmailVerified = pgtype.Bool{Bool: req.EmailVerified.Value, Valid: true}
+		updateParams.EmailVerified = pgtype.Bool{Bool: false, Valid: true}
 	}
 
 	if req.Password != nil {
@@ -189,6 +243,13 @@ func (s *userServiceServer) UpdateUser(ctx context.Context, req *userV1.UpdateUs
 		return nil, status.Errorf(codes.Internal, "failed to update user: %v", err)
 	}
 
+	if req.Email != nil {
+		if err := s.sendEmailVerification(ctx, user); err != nil {
+			// Log error but don't fail the update, it can be requested again
+			fmt.Printf("Failed to send email verification: %v\n", err)
+		}
+	}
+
 	return &userV1.UpdateUserResponse{
 		User: s.dbUserToProto(user),
 	}, nil
@@ -200,6 +261,9 @@ func (s *userServiceServer) DeleteUser(ctx context.Context, req *userV1.DeleteUs
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID: %v", err)
 	}
+	if err := requireSelf(ctx, uuid); err != nil {
+		return nil, err
+	}
 
 	err = db.New(s.db).DeleteUser(ctx, uuid)
 	if err != nil {
@@ -209,36 +273,34 @@ func (s *userServiceServer) DeleteUser(ctx context.Context, req *userV1.DeleteUs
 	return &userV1.DeleteUserResponse{}, nil
 }
 
-// ListUsers lists users with pagination
+// ListUsers lists the public profiles of users with pagination
 func (s *userServiceServer) ListUsers(ctx context.Context, req *userV1.ListUsersRequest) (*userV1.ListUsersResponse, error) {
-	limit := req.Limit
-	if limit <= 0 {
-		limit = 50 // Default limit
-	}
-	if limit > 100 {
-		limit = 100 // Max limit
-	}
-
-	offset := req.Offset
-	if offset < 0 {
-		offset = 0
+	cursor, err := pagination.Decode(req.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
+	size := pagination.Size(req.PageSize)
 
 	users, err := db.New(s.db).IndexUsers(ctx, db.IndexUsersParams{
-		Limit:  limit,
-		Offset: offset,
+		BeforeCreatedAt: cursor.CreatedAt,
+		BeforeID:        cursor.ID,
+		PageSize:        size + 1,
 	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list users: %v", err)
 	}
+	users, next := pagination.Page(users, size, func(user db.User) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: user.CreatedAt, ID: user.ID}
+	})
 
-	var protoUsers []*userV1.User
+	var profiles []*userV1.Profile
 	for _, user := range users {
-		protoUsers = append(protoUsers, s.dbUserToProto(user))
+		profiles = append(profiles, s.dbUserToProfile(user))
 	}
 
 	return &userV1.ListUsersResponse{
-		Users: protoUsers,
+		Profiles:      profiles,
+		NextPageToken: next,
 	}, nil
 }
 
@@ -302,10 +364,10 @@ func (s *userServiceServer) Login(ctx context.Context, req *userV1.LoginRequest)
 		fmt.Printf("Failed to update last login time: %v\n", err)
 	}
 
-	// Generate a simple token (in production, use JWT)
-	token, err := generateToken(32)
+	// Issue a signed session token, revoked by Logout
+	token, err := s.tokens.Issue(ctx, user.ID)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to generate token: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to issue token: %v", err)
 	}
 
 	return &userV1.LoginResponse{
@@ -314,10 +376,17 @@ func (s *userServiceServer) Login(ctx context.Context, req *userV1.LoginRequest)
 	}, nil
 }
 
-// Logout logs out a user
+// Logout revokes the session token of the caller
 func (s *userServiceServer) Logout(ctx context.Context, req *userV1.LogoutRequest) (*userV1.LogoutResponse, error) {
-	// In a real implementation, you would invalidate the token
-	// For now, just return success
+	session, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "missing session token")
+	}
+
+	if err := s.tokens.Revoke(ctx, session.TokenID); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke token: %v", err)
+	}
+
 	return &userV1.LogoutResponse{
 		Success: true,
 	}, nil
@@ -381,6 +450,11 @@ func (s *userServiceServer) ResetPassword(ctx context.Context, req *userV1.Reset
 		return nil, status.Errorf(codes.Internal, "failed to update password: %v", err)
 	}
 
+	// Log out every session, the password may have been stolen
+	if err := s.tokens.RevokeUser(ctx, user.ID); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke tokens: %v", err)
+	}
+
 	// Clear reset token
 	_, err = db.New(s.db).UpdatePasswordResetToken(ctx, db.UpdatePasswordResetTokenParams{
 		ID:                   user.ID,
@@ -397,16 +471,39 @@ func (s *userServiceServer) ResetPassword(ctx context.Context, req *userV1.Reset
 	}, nil
 }
 
-// VerifyEmail verifies a user's email
-func (s *userServiceServer) VerifyEmail(ctx context.Context, req *userV1.VerifyEmailRequest) (*userV1.VerifyEmailResponse, error) {
-	uuid, err := parseUUID(req.Id)
+// RequestEmailVerification sends a new verification token to the email of the caller
+func (s *userServiceServer) RequestEmailVerification(ctx context.Context, req *userV1.RequestEmailVerificationRequest) (*userV1.RequestEmailVerificationResponse, error) {
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "log in to verify your email")
+	}
+
+	user, err := db.New(s.db).GetUserById(ctx, userID)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID: %v", err)
+		return nil, status.Errorf(codes.NotFound, "user not found: %v", err)
+	}
+	if user.EmailVerified.Bool {
+		return nil, status.Errorf(codes.FailedPrecondition, "email already verified")
+	}
+
+	if err := s.sendEmailVerification(ctx, user); err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return &userV1.RequestEmailVerificationResponse{
+		Success: true,
+	}, nil
+}
+
+// VerifyEmail verifies the email that a verification token was sent to
+func (s *userServiceServer) VerifyEmail(ctx context.Context, req *userV1.VerifyEmailRequest) (*userV1.VerifyEmailResponse, error) {
+	if req.Token == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "missing verification token")
 	}
 
-	_, err = db.New(s.db).VerifyEmail(ctx, uuid)
+	_, err := db.New(s.db).VerifyEmail(ctx, pgtype.Text{String: req.Token, Valid: true})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to verify email: %v", err)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid verification token")
 	}
 
 	return &userV1.VerifyEmailResponse{
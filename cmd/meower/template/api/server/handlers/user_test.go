@@ -0,0 +1,330 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	userV1 "TEMPLATE_MODULE_PATH/api/proto/user/v1"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newUserClient(t *testing.T, fake *fakeDB) userV1.UserServiceClient {
+	conn := startServer(t, func(g *grpc.Server) {
+		userV1.RegisterUserServiceServer(g, NewUserServer(fake, newTokens(t, fake)))
+	})
+	return userV1.NewUserServiceClient(conn)
+}
+
+// tokenRow returns a tokens table row of the user
+func tokenRow(userID pgtype.UUID) []any {
+	return []any{
+		testUUID(9),     // id
+		userID,          // user_id
+		testTimestamp(), // created_at
+		pgtype.Timestamp{Time: time.Now().Add(time.Hour), Valid: true}, // expires_at
+		pgtype.Timestamp{}, // revoked_at
+	}
+}
+
+// userRow returns a users table row for username with the given password
+func userRow(t *testing.T, username, password string) []any {
+	t.Helper()
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+	return []any{
+		testUUID(1),                        // id
+		username,                           // username
+		"Test User",                        // display_name
+		username + "@example.com",          // email
+		pgtype.Bool{Valid: true},           // email_verified
+		pgtype.Text{},                      // email_verification_token
+		hash,                               // password_hash
+		pgtype.Text{},                      // reset_password_token
+		pgtype.Timestamp{},                 // reset_password_expires
+		testTimestamp(),                    // created_at
+		pgtype.Timestamp{},                 // last_login_at
+		pgtype.Bool{Valid: true},           // account_locked
+		pgtype.Int4{Int32: 0, Valid: true}, // failed_login_attempts
+	}
+}
+
+func TestUserServiceCreateUser(t *testing.T) {
+	fake := &fakeDB{rows: map[string][][]any{
+		"CreateUser":                   {userRow(t, "meower", "secret")},
+		"UpdateEmailVerificationToken": {userRow(t, "meower", "secret")},
+	}}
+	client := newUserClient(t, fake)
+
+	resp, err := client.CreateUser(context.Background(), &userV1.CreateUserRequest{
+		Username:    "meower",
+		DisplayName: "Test User",
+		Email:       "meower@example.com",
+		Password:    "secret",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+	if resp.GetUser().GetUsername() != "meower" {
+		t.Errorf("Username = %q, want meower", resp.GetUser().GetUsername())
+	}
+	if resp.GetUser().GetEmail() != "meower@example.com" {
+		t.Errorf("Email = %q, want meower@example.com", resp.GetUser().GetEmail())
+	}
+	if !slices.Contains(fake.queries, "UpdateEmailVerificationToken") {
+		t.Errorf("queries = %v, want UpdateEmailVerificationToken", fake.queries)
+	}
+}
+
+func TestUserServiceEmailVerification(t *testing.T) {
+	row := userRow(t, "meower", "secret")
+	fake := &fakeDB{rows: map[string][][]any{
+		"GetUserById":                  {row},
+		"UpdateEmailVerificationToken": {row},
+		"VerifyEmail":                  {row},
+	}}
+	client := newUserClient(t, fake)
+
+	requested, err := client.RequestEmailVerification(asUser(context.Background(), testUUID(1)), &userV1.RequestEmailVerificationRequest{})
+	if err != nil {
+		t.Fatalf("RequestEmailVerification() error = %v", err)
+	}
+	if !requested.GetSuccess() || !slices.Contains(fake.queries, "UpdateEmailVerificationToken") {
+		t.Errorf("RequestEmailVerification() did not store a token, queries = %v", fake.queries)
+	}
+
+	verified, err := client.VerifyEmail(context.Background(), &userV1.VerifyEmailRequest{Token: "0123abcd"})
+	if err != nil {
+		t.Fatalf("VerifyEmail() error = %v", err)
+	}
+	if !verified.GetSuccess() {
+		t.Error("VerifyEmail() success = false")
+	}
+}
+
+func TestUserServiceGetProfile(t *testing.T) {
+	fake := &fakeDB{rows: map[string][][]any{
+		"GetUserById":       {userRow(t, "meower", "secret")},
+		"GetUserByUsername": {userRow(t, "meower", "secret")},
+	}}
+	client := newUserClient(t, fake)
+
+	byID, err := client.GetUser(context.Background(), &userV1.GetUserRequest{Id: "01010101010101010101010101010101"})
+	if err != nil {
+		t.Fatalf("GetUser() error = %v", err)
+	}
+	byUsername, err := client.GetUserByUsername(context.Background(), &userV1.GetUserByUsernameRequest{Username: "meower"})
+	if err != nil {
+		t.Fatalf("GetUserByUsername() error = %v", err)
+	}
+
+	for _, profile := range []*userV1.Profile{byID.GetProfile(), byUsername.GetProfile()} {
+		if profile.GetUsername() != "meower" || profile.GetDisplayName() != "Test User" {
+			t.Errorf("profile = %v, want meower (Test User)", profile)
+		}
+		if profile.GetCreatedAt() == nil {
+			t.Errorf("profile = %v, want its creation time", profile)
+		}
+	}
+}
+
+func TestUserServiceGetUserByEmail(t *testing.T) {
+	fake := &fakeDB{rows: map[string][][]any{
+		"GetUserByEmail": {userRow(t, "meower", "secret")},
+	}}
+	client := newUserClient(t, fake)
+
+	resp, err := client.GetUserByEmail(asUser(context.Background(), testUUID(1)), &userV1.GetUserByEmailRequest{Email: "meower@example.com"})
+	if err != nil {
+		t.Fatalf("GetUserByEmail() error = %v", err)
+	}
+	if resp.GetUser().GetEmail() != "meower@example.com" {
+		t.Errorf("Email = %q, want meower@example.com", resp.GetUser().GetEmail())
+	}
+}
+
+func TestUserServiceListUsers(t *testing.T) {
+	fake := &fakeDB{rows: map[string][][]any{
+		"IndexUsers": {userRow(t, "meower", "secret")},
+	}}
+	client := newUserClient(t, fake)
+
+	resp, err := client.ListUsers(asUser(context.Background(), testUUID(2)), &userV1.ListUsersRequest{})
+	if err != nil {
+		t.Fatalf("ListUsers() error = %v", err)
+	}
+	if len(resp.GetProfiles()) != 1 || resp.GetProfiles()[0].GetUsername() != "meower" {
+		t.Errorf("ListUsers() = %v, want the profile of meower", resp.GetProfiles())
+	}
+}
+
+func TestUserServiceErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		rows map[string][][]any
+		call func(userV1.UserServiceClient) error
+		want codes.Code
+	}{
+		{
+			name: "get user with invalid id",
+			call: func(c userV1.UserServiceClient) error {
+				_, err := c.GetUser(context.Background(), &userV1.GetUserRequest{Id: "not-a-uuid"})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "get missing user",
+			call: func(c userV1.UserServiceClient) error {
+				_, err := c.GetUserByUsername(context.Background(), &userV1.GetUserByUsernameRequest{Username: "nobody"})
+				return err
+			},
+			want: codes.NotFound,
+		},
+		{
+			name: "get another user by email",
+			rows: map[string][][]any{"GetUserByEmail": {userRow(t, "meower", "secret")}},
+			call: func(c userV1.UserServiceClient) error {
+				ctx := asUser(context.Background(), testUUID(2))
+				_, err := c.GetUserByEmail(ctx, &userV1.GetUserByEmailRequest{Email: "meower@example.com"})
+				return err
+			},
+			want: codes.NotFound,
+		},
+		{
+			name: "update another user",
+			call: func(c userV1.UserServiceClient) error {
+				ctx := asUser(context.Background(), testUUID(2))
+				_, err := c.UpdateUser(ctx, &userV1.UpdateUserRequest{Id: "01010101010101010101010101010101"})
+				return err
+			},
+			want: codes.PermissionDenied,
+		},
+		{
+			name: "delete user anonymously",
+			call: func(c userV1.UserServiceClient) error {
+				_, err := c.DeleteUser(context.Background(), &userV1.DeleteUserRequest{Id: "01010101010101010101010101010101"})
+				return err
+			},
+			want: codes.PermissionDenied,
+		},
+		{
+			name: "request email verification anonymously",
+			call: func(c userV1.UserServiceClient) error {
+				_, err := c.RequestEmailVerification(context.Background(), &userV1.RequestEmailVerificationRequest{})
+				return err
+			},
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "verify email without token",
+			call: func(c userV1.UserServiceClient) error {
+				_, err := c.VerifyEmail(context.Background(), &userV1.VerifyEmailRequest{})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "verify email with unknown token",
+			call: func(c userV1.UserServiceClient) error {
+				_, err := c.VerifyEmail(context.Background(), &userV1.VerifyEmailRequest{Token: "0123abcd"})
+				return err
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "logout anonymously",
+			call: func(c userV1.UserServiceClient) error {
+				_, err := c.Logout(context.Background(), &userV1.LogoutRequest{})
+				return err
+			},
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "login with unknown user",
+			call: func(c userV1.UserServiceClient) error {
+				_, err := c.Login(context.Background(), &userV1.LoginRequest{UsernameOrEmail: "nobody", Password: "secret"})
+				return err
+			},
+			want: codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newUserClient(t, &fakeDB{rows: tt.rows})
+			if err := tt.call(client); status.Code(err) != tt.want {
+				t.Errorf("code = %v, want %v (error: %v)", status.Code(err), tt.want, err)
+			}
+		})
+	}
+}
+
+func TestUserServiceLogin(t *testing.T) {
+	t.Run("valid credentials", func(t *testing.T) {
+		row := userRow(t, "meower", "secret")
+		fake := &fakeDB{rows: map[string][][]any{
+			"GetUserByUsername":   {row},
+			"UpdateLoginAttempts": {row},
+			"UpdateLastLoginAt":   {row},
+			"CreateToken":         {tokenRow(testUUID(1))},
+			"GetActiveToken":      {tokenRow(testUUID(1))},
+		}}
+		client := newUserClient(t, fake)
+
+		resp, err := client.Login(context.Background(), &userV1.LoginRequest{UsernameOrEmail: "meower", Password: "secret"})
+		if err != nil {
+			t.Fatalf("Login() error = %v", err)
+		}
+		session, err := newTokens(t, fake).Verify(context.Background(), resp.GetToken())
+		if err != nil {
+			t.Fatalf("Login() returned an invalid token: %v", err)
+		}
+		if session.UserID != testUUID(1) {
+			t.Errorf("token user = %x, want %x", session.UserID.Bytes, testUUID(1).Bytes)
+		}
+		if resp.GetUser().GetUsername() != "meower" {
+			t.Errorf("Username = %q, want meower", resp.GetUser().GetUsername())
+		}
+	})
+
+	t.Run("wrong password counts the failed attempt", func(t *testing.T) {
+		row := userRow(t, "meower", "secret")
+		fake := &fakeDB{rows: map[string][][]any{
+			"GetUserByEmail":      {row},
+			"UpdateLoginAttempts": {row},
+		}}
+		client := newUserClient(t, fake)
+
+		_, err := client.Login(context.Background(), &userV1.LoginRequest{UsernameOrEmail: "meower@example.com", Password: "wrong"})
+		if status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("Login() code = %v, want %v", status.Code(err), codes.Unauthenticated)
+		}
+		if !slices.Contains(fake.queries, "UpdateLoginAttempts") {
+			t.Errorf("queries = %v, want UpdateLoginAttempts", fake.queries)
+		}
+	})
+}
+
+func TestUserServiceLogout(t *testing.T) {
+	fake := &fakeDB{}
+	client := newUserClient(t, fake)
+
+	resp, err := client.Logout(asUser(context.Background(), testUUID(1)), &userV1.LogoutRequest{})
+	if err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+	if !resp.GetSuccess() {
+		t.Error("Logout() success = false")
+	}
+	if !slices.Contains(fake.queries, "RevokeToken") {
+		t.Errorf("queries = %v, want RevokeToken", fake.queries)
+	}
+}
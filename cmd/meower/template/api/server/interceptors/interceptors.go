@@ -0,0 +1,176 @@
+// Package interceptors holds the chain of gRPC interceptors run around every
+// call to the API.
+//
+// The chain tags each call with a request ID, taken from the x-request-id
+// metadata sent by the web server or generated, writes an access log line
+// once the call returns and turns handler panics into codes.Internal errors.
+// Handlers read the ID with RequestID and log with Logger, which carries it.
+package interceptors
+
+import (
+	"context"
+	"crypto/rand"
+	"runtime/debug"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDKey is the metadata key carrying the request ID, the gRPC
+// counterpart of the X-Request-ID HTTP header set by the web server
+const RequestIDKey = "x-request-id"
+
+// ServerOptions returns the interceptor chain of the API server, outermost
+// first. Add your own interceptors at the end of each chain: they then see the
+// request ID and their panics are recovered.
+func ServerOptions(logger *log.Logger) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			UnaryRequestID,
+			UnaryLogging(logger),
+			UnaryRecovery(logger),
+		),
+		grpc.ChainStreamInterceptor(
+			StreamRequestID,
+			StreamLogging(logger),
+			StreamRecovery(logger),
+		),
+	}
+}
+
+type requestIDKey struct{}
+
+type loggerKey struct{}
+
+// RequestID returns the ID of the call handled with ctx, or ""
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Logger returns the logger of the call handled with ctx, which adds its
+// request ID to every line, or the default logger
+func Logger(ctx context.Context) *log.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*log.Logger); ok {
+		return logger
+	}
+	return log.Default()
+}
+
+// withRequestID adds the request ID found in the incoming metadata of ctx, or
+// a new one, to ctx and to the response headers
+func withRequestID(ctx context.Context) context.Context {
+	var id string
+	if values := metadata.ValueFromIncomingContext(ctx, RequestIDKey); len(values) > 0 && values[0] != "" {
+		id = values[0]
+	} else {
+		id = rand.Text()
+	}
+	// Fails only when headers were already sent, which no handler did yet
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, id))
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// UnaryRequestID tags unary calls with their request ID
+func UnaryRequestID(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(withRequestID(ctx), req)
+}
+
+// StreamRequestID tags streams with their request ID
+func StreamRequestID(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &serverStream{ServerStream: ss, ctx: withRequestID(ss.Context())})
+}
+
+// UnaryLogging logs every unary call once it returns
+func UnaryLogging(logger *log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, callLogger := withLogger(ctx, logger)
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(callLogger, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamLogging logs every stream once it ends
+func StreamLogging(logger *log.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, callLogger := withLogger(ss.Context(), logger)
+		start := time.Now()
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		logCall(callLogger, info.FullMethod, start, err)
+		return err
+	}
+}
+
+// withLogger adds a logger tagged with the request ID of ctx to ctx
+func withLogger(ctx context.Context, logger *log.Logger) (context.Context, *log.Logger) {
+	if id := RequestID(ctx); id != "" {
+		logger = logger.With("request_id", id)
+	}
+	return context.WithValue(ctx, loggerKey{}, logger), logger
+}
+
+// logCall writes the access log line of a call, as an error when the server
+// is at fault and a warning when the client is
+func logCall(logger *log.Logger, method string, start time.Time, err error) {
+	code := status.Code(err)
+	keyvals := []any{"method", method, "code", code, "duration", time.Since(start)}
+
+	switch code {
+	case codes.OK:
+		logger.Info("call", keyvals...)
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unavailable, codes.Unimplemented:
+		logger.Error("call", append(keyvals, "err", err)...)
+	default:
+		logger.Warn("call", append(keyvals, "err", err)...)
+	}
+}
+
+// UnaryRecovery turns panics of unary handlers into codes.Internal errors
+func UnaryRecovery(logger *log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(ctx, logger, info.FullMethod, p)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecovery turns panics of stream handlers into codes.Internal errors
+func StreamRecovery(logger *log.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(ss.Context(), logger, info.FullMethod, p)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// recovered logs a panic with its stack trace and returns the error sent to
+// the client, which does not leak the panic value
+func recovered(ctx context.Context, logger *log.Logger, method string, p any) error {
+	if id := RequestID(ctx); id != "" {
+		logger = logger.With("request_id", id)
+	}
+	logger.Error("panic", "method", method, "panic", p, "stack", string(debug.Stack()))
+	return status.Error(codes.Internal, "internal error")
+}
+
+// serverStream replaces the context of a stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
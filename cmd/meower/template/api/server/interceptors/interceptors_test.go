@@ -0,0 +1,120 @@
+package interceptors
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// TestServerOptions calls a server running the chain through a real client
+func TestServerOptions(t *testing.T) {
+	var logs bytes.Buffer
+	lis := bufconn.Listen(1 << 20)
+	g := grpc.NewServer(ServerOptions(log.New(&logs))...)
+	grpc_health_v1.RegisterHealthServer(g, health.NewServer())
+	go g.Serve(lis)
+	t.Cleanup(g.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial bufconn: %v", err)
+	}
+	defer conn.Close()
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	tests := []struct {
+		name    string
+		sent    string
+		service string
+		code    codes.Code
+	}{
+		{name: "forwarded request ID", sent: "req-1", code: codes.OK},
+		{name: "generated request ID", code: codes.OK},
+		{name: "failed call", sent: "req-2", service: "unknown.Service", code: codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs.Reset()
+			ctx := context.Background()
+			if tt.sent != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, RequestIDKey, tt.sent)
+			}
+
+			var header metadata.MD
+			_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: tt.service}, grpc.Header(&header))
+			if got := status.Code(err); got != tt.code {
+				t.Fatalf("Check() code = %v, want %v", got, tt.code)
+			}
+
+			ids := header.Get(RequestIDKey)
+			if len(ids) != 1 || ids[0] == "" || (tt.sent != "" && ids[0] != tt.sent) {
+				t.Fatalf("%s header = %v, want %q", RequestIDKey, ids, tt.sent)
+			}
+			for _, want := range []string{"/grpc.health.v1.Health/Check", "request_id=" + ids[0], "code=" + tt.code.String()} {
+				if !strings.Contains(logs.String(), want) {
+					t.Errorf("log %q does not contain %q", logs.String(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestRecovery(t *testing.T) {
+	var logs bytes.Buffer
+	logger := log.New(&logs)
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "req-1")
+
+	_, err := UnaryRecovery(logger)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/meow.v1.MeowService/CreateMeow"},
+		func(ctx context.Context, req any) (any, error) {
+			panic("secret")
+		})
+	if status.Code(err) != codes.Internal || strings.Contains(err.Error(), "secret") {
+		t.Errorf("UnaryRecovery() error = %v, want an Internal error without the panic value", err)
+	}
+
+	err = StreamRecovery(logger)(nil, &serverStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/meow.v1.MeowService/WatchMeows"},
+		func(srv any, ss grpc.ServerStream) error {
+			panic("secret")
+		})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("StreamRecovery() error = %v, want an Internal error", err)
+	}
+
+	for _, want := range []string{"CreateMeow", "WatchMeows", "panic=secret", "request_id=req-1"} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("log %q does not contain %q", logs.String(), want)
+		}
+	}
+}
+
+func TestLogger(t *testing.T) {
+	if Logger(context.Background()) != log.Default() {
+		t.Error("Logger() outside of a call is not the default logger")
+	}
+
+	var logs bytes.Buffer
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "req-1")
+	ctx, _ = withLogger(ctx, log.New(&logs))
+	Logger(ctx).Info("creating meow")
+	if !strings.Contains(logs.String(), "request_id=req-1") {
+		t.Errorf("log %q does not contain the request ID", logs.String())
+	}
+}
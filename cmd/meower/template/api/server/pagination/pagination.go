@@ -0,0 +1,144 @@
+// Package pagination implements the keyset pagination shared by list RPCs.
+//
+// List requests have a page_size and a page_token, list responses the
+// next_page_token of the following page, empty on the last one. Rows are
+// listed newest first, ordered by (created_at, id), and a page token is an
+// opaque cursor on the last row of its page: the next page starts right after
+// it, so pages stay fast however deep they go, unlike OFFSET.
+//
+// Queries take the cursor as nullable parameters and fetch one row more than
+// the page size to know whether another page follows:
+//
+//	WHERE sqlc.narg(before_created_at)::timestamp IS NULL
+//	  OR (created_at, id) < (sqlc.narg(before_created_at), sqlc.narg(before_id)::uuid)
+//	ORDER BY created_at DESC, id DESC
+//	LIMIT sqlc.arg(page_size)
+//
+// Rows ordered by relevance first, such as search results, add their rank to
+// the cursor: (rank, created_at, id). Their page tokens also hold a hash of
+// the query the rank is relative to, and are decoded with DecodeRanked. The two
+// kinds of tokens are not interchangeable.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// DefaultSize is the page size of requests without one
+	DefaultSize = 20
+	// MaxSize caps the page size of requests
+	MaxSize = 100
+)
+
+// ErrInvalidToken is returned for page tokens not returned by a list RPC
+var ErrInvalidToken = errors.New("invalid page token")
+
+// Cursor is the (created_at, id) of the last row of a page, and its rank for
+// rows ordered by relevance to Query. The zero Cursor, with NULL fields,
+// starts at the first page.
+type Cursor struct {
+	Rank      pgtype.Float4
+	Query     string
+	CreatedAt pgtype.Timestamp
+	ID        pgtype.UUID
+}
+
+// Size returns the page size to use for a requested one
+func Size(requested int32) int32 {
+	switch {
+	case requested <= 0:
+		return DefaultSize
+	case requested > MaxSize:
+		return MaxSize
+	}
+	return requested
+}
+
+// Decode returns the cursor of a page token, the zero Cursor for "". Ranked
+// tokens are invalid.
+func Decode(token string) (Cursor, error) {
+	c, _, err := decode(token, false)
+	return c, err
+}
+
+// DecodeRanked returns the cursor of a page token of rows ordered by
+// relevance to query, the zero Cursor for "". Tokens without a rank, or
+// returned for another query, are invalid.
+func DecodeRanked(token, query string) (Cursor, error) {
+	c, hash, err := decode(token, true)
+	if err != nil || token == "" {
+		return c, err
+	}
+	if hash != queryHash(query) {
+		return Cursor{}, ErrInvalidToken
+	}
+	c.Query = query
+	return c, nil
+}
+
+// queryHash returns the hash of query held by ranked page tokens
+func queryHash(query string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(query))
+	return h.Sum32()
+}
+
+// decode returns the cursor of a token, and the query hash of ranked ones
+func decode(token string, ranked bool) (Cursor, uint32, error) {
+	if token == "" {
+		return Cursor{}, 0, nil
+	}
+
+	size := 8 + 16
+	if ranked {
+		size += 4 + 4
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(b) != size {
+		return Cursor{}, 0, ErrInvalidToken
+	}
+
+	var c Cursor
+	var hash uint32
+	if ranked {
+		hash = binary.BigEndian.Uint32(b)
+		b = b[4:]
+		c.Rank = pgtype.Float4{Float32: math.Float32frombits(binary.BigEndian.Uint32(b)), Valid: true}
+		b = b[4:]
+	}
+	c.CreatedAt = pgtype.Timestamp{Time: time.UnixMicro(int64(binary.BigEndian.Uint64(b))).UTC(), Valid: true}
+	c.ID = pgtype.UUID{Valid: true}
+	copy(c.ID.Bytes[:], b[8:])
+	return c, hash, nil
+}
+
+// Token returns the page token starting after the cursor, with the
+// microsecond precision of PostgreSQL timestamps
+func (c Cursor) Token() string {
+	var b []byte
+	if c.Rank.Valid {
+		b = binary.BigEndian.AppendUint32(b, queryHash(c.Query))
+		b = binary.BigEndian.AppendUint32(b, math.Float32bits(c.Rank.Float32))
+	}
+	b = binary.BigEndian.AppendUint64(b, uint64(c.CreatedAt.Time.UnixMicro()))
+	b = append(b, c.ID.Bytes[:]...)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Page trims rows, fetched with a limit of size+1, to a page of size and
+// returns the token of the next page, "" when rows held the last one
+func Page[T any](rows []T, size int32, cursor func(T) Cursor) ([]T, string) {
+	if len(rows) <= int(size) {
+		return rows, ""
+	}
+	rows = rows[:size]
+	return rows, cursor(rows[len(rows)-1]).Token()
+}
@@ -0,0 +1,64 @@
+// Package pubsub publishes events to the subscribers of this API instance,
+// such as the streams of MeowService.WatchMeows.
+//
+// The broker is in-process: with several API instances, each one only sees
+// the events published by its own handlers. Use PostgreSQL LISTEN/NOTIFY or a
+// message queue to share them between instances. Subscribers falling behind
+// are dropped rather than blocking publishers, streams then end and clients
+// resume them.
+package pubsub
+
+import "sync"
+
+// BufferSize is the number of events a subscriber can fall behind by before
+// it is dropped
+const BufferSize = 64
+
+// Broker sends the events published to every subscriber
+type Broker[T any] struct {
+	mu          sync.Mutex
+	subscribers map[chan T]struct{}
+}
+
+func NewBroker[T any]() *Broker[T] {
+	return &Broker[T]{subscribers: map[chan T]struct{}{}}
+}
+
+// Subscribe returns a channel receiving the events published from now on,
+// and a function to unsubscribe. The channel is closed on unsubscribe, or
+// when the subscriber falls BufferSize events behind.
+func (b *Broker[T]) Subscribe() (<-chan T, func()) {
+	events := make(chan T, BufferSize)
+
+	b.mu.Lock()
+	b.subscribers[events] = struct{}{}
+	b.mu.Unlock()
+
+	return events, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.drop(events)
+	}
+}
+
+// Publish sends event to every subscriber, without waiting for them
+func (b *Broker[T]) Publish(event T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for events := range b.subscribers {
+		select {
+		case events <- event:
+		default:
+			b.drop(events)
+		}
+	}
+}
+
+// drop removes a subscriber and closes its channel, once
+func (b *Broker[T]) drop(events chan T) {
+	if _, ok := b.subscribers[events]; ok {
+		delete(b.subscribers, events)
+		close(events)
+	}
+}
@@ -2,59 +2,144 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
 	"net"
+	"net/http"
 	"os"
+	"time"
 
+	"TEMPLATE_MODULE_PATH/api/config"
+	pbFollowV1 "TEMPLATE_MODULE_PATH/api/proto/follow/v1"
 	pbMeowV1 "TEMPLATE_MODULE_PATH/api/proto/meow/v1"
 	pbUserV1 "TEMPLATE_MODULE_PATH/api/proto/user/v1"
+	"TEMPLATE_MODULE_PATH/api/server/auth"
 	"TEMPLATE_MODULE_PATH/api/server/handlers"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"TEMPLATE_MODULE_PATH/api/server/interceptors"
+	"TEMPLATE_MODULE_PATH/api/server/pubsub"
+	"github.com/charmbracelet/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
-const (
-	apiEndpoint = "localhost:50051"
-)
+// Serve runs the gRPC API, and its gRPC-Web server when enabled, until ctx
+// is cancelled. It then reports NOT_SERVING to health checks and waits for
+// in-flight calls to finish, for up to cfg.ShutdownTimeout.
+func Serve(ctx context.Context, cfg *config.API) error {
+	// Log in JSON in production, for log collectors
+	logger := log.NewWithOptions(os.Stderr, log.Options{ReportTimestamp: true})
+	if cfg.IsProduction() {
+		logger.SetFormatter(log.JSONFormatter)
+	}
 
-func Serve() {
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	// Connect to PostgreSQL, waiting for it to accept connections
+	db, err := connectDB(ctx, logger, cfg.DatabaseURL, cfg.DatabaseTimeout)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	// Session tokens issued by Login and checked on every call
+	tokens, err := auth.NewTokens(db, cfg.TokenSigningKey, cfg.TokenTTL)
+	if err != nil {
+		return err
+	}
+
+	// Meows created, published to the streams of WatchMeows
+	meows := pubsub.NewBroker[handlers.MeowEvent]()
 
 	// Create a listener on TCP port for gRPC server
-	lis, err := net.Listen("tcp", ":50051")
+	lis, err := net.Listen("tcp", cfg.Addr)
 	if err != nil {
-		log.Fatalf("failed to listen: %v\n", err)
+		return fmt.Errorf("failed to listen: %w", err)
 	}
-	defer lis.Close()
 
-	// Create a new gRPC server
-	g := grpc.NewServer()
-	defer g.GracefulStop()
+	// Create a new gRPC server, recovering panics and logging every call. Add
+	// your own interceptors in interceptors.ServerOptions, they also run in
+	// handler tests. Calls are then authenticated: RPCs are private unless
+	// annotated with option (auth.v1.public) = true.
+	g := grpc.NewServer(append(interceptors.ServerOptions(logger),
+		grpc.ChainUnaryInterceptor(tokens.UnaryInterceptor),
+		grpc.ChainStreamInterceptor(tokens.StreamInterceptor),
+	)...)
 
 	// Register reflection service
 	reflection.Register(g)
 
 	// Register health check service
-	grpc_health_v1.RegisterHealthServer(g, health.NewServer())
-
-	// Create a new PostgreSQL connection pool
-	db, err := pgxpool.New(ctx, os.Getenv("DATABASE_URL"))
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Unable to connect to database: %v\n", err)
-	}
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(g, healthServer)
 
 	// Register V1 services
-	pbMeowV1.RegisterMeowServiceServer(g, handlers.NewMeowerServer(db))
-	pbUserV1.RegisterUserServiceServer(g, handlers.NewUserServer(db))
+	pbMeowV1.RegisterMeowServiceServer(g, handlers.NewMeowerServer(db, meows))
+	pbUserV1.RegisterUserServiceServer(g, handlers.NewUserServer(db, tokens))
+	pbFollowV1.RegisterFollowServiceServer(g, handlers.NewFollowServer(db))
+
+	// Report the status of the server and its services from database pings
+	go watchHealth(ctx, logger, healthServer, db, servedServices(g), cfg.HealthCheckInterval)
+
+	errs := make(chan error, 2)
+
+	// Serve the API to browsers over gRPC-Web
+	var webServer *http.Server
+	if cfg.GRPCWebEnabled {
+		webServer = newGRPCWebServer(g, cfg)
+		go func() {
+			logger.Info("gRPC-Web server listening", "addr", webServer.Addr)
+			if err := webServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errs <- fmt.Errorf("failed to serve gRPC-Web: %w", err)
+			}
+		}()
+	}
 
 	// Serve the gRPC server
-	log.Printf("API server listening at %v", lis.Addr())
-	if err := g.Serve(lis); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+	go func() {
+		logger.Info("API server listening", "addr", lis.Addr())
+		if err := g.Serve(lis); err != nil {
+			errs <- fmt.Errorf("failed to serve: %w", err)
+		}
+	}()
+
+	select {
+	case err := <-errs:
+		g.Stop()
+		if webServer != nil {
+			webServer.Close()
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.Info("Shutting down, waiting for in-flight calls", "timeout", cfg.ShutdownTimeout)
+	healthServer.Shutdown()
+	return shutdown(g, webServer, cfg.ShutdownTimeout)
+}
+
+// shutdown stops accepting calls and waits for in-flight ones to finish. Calls
+// still running after timeout, such as long-lived streams, are cancelled.
+func shutdown(g *grpc.Server, webServer *http.Server, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		g.GracefulStop()
+		close(stopped)
+	}()
+
+	if webServer != nil {
+		if err := webServer.Shutdown(ctx); err != nil {
+			webServer.Close()
+		}
+	}
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		g.Stop()
+		return fmt.Errorf("calls still running after %v were cancelled", timeout)
 	}
 }
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestConnectDBTimeout(t *testing.T) {
+	// Nothing listens on port 1: every ping is refused
+	start := time.Now()
+	_, err := connectDB(context.Background(), log.New(io.Discard), "postgres://meower@127.0.0.1:1/meower?connect_timeout=1", 500*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "failed to reach the database within 500ms") {
+		t.Fatalf("connectDB() error = %v, want a timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("connectDB() gave up after %v, want about 500ms", elapsed)
+	}
+
+	if _, err := connectDB(context.Background(), log.New(io.Discard), "postgres://meower@127.0.0.1:1/meower?sslmode=bogus", time.Second); err == nil || !strings.Contains(err.Error(), "invalid database configuration") {
+		t.Errorf("connectDB() error = %v, want an invalid configuration", err)
+	}
+}
+
+// fakePinger fails while down is set
+type fakePinger struct {
+	down atomic.Bool
+}
+
+func (p *fakePinger) Ping(ctx context.Context) error {
+	if p.down.Load() {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func TestWatchHealth(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	healthServer := health.NewServer()
+	db := &fakePinger{}
+	db.down.Store(true)
+	go watchHealth(ctx, log.New(io.Discard), healthServer, db, []string{"meow.v1.MeowService", "user.v1.UserService"}, 10*time.Millisecond)
+
+	// waitStatus polls the status of every service until it is want
+	waitStatus := func(want grpc_health_v1.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		for _, service := range []string{"", "meow.v1.MeowService", "user.v1.UserService"} {
+			var got grpc_health_v1.HealthCheckResponse_ServingStatus
+			for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+				resp, err := healthServer.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service})
+				if err == nil {
+					if got = resp.GetStatus(); got == want {
+						break
+					}
+				}
+			}
+			if got != want {
+				t.Fatalf("status of %q = %v, want %v", service, got, want)
+			}
+		}
+	}
+
+	waitStatus(grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	db.down.Store(false)
+	waitStatus(grpc_health_v1.HealthCheckResponse_SERVING)
+}
+
+// TestShutdown watches the health of a server while it shuts down: the watch
+// sees NOT_SERVING, then is cancelled once the drain timeout expires since the
+// stream never ends on its own
+func TestShutdown(t *testing.T) {
+	lis := bufconn.Listen(1 << 20)
+	g := grpc.NewServer()
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(g, healthServer)
+	go g.Serve(lis)
+	t.Cleanup(g.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial bufconn: %v", err)
+	}
+	defer conn.Close()
+
+	watch, err := grpc_health_v1.NewHealthClient(conn).Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	if resp, err := watch.Recv(); err != nil || resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Fatalf("status = %v, %v, want SERVING", resp.GetStatus(), err)
+	}
+
+	healthServer.Shutdown()
+	if resp, err := watch.Recv(); err != nil || resp.GetStatus() != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("status = %v, %v, want NOT_SERVING", resp.GetStatus(), err)
+	}
+
+	err = shutdown(g, nil, 100*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "were cancelled") {
+		t.Errorf("shutdown() error = %v, want the watch to be cancelled", err)
+	}
+	if _, err := watch.Recv(); err == nil {
+		t.Error("watch still open after shutdown")
+	}
+}
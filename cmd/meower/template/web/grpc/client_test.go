@@ -0,0 +1,72 @@
+package grpc
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want []string
+	}{
+		{name: "with request ID", ctx: WithRequestID(context.Background(), "req-1"), want: []string{"req-1"}},
+		{name: "without request ID", ctx: context.Background()},
+		{name: "empty request ID", ctx: WithRequestID(context.Background(), "")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				md, _ := metadata.FromOutgoingContext(ctx)
+				got = md.Get(requestIDKey)
+				return nil
+			}
+			if err := unaryOutgoing(tt.ctx, "/meow.v1.MeowService/CreateMeow", nil, nil, nil, invoker); err != nil {
+				t.Fatalf("unaryOutgoing() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s metadata = %v, want %v", requestIDKey, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToken(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want []string
+	}{
+		{name: "with token", ctx: WithToken(context.Background(), "abc"), want: []string{"Bearer abc"}},
+		{name: "without token", ctx: context.Background()},
+		{
+			name: "forwarded authorization",
+			ctx:  WithToken(metadata.AppendToOutgoingContext(context.Background(), authorizationKey, "Bearer def"), "abc"),
+			want: []string{"Bearer def"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				md, _ := metadata.FromOutgoingContext(ctx)
+				got = md.Get(authorizationKey)
+				return nil
+			}
+			if err := unaryOutgoing(tt.ctx, "/meow.v1.MeowService/CreateMeow", nil, nil, nil, invoker); err != nil {
+				t.Fatalf("unaryOutgoing() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s metadata = %v, want %v", authorizationKey, got, tt.want)
+			}
+		})
+	}
+}
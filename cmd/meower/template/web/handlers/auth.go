@@ -11,6 +11,7 @@ import (
 
 	userV1 "TEMPLATE_MODULE_PATH/api/proto/user/v1"
 
+	"github.com/charmbracelet/log"
 	"github.com/gofiber/fiber/v2"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -45,7 +46,7 @@ func (a *Auth) Login(c *fiber.Ctx) error {
 	}
 
 	// Call the gRPC API
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
 	resp, err := a.App.API.UserService.Login(ctx, &userV1.LoginRequest{
@@ -133,10 +134,10 @@ func (a *Auth) Signup(c *fiber.Ctx) error {
 	}
 
 	// Call the gRPC API
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
-	resp, err := a.App.API.UserService.CreateUser(ctx, &userV1.CreateUserRequest{
+	_, err := a.App.API.UserService.CreateUser(ctx, &userV1.CreateUserRequest{
 		Username:    username,
 		DisplayName: displayName,
 		Email:       email,
@@ -165,7 +166,15 @@ func (a *Auth) Signup(c *fiber.Ctx) error {
 		return renderTempl(c, views.Signup(c, errorMsg))
 	}
 
-	// Auto-login after successful signup
+	// Auto-login after successful signup, for a session token
+	resp, err := a.App.API.UserService.Login(ctx, &userV1.LoginRequest{
+		UsernameOrEmail: username,
+		Password:        password,
+	})
+	if err != nil {
+		return c.Redirect(routes.LoginShow.Path)
+	}
+
 	sess, err := a.App.SessionStore.Get(c)
 	if err != nil {
 		return err
@@ -174,6 +183,7 @@ func (a *Auth) Signup(c *fiber.Ctx) error {
 	sess.Set("user_id", resp.User.Id)
 	sess.Set("username", resp.User.Username)
 	sess.Set("display_name", resp.User.DisplayName)
+	sess.Set("token", resp.Token)
 
 	if err := sess.Save(); err != nil {
 		return err
@@ -189,14 +199,14 @@ func (a *Auth) Logout(c *fiber.Ctx) error {
 		return err
 	}
 
-	// Call the gRPC API
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	// Revoke the session token, sent by APITokenMiddleware
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
 	defer cancel()
 
 	_, err = a.App.API.UserService.Logout(ctx, &userV1.LogoutRequest{})
 	if err != nil {
-		// Log the error but don't fail the logout
-		// In a real app, you might want to handle this differently
+		// Don't fail the logout, the token expires anyway
+		log.Warn("failed to revoke session token", "error", err)
 	}
 
 	// Clear the session
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	meowV1 "TEMPLATE_MODULE_PATH/api/proto/meow/v1"
+	"TEMPLATE_MODULE_PATH/web/views"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// keepAliveInterval is how often idle event streams send a comment, so
+// proxies keep them open and disconnected browsers are noticed
+const keepAliveInterval = 30 * time.Second
+
+// Events streams the meows created to the browser as Server-Sent Events,
+// each one a "meow" event holding its rendered views.MeowItem. Browsers
+// reconnect on their own with the Last-Event-ID header, and the stream
+// resumes after that meow. With ?home=true, only the meows of the home
+// timeline are streamed.
+func (h *Meower) Events(c *fiber.Ctx) error {
+	ctx, cancel := context.WithCancel(c.UserContext())
+	stream, err := h.API.MeowService.WatchMeows(ctx, &meowV1.WatchMeowsRequest{
+		Home:         c.QueryBool("home"),
+		AfterEventId: c.Get("Last-Event-ID"),
+	})
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	// c is released once the handler returns, views are rendered with a
+	// context of their own holding the logged in user
+	app, userID, username := c.App(), c.Locals("user_id"), c.Locals("username")
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+
+		view := app.AcquireCtx(&fasthttp.RequestCtx{})
+		defer app.ReleaseCtx(view)
+		view.Locals("user_id", userID)
+		view.Locals("username", username)
+
+		meows := make(chan *meowV1.WatchMeowsResponse)
+		go func() {
+			defer close(meows)
+			for {
+				resp, err := stream.Recv()
+				if err != nil {
+					return
+				}
+				select {
+				case meows <- resp:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		keepAlive := time.NewTicker(keepAliveInterval)
+		defer keepAlive.Stop()
+		for {
+			select {
+			case resp, ok := <-meows:
+				if !ok {
+					// Ended by the API, the browser reconnects
+					return
+				}
+				var html strings.Builder
+				if err := views.MeowItem(view, resp.Meow).Render(ctx, &html); err != nil {
+					return
+				}
+				writeEvent(w, resp.EventId, "meow", html.String())
+			case <-keepAlive.C:
+				w.WriteString(": keep-alive\n\n")
+			}
+			if err := w.Flush(); err != nil {
+				// The browser is gone
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+// writeEvent writes a Server-Sent Event, with a data field per line of data
+func writeEvent(w *bufio.Writer, id, event, data string) {
+	fmt.Fprintf(w, "id: %s\nevent: %s\n", id, event)
+	for _, line := range strings.Split(data, "\n") {
+		fmt.Fprintf(w, "data: %s\n", line)
+	}
+	w.WriteString("\n")
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestWriteEvent(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "single line",
+			data: "<li>Meow</li>",
+			want: "id: 1\nevent: meow\ndata: <li>Meow</li>\n\n",
+		},
+		{
+			name: "multiple lines",
+			data: "<li>\nMeow\n</li>",
+			want: "id: 1\nevent: meow\ndata: <li>\ndata: Meow\ndata: </li>\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got strings.Builder
+			w := bufio.NewWriter(&got)
+			writeEvent(w, "1", "meow", tt.data)
+			w.Flush()
+			if got.String() != tt.want {
+				t.Errorf("writeEvent() wrote %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
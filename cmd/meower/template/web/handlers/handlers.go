@@ -3,6 +3,7 @@ package handlers
 import (
 	"errors"
 
+	"TEMPLATE_MODULE_PATH/web/gateway"
 	"TEMPLATE_MODULE_PATH/web/grpc"
 	"TEMPLATE_MODULE_PATH/web/views"
 
@@ -10,6 +11,9 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/session"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/gofiber/storage/redis/v3"
+	"google.golang.org/grpc/status"
 
 	"github.com/charmbracelet/log"
 )
@@ -18,6 +22,7 @@ type App struct {
 	Web          *fiber.App
 	API          *grpc.Client
 	SessionStore *session.Store
+	Redis        *redis.Storage
 }
 
 func ErrorHandler(ctx *fiber.Ctx, err error) error {
@@ -25,10 +30,13 @@ func ErrorHandler(ctx *fiber.Ctx, err error) error {
 	// Status code defaults to 500
 	code := fiber.StatusInternalServerError
 
-	// Retrieve the custom status code if it's a *fiber.Error
+	// Retrieve the custom status code if it's a *fiber.Error, or the one
+	// matching the status of a failed API call
 	var e *fiber.Error
 	if errors.As(err, &e) {
 		code = e.Code
+	} else if st, ok := status.FromError(err); ok {
+		code = gateway.HTTPStatus(st.Code())
 	}
 
 	switch code {
@@ -37,7 +45,7 @@ func ErrorHandler(ctx *fiber.Ctx, err error) error {
 	case 404:
 		err = renderTempl(ctx, views.Error404(ctx), templ.WithStatus(code))
 	default:
-		return ctx.Status(fiber.StatusInternalServerError).SendString("Internal Server Error")
+		return ctx.Status(code).SendString(utils.StatusMessage(code))
 	}
 
 	if err != nil {
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestHealth(t *testing.T) {
+	ok := func(ctx context.Context) error { return nil }
+	down := func(ctx context.Context) error { return errors.New("connection refused") }
+
+	tests := []struct {
+		name       string
+		target     string
+		checks     map[string]Check
+		wantStatus int
+		wantChecks map[string]any
+	}{
+		{
+			name:       "live while dependencies are down",
+			target:     "/healthz",
+			checks:     map[string]Check{"api": down},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:       "ready",
+			target:     "/readyz",
+			checks:     map[string]Check{"api": ok, "redis": ok},
+			wantStatus: fiber.StatusOK,
+			wantChecks: map[string]any{"api": "ok", "redis": "ok"},
+		},
+		{
+			name:       "not ready",
+			target:     "/readyz",
+			checks:     map[string]Check{"api": ok, "redis": down},
+			wantStatus: fiber.StatusServiceUnavailable,
+			wantChecks: map[string]any{"api": "ok", "redis": "connection refused"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := &Health{Checks: tt.checks}
+			app := fiber.New()
+			app.Get("/healthz", health.Live)
+			app.Get("/readyz", health.Ready)
+
+			resp, err := app.Test(httptest.NewRequest("GET", tt.target, nil))
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+
+			var body struct {
+				Checks map[string]any `json:"checks"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("invalid response: %v", err)
+			}
+			for name, want := range tt.wantChecks {
+				if got := body.Checks[name]; got != want {
+					t.Errorf("check %s = %v, want %v", name, got, want)
+				}
+			}
+		})
+	}
+}
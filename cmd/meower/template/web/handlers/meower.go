@@ -1,8 +1,13 @@
 package handlers
 
 import (
+	"strings"
+
+	followV1 "TEMPLATE_MODULE_PATH/api/proto/follow/v1"
 	meowV1 "TEMPLATE_MODULE_PATH/api/proto/meow/v1"
+	userV1 "TEMPLATE_MODULE_PATH/api/proto/user/v1"
 
+	"TEMPLATE_MODULE_PATH/web/routes"
 	"TEMPLATE_MODULE_PATH/web/views"
 
 	"github.com/gofiber/fiber/v2"
@@ -16,23 +21,194 @@ func (h *Meower) New(c *fiber.Ctx) error {
 
 func (h *Meower) Create(c *fiber.Ctx) error {
 	content := c.FormValue("content")
-	req := &meowV1.CreateMeowRequest{Content: content}
+	req := &meowV1.CreateMeowRequest{Content: content, ParentId: c.FormValue("parent_id")}
 
-	resp, err := h.API.MeowService.CreateMeow(c.Context(), req)
+	resp, err := h.API.MeowService.CreateMeow(c.UserContext(), req)
 	if err != nil {
 		return err
 	}
 
+	// Replies go back to the meow replied to
+	if req.ParentId != "" {
+		return c.Redirect(routes.MeowShow.URL(c, fiber.Map{"id": req.ParentId}))
+	}
+
 	return renderTempl(c, views.CreateMeow(c, resp))
 }
 
 func (h *Meower) Index(c *fiber.Ctx) error {
-	req := &meowV1.IndexMeowRequest{}
+	req := &meowV1.IndexMeowRequest{PageToken: c.Query("page_token")}
 
-	resp, err := h.API.MeowService.IndexMeow(c.Context(), req)
+	resp, err := h.API.MeowService.IndexMeow(c.UserContext(), req)
 	if err != nil {
 		return err
 	}
 
 	return renderTempl(c, views.IndexMeows(c, resp))
 }
+
+// Home shows the meows of the user and of the users they follow
+func (h *Meower) Home(c *fiber.Ctx) error {
+	req := &meowV1.HomeTimelineRequest{PageToken: c.Query("page_token")}
+
+	resp, err := h.API.MeowService.HomeTimeline(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return renderTempl(c, views.HomeMeows(c, resp))
+}
+
+func (h *Meower) Show(c *fiber.Ctx) error {
+	req := &meowV1.GetMeowRequest{Id: c.Params("id")}
+
+	resp, err := h.API.MeowService.GetMeow(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	replies, err := h.API.MeowService.ListMeowsByParent(c.UserContext(), &meowV1.ListMeowsByParentRequest{
+		ParentId:  resp.Meow.Id,
+		PageToken: c.Query("page_token"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return renderTempl(c, views.ShowMeow(c, resp.Meow, replies))
+}
+
+func (h *Meower) Edit(c *fiber.Ctx) error {
+	req := &meowV1.GetMeowRequest{Id: c.Params("id")}
+
+	resp, err := h.API.MeowService.GetMeow(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+	if resp.Meow.AuthorId != c.Locals("user_id") {
+		return fiber.ErrForbidden
+	}
+
+	return renderTempl(c, views.EditMeow(c, resp.Meow))
+}
+
+func (h *Meower) Update(c *fiber.Ctx) error {
+	req := &meowV1.UpdateMeowRequest{Id: c.Params("id"), Content: c.FormValue("content")}
+
+	resp, err := h.API.MeowService.UpdateMeow(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Redirect(routes.MeowShow.URL(c, fiber.Map{"id": resp.Meow.Id}))
+}
+
+func (h *Meower) Delete(c *fiber.Ctx) error {
+	req := &meowV1.DeleteMeowRequest{Id: c.Params("id")}
+
+	if _, err := h.API.MeowService.DeleteMeow(c.UserContext(), req); err != nil {
+		return err
+	}
+
+	return c.Redirect(routes.UserShow.URL(c, fiber.Map{"username": c.Locals("username")}))
+}
+
+// User shows the timeline of a user
+func (h *Meower) User(c *fiber.Ctx) error {
+	user, err := h.API.UserService.GetUserByUsername(c.UserContext(), &userV1.GetUserByUsernameRequest{Username: c.Params("username")})
+	if err != nil {
+		return err
+	}
+
+	stats, err := h.API.FollowService.GetFollowStats(c.UserContext(), &followV1.GetFollowStatsRequest{Username: user.Profile.Username})
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.API.MeowService.ListMeowsByUser(c.UserContext(), &meowV1.ListMeowsByUserRequest{
+		Username:  user.Profile.Username,
+		PageToken: c.Query("page_token"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return renderTempl(c, views.UserMeows(c, user.Profile, stats, resp))
+}
+
+// Search shows the meows matching the q query parameter, best matches first
+func (h *Meower) Search(c *fiber.Ctx) error {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		return renderTempl(c, views.SearchMeows(c, query, nil))
+	}
+
+	resp, err := h.API.MeowService.SearchMeows(c.UserContext(), &meowV1.SearchMeowsRequest{
+		Query:     query,
+		PageToken: c.Query("page_token"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return renderTempl(c, views.SearchMeows(c, query, resp))
+}
+
+// Tag shows the meows tagged with a #tag, newest first
+func (h *Meower) Tag(c *fiber.Ctx) error {
+	resp, err := h.API.MeowService.ListMeowsByTag(c.UserContext(), &meowV1.ListMeowsByTagRequest{
+		Tag:       c.Params("tag"),
+		PageToken: c.Query("page_token"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return renderTempl(c, views.TagMeows(c, strings.ToLower(c.Params("tag")), resp))
+}
+
+func (h *Meower) Like(c *fiber.Ctx) error {
+	resp, err := h.API.MeowService.LikeMeow(c.UserContext(), &meowV1.LikeMeowRequest{Id: c.Params("id")})
+	if err != nil {
+		return err
+	}
+
+	return renderActions(c, resp.Meow)
+}
+
+func (h *Meower) Unlike(c *fiber.Ctx) error {
+	resp, err := h.API.MeowService.UnlikeMeow(c.UserContext(), &meowV1.UnlikeMeowRequest{Id: c.Params("id")})
+	if err != nil {
+		return err
+	}
+
+	return renderActions(c, resp.Meow)
+}
+
+func (h *Meower) Repost(c *fiber.Ctx) error {
+	resp, err := h.API.MeowService.RepostMeow(c.UserContext(), &meowV1.RepostMeowRequest{Id: c.Params("id")})
+	if err != nil {
+		return err
+	}
+
+	return renderActions(c, resp.Meow)
+}
+
+func (h *Meower) Unrepost(c *fiber.Ctx) error {
+	resp, err := h.API.MeowService.UnrepostMeow(c.UserContext(), &meowV1.UnrepostMeowRequest{Id: c.Params("id")})
+	if err != nil {
+		return err
+	}
+
+	return renderActions(c, resp.Meow)
+}
+
+// renderActions answers an interaction with a meow: HTMX requests get the
+// updated actions of the meow to swap in, others go back to the page they
+// came from
+func renderActions(c *fiber.Ctx, m *meowV1.Meow) error {
+	if c.Get("HX-Request") == "true" {
+		return renderTempl(c, views.MeowActions(c, m))
+	}
+	return c.RedirectBack(routes.MeowShow.URL(c, fiber.Map{"id": m.Id}))
+}
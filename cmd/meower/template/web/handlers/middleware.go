@@ -1,10 +1,17 @@
 package handlers
 
 import (
+	"strings"
+
+	"TEMPLATE_MODULE_PATH/web/grpc"
+	"TEMPLATE_MODULE_PATH/web/routes"
+
 	"github.com/charmbracelet/log"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/session"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // AuthMiddleware checks if the user is authenticated
@@ -75,3 +82,36 @@ func OptionalAuthMiddleware(store *session.Store) fiber.Handler {
 		return c.Next()
 	}
 }
+
+// APITokenMiddleware authenticates the calls to the API made through
+// c.UserContext() with the session token of the logged in user. When the API
+// rejects the token, because it expired or was revoked by a password reset,
+// the session is destroyed and the user is sent back to the login page.
+//
+// The JSON gateway under /api/ is skipped: its clients send their own token
+// in the Authorization header, and it is not protected against CSRF.
+func APITokenMiddleware(store *session.Store) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if strings.HasPrefix(c.Path(), "/api/") {
+			return c.Next()
+		}
+
+		sess, err := store.Get(c)
+		if err != nil {
+			return c.Next()
+		}
+		if token, ok := sess.Get("token").(string); ok {
+			c.SetUserContext(grpc.WithToken(c.UserContext(), token))
+		}
+
+		err = c.Next()
+		if status.Code(err) == codes.Unauthenticated && sess.Get("user_id") != nil {
+			log.Debug("API rejected the session token", "path", c.Path(), "error", err)
+			if err := sess.Destroy(); err != nil {
+				return err
+			}
+			return c.Redirect(routes.LoginShow.Path)
+		}
+		return err
+	}
+}
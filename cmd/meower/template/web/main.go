@@ -1,13 +1,18 @@
 package main
 
 import (
-	"os"
+	"strings"
 	"time"
 
+	"TEMPLATE_MODULE_PATH/api/config"
+	"TEMPLATE_MODULE_PATH/web/docs"
+	"TEMPLATE_MODULE_PATH/web/gateway"
 	"TEMPLATE_MODULE_PATH/web/grpc"
 	"TEMPLATE_MODULE_PATH/web/handlers"
+	"TEMPLATE_MODULE_PATH/web/routes"
 	"TEMPLATE_MODULE_PATH/web/routing"
 
+	"github.com/charmbracelet/log"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/compress"
 	"github.com/gofiber/fiber/v2/middleware/csrf"
@@ -20,12 +25,17 @@ import (
 )
 
 func main() {
+	var cfg config.Web
+	if err := config.Load(&cfg); err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
+	}
+
 	// Connect to the internal gRPC API
-	GrpcClient := grpc.NewClient()
+	GrpcClient := grpc.NewClient(cfg.APIEndpoint)
 
 	// Create Redis storage
 	redisStore := redis.New(redis.Config{
-		URL: os.Getenv("REDIS_URL"),
+		URL: cfg.RedisURL,
 	})
 
 	// Create session store with Redis storage
@@ -34,7 +44,7 @@ func main() {
 		KeyLookup:      "cookie:session_id",
 		CookieDomain:   "",
 		CookiePath:     "/",
-		CookieSecure:   os.Getenv("ENV") == "production",
+		CookieSecure:   cfg.IsProduction(),
 		CookieHTTPOnly: true,
 		CookieSameSite: "Lax",
 		Expiration:     365 * 24 * time.Hour, // 1 year
@@ -47,26 +57,61 @@ func main() {
 	})
 
 	// Add middlewares
-	if os.Getenv("ENV") == "production" {
-		fiberApp.Use(compress.New()) // Enable gzip compression in production only, templ proxy does not support brotli
-		fiberApp.Use(csrf.New())
+	if cfg.IsProduction() {
+		// Enable gzip compression in production only, templ proxy does not
+		// support brotli. Event streams are left alone, to flush every event.
+		fiberApp.Use(compress.New(compress.Config{
+			Next: func(c *fiber.Ctx) bool {
+				return c.Path() == routes.MeowEvents.Path
+			},
+		}))
+		fiberApp.Use(csrf.New(csrf.Config{
+			// The JSON gateway is called by API clients, not from forms
+			Next: func(c *fiber.Ctx) bool {
+				return strings.HasPrefix(c.Path(), "/api/")
+			},
+		}))
 	} else {
-		fiberApp.Use(logger.New()) // Enable request logging in development
+		// Enable request logging in development, except for health probes
+		// running every few seconds
+		fiberApp.Use(logger.New(logger.Config{
+			Next: func(c *fiber.Ctx) bool {
+				return c.Path() == routes.Healthz.Path || c.Path() == routes.Readyz.Path
+			},
+		}))
 	}
 	fiberApp.Use(requestid.New(requestid.Config{Generator: utils.UUIDv4}))
+	// Forward the request ID to the API through c.UserContext()
+	fiberApp.Use(func(c *fiber.Ctx) error {
+		c.SetUserContext(grpc.WithRequestID(c.UserContext(), c.GetRespHeader(fiber.HeaderXRequestID)))
+		return c.Next()
+	})
 	fiberApp.Use(encryptcookie.New(encryptcookie.Config{
-		Key: os.Getenv("COOKIE_SECRET_KEY"),
+		Key: cfg.CookieSecretKey,
 	}))
+	// Authenticate calls to the API with the session token of the user
+	fiberApp.Use(handlers.APITokenMiddleware(sessionStore))
 
 	app := &handlers.App{
 		Web:          fiberApp,
 		API:          GrpcClient,
 		SessionStore: sessionStore,
+		Redis:        redisStore,
+	}
+
+	// Mount the JSON gateway to the gRPC API under /api
+	if cfg.GatewayEnabled {
+		gateway.Register(app.Web, app.API.Conn())
+	}
+
+	// Serve the OpenAPI document of the gateway and its viewer at /api/docs
+	if cfg.DocsEnabled {
+		docs.Register(app.Web)
 	}
 
 	// Mount public routes
 	routing.RegisterRoutes(app)
-	if err := app.Web.Listen("0.0.0.0:3000"); err != nil {
+	if err := app.Web.Listen(cfg.Addr); err != nil {
 		panic(err)
 	}
 }
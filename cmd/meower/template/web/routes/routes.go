@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"github.com/charmbracelet/log"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -10,6 +11,10 @@ type route struct {
 }
 
 type routes struct {
+	// Health probes
+	Healthz route
+	Readyz  route
+
 	// Homepage
 	Homepage route
 
@@ -24,6 +29,27 @@ type routes struct {
 	MeowIndex  route
 	MeowNew    route
 	MeowCreate route
+	MeowShow   route
+	MeowEdit   route
+	MeowUpdate route
+	MeowDelete route
+	MeowHome   route
+	MeowSearch route
+	MeowTag    route
+	MeowEvents route
+
+	// Meow interactions
+	MeowLike     route
+	MeowUnlike   route
+	MeowRepost   route
+	MeowUnrepost route
+
+	// Users
+	UserShow      route
+	UserFollow    route
+	UserUnfollow  route
+	UserFollowers route
+	UserFollowing route
 }
 
 /*
@@ -43,6 +69,10 @@ Feel free to use anything else that makes sense for your endpoints, resources an
 */
 
 var (
+	// Health probes
+	Healthz = route{Name: "health.live", Path: "/healthz"}
+	Readyz  = route{Name: "health.ready", Path: "/readyz"}
+
 	// Homepage
 	Homepage = route{Name: "home.index", Path: "/"}
 
@@ -53,17 +83,41 @@ var (
 	Signup     = route{Name: "auth.signup", Path: "/signup"}
 	Logout     = route{Name: "auth.logout", Path: "/logout"}
 
-	// Meower
+	// Meower, updated and deleted with POST as HTML forms can't send PATCH or DELETE
 	MeowIndex  = route{Name: "meow.index", Path: "/meows"}
 	MeowNew    = route{Name: "meow.new", Path: "/meows/new"}
 	MeowCreate = route{Name: "meow.create", Path: "/meows"}
+	MeowShow   = route{Name: "meow.show", Path: "/meows/:id"}
+	MeowEdit   = route{Name: "meow.edit", Path: "/meows/:id/edit"}
+	MeowUpdate = route{Name: "meow.update", Path: "/meows/:id"}
+	MeowDelete = route{Name: "meow.delete", Path: "/meows/:id/delete"}
+	MeowHome   = route{Name: "meow.home", Path: "/home"}
+	MeowSearch = route{Name: "meow.search", Path: "/search"}
+	MeowTag    = route{Name: "meow.tag", Path: "/tags/:tag"}
+	MeowEvents = route{Name: "meow.events", Path: "/meows/events"}
+
+	// Meow interactions, answering HTMX requests with the updated actions of the meow
+	MeowLike     = route{Name: "meow.like", Path: "/meows/:id/like"}
+	MeowUnlike   = route{Name: "meow.unlike", Path: "/meows/:id/unlike"}
+	MeowRepost   = route{Name: "meow.repost", Path: "/meows/:id/repost"}
+	MeowUnrepost = route{Name: "meow.unrepost", Path: "/meows/:id/unrepost"}
+
+	// Users
+	UserShow      = route{Name: "user.show", Path: "/@:username"}
+	UserFollow    = route{Name: "user.follow", Path: "/@:username/follow"}
+	UserUnfollow  = route{Name: "user.unfollow", Path: "/@:username/unfollow"}
+	UserFollowers = route{Name: "user.followers", Path: "/@:username/followers"}
+	UserFollowing = route{Name: "user.following", Path: "/@:username/following"}
 )
 
+// URL returns the path of the route with its :params replaced, such as
+// routes.MeowShow.URL(c, fiber.Map{"id": meow.Id}). It returns "" and logs
+// the error for unknown routes or missing params.
 func (r *route) URL(c *fiber.Ctx, params fiber.Map) string {
 	url, err := c.GetRouteURL(r.Name, params)
 	if err != nil {
-		c.Next()
-		fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		log.Error("failed to build route URL", "route", r.Name, "error", err)
+		return ""
 	}
 	return url
 }
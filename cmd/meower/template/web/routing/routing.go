@@ -9,6 +9,11 @@ func RegisterRoutes(app *handlers.App) {
 	// Static
 	app.Web.Static("/static", "/src/web/static/public/").Name("static")
 
+	// Health probes, used by docker compose and orchestrators
+	health := handlers.NewHealth(app)
+	app.Web.Get(routes.Healthz.Path, health.Live).Name(routes.Healthz.Name)
+	app.Web.Get(routes.Readyz.Path, health.Ready).Name(routes.Readyz.Name)
+
 	// Homepage (available to all) - Register first to avoid conflicts
 	homepage := handlers.Homepage{App: app}
 	app.Web.Get(routes.Homepage.Path, handlers.OptionalAuthMiddleware(app.SessionStore), homepage.Homepage).Name(routes.Homepage.Name)
@@ -31,4 +36,26 @@ func RegisterRoutes(app *handlers.App) {
 	app.Web.Get(routes.MeowIndex.Path, handlers.AuthMiddleware(app.SessionStore), meower.Index).Name(routes.MeowIndex.Name)
 	app.Web.Get(routes.MeowNew.Path, handlers.AuthMiddleware(app.SessionStore), meower.New).Name(routes.MeowNew.Name)
 	app.Web.Post(routes.MeowCreate.Path, handlers.AuthMiddleware(app.SessionStore), meower.Create).Name(routes.MeowCreate.Name)
+	app.Web.Get(routes.MeowEdit.Path, handlers.AuthMiddleware(app.SessionStore), meower.Edit).Name(routes.MeowEdit.Name)
+	app.Web.Post(routes.MeowUpdate.Path, handlers.AuthMiddleware(app.SessionStore), meower.Update).Name(routes.MeowUpdate.Name)
+	app.Web.Post(routes.MeowDelete.Path, handlers.AuthMiddleware(app.SessionStore), meower.Delete).Name(routes.MeowDelete.Name)
+	app.Web.Get(routes.MeowHome.Path, handlers.AuthMiddleware(app.SessionStore), meower.Home).Name(routes.MeowHome.Name)
+	app.Web.Get(routes.MeowEvents.Path, handlers.AuthMiddleware(app.SessionStore), meower.Events).Name(routes.MeowEvents.Name)
+	app.Web.Post(routes.MeowLike.Path, handlers.AuthMiddleware(app.SessionStore), meower.Like).Name(routes.MeowLike.Name)
+	app.Web.Post(routes.MeowUnlike.Path, handlers.AuthMiddleware(app.SessionStore), meower.Unlike).Name(routes.MeowUnlike.Name)
+	app.Web.Post(routes.MeowRepost.Path, handlers.AuthMiddleware(app.SessionStore), meower.Repost).Name(routes.MeowRepost.Name)
+	app.Web.Post(routes.MeowUnrepost.Path, handlers.AuthMiddleware(app.SessionStore), meower.Unrepost).Name(routes.MeowUnrepost.Name)
+
+	// Follow routes (authenticated users only)
+	follows := handlers.Follows{App: app}
+	app.Web.Post(routes.UserFollow.Path, handlers.AuthMiddleware(app.SessionStore), follows.Follow).Name(routes.UserFollow.Name)
+	app.Web.Post(routes.UserUnfollow.Path, handlers.AuthMiddleware(app.SessionStore), follows.Unfollow).Name(routes.UserUnfollow.Name)
+
+	// Public meows, user timelines, follow lists, tags and search, registered after /meows/new
+	app.Web.Get(routes.MeowShow.Path, handlers.OptionalAuthMiddleware(app.SessionStore), meower.Show).Name(routes.MeowShow.Name)
+	app.Web.Get(routes.MeowSearch.Path, handlers.OptionalAuthMiddleware(app.SessionStore), meower.Search).Name(routes.MeowSearch.Name)
+	app.Web.Get(routes.MeowTag.Path, handlers.OptionalAuthMiddleware(app.SessionStore), meower.Tag).Name(routes.MeowTag.Name)
+	app.Web.Get(routes.UserShow.Path, handlers.OptionalAuthMiddleware(app.SessionStore), meower.User).Name(routes.UserShow.Name)
+	app.Web.Get(routes.UserFollowers.Path, handlers.OptionalAuthMiddleware(app.SessionStore), follows.Followers).Name(routes.UserFollowers.Name)
+	app.Web.Get(routes.UserFollowing.Path, handlers.OptionalAuthMiddleware(app.SessionStore), follows.Following).Name(routes.UserFollowing.Name)
 }
@@ -0,0 +1,13 @@
+// Live lists: prepends the meows streamed by the meow events route to lists
+// with a data-live-url. The browser reconnects on its own, sending the ID of
+// the last event received so no meow is missed.
+document.addEventListener("DOMContentLoaded", () => {
+  document.querySelectorAll("[data-live-url]").forEach((list) => {
+    const source = new EventSource(list.dataset.liveUrl);
+    source.addEventListener("meow", (event) => {
+      list.insertAdjacentHTML("afterbegin", event.data);
+      // Enable the hx-* attributes of the like and repost buttons
+      window.htmx?.process(list.firstElementChild);
+    });
+  });
+});
@@ -0,0 +1,83 @@
+// Package content parses the #tags and @mentions of meows, for the API to
+// store them and for views to link them.
+package content
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Kind is the kind of a segment of content
+type Kind int
+
+const (
+	Text Kind = iota
+	Tag
+	Mention
+)
+
+// Segment is a part of content. Value is the text of Text segments, the
+// lowercase name of Tag segments and the username of Mention segments.
+type Segment struct {
+	Kind  Kind
+	Text  string
+	Value string
+}
+
+// tokenRegex matches #tags and @mentions, which must not follow a letter,
+// digit or underscore so that emails aren't mentions
+var tokenRegex = regexp.MustCompile(`[#@][\p{L}\p{N}_]+`)
+
+// Split splits content into text, #tags and @mentions
+func Split(content string) []Segment {
+	var segments []Segment
+	last := 0
+	for _, loc := range tokenRegex.FindAllStringIndex(content, -1) {
+		start, end := loc[0], loc[1]
+		if r, _ := utf8.DecodeLastRuneInString(content[:start]); start > 0 && isWord(r) {
+			continue
+		}
+
+		if start > last {
+			segments = append(segments, Segment{Kind: Text, Text: content[last:start], Value: content[last:start]})
+		}
+		if content[start] == '#' {
+			segments = append(segments, Segment{Kind: Tag, Text: content[start:end], Value: strings.ToLower(content[start+1 : end])})
+		} else {
+			segments = append(segments, Segment{Kind: Mention, Text: content[start:end], Value: content[start+1 : end]})
+		}
+		last = end
+	}
+	if last < len(content) {
+		segments = append(segments, Segment{Kind: Text, Text: content[last:], Value: content[last:]})
+	}
+	return segments
+}
+
+// Tags returns the lowercase names of the #tags of content, without
+// duplicates
+func Tags(content string) []string {
+	return values(content, Tag)
+}
+
+// Mentions returns the usernames @mentioned in content, without duplicates
+func Mentions(content string) []string {
+	return values(content, Mention)
+}
+
+func values(content string, kind Kind) []string {
+	var values []string
+	for _, segment := range Split(content) {
+		if segment.Kind == kind && !slices.Contains(values, segment.Value) {
+			values = append(values, segment.Value)
+		}
+	}
+	return values
+}
+
+func isWord(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
@@ -0,0 +1,53 @@
+package content
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Segment
+	}{
+		{name: "empty", content: ""},
+		{name: "text", content: "Hello", want: []Segment{{Kind: Text, Text: "Hello", Value: "Hello"}}},
+		{
+			name:    "tag and mention",
+			content: "Hi @meower, #Caturday!",
+			want: []Segment{
+				{Kind: Text, Text: "Hi ", Value: "Hi "},
+				{Kind: Mention, Text: "@meower", Value: "meower"},
+				{Kind: Text, Text: ", ", Value: ", "},
+				{Kind: Tag, Text: "#Caturday", Value: "caturday"},
+				{Kind: Text, Text: "!", Value: "!"},
+			},
+		},
+		{name: "email", content: "cat@example.com", want: []Segment{{Kind: Text, Text: "cat@example.com", Value: "cat@example.com"}}},
+		{name: "unicode tag", content: "#café", want: []Segment{{Kind: Tag, Text: "#café", Value: "café"}}},
+		{name: "lone sign", content: "# @", want: []Segment{{Kind: Text, Text: "# @", Value: "# @"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Split(tt.content); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Split(%q) = %v, want %v", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagsAndMentions(t *testing.T) {
+	content := "#cats and #Cats with @meower and @kitten, cc @meower"
+
+	if got, want := Tags(content), []string{"cats"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Tags() = %v, want %v", got, want)
+	}
+	if got, want := Mentions(content), []string{"meower", "kitten"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Mentions() = %v, want %v", got, want)
+	}
+	if got := Tags("no tags"); len(got) != 0 {
+		t.Errorf(`Tags("no tags") = %v, want none`, got)
+	}
+}
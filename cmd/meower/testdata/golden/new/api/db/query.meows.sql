@@ -0,0 +1,157 @@
+-- name: ShowMeow :one
+SELECT sqlc.embed(meows), users.username, users.display_name,
+  (SELECT COUNT(*) FROM likes WHERE likes.meow_id = meows.id)::bigint AS like_count,
+  (SELECT COUNT(*) FROM meows AS replies WHERE replies.parent_id = meows.id)::bigint AS reply_count,
+  (SELECT COUNT(*) FROM reposts WHERE reposts.meow_id = meows.id)::bigint AS repost_count,
+  EXISTS (SELECT 1 FROM likes WHERE likes.meow_id = meows.id AND likes.user_id = sqlc.narg(viewer_id)) AS liked_by_me,
+  EXISTS (SELECT 1 FROM reposts WHERE reposts.meow_id = meows.id AND reposts.user_id = sqlc.narg(viewer_id)) AS reposted_by_me
+FROM meows
+JOIN users ON users.id = meows.user_id
+WHERE meows.id = sqlc.arg(id)
+LIMIT 1;
+-- name: CreateMeow :one
+INSERT INTO meows (user_id, content, parent_id)
+VALUES ($1, $2, $3)
+RETURNING *;
+-- name: UpdateMeow :one
+UPDATE meows
+SET content = $2
+WHERE id = $1
+RETURNING *;
+-- name: DeleteMeow :exec
+DELETE FROM meows
+WHERE id = $1;
+-- name: IndexMeows :many
+SELECT sqlc.embed(meows), users.username, users.display_name,
+  (SELECT COUNT(*) FROM likes WHERE likes.meow_id = meows.id)::bigint AS like_count,
+  (SELECT COUNT(*) FROM meows AS replies WHERE replies.parent_id = meows.id)::bigint AS reply_count,
+  (SELECT COUNT(*) FROM reposts WHERE reposts.meow_id = meows.id)::bigint AS repost_count,
+  EXISTS (SELECT 1 FROM likes WHERE likes.meow_id = meows.id AND likes.user_id = sqlc.narg(viewer_id)) AS liked_by_me,
+  EXISTS (SELECT 1 FROM reposts WHERE reposts.meow_id = meows.id AND reposts.user_id = sqlc.narg(viewer_id)) AS reposted_by_me
+FROM meows
+JOIN users ON users.id = meows.user_id
+WHERE sqlc.narg(before_created_at)::timestamp IS NULL
+  OR (meows.created_at, meows.id) < (sqlc.narg(before_created_at), sqlc.narg(before_id)::uuid)
+ORDER BY meows.created_at DESC, meows.id DESC
+LIMIT sqlc.arg(page_size);
+-- name: ListMeowsByUser :many
+SELECT sqlc.embed(meows), users.username, users.display_name,
+  (SELECT COUNT(*) FROM likes WHERE likes.meow_id = meows.id)::bigint AS like_count,
+  (SELECT COUNT(*) FROM meows AS replies WHERE replies.parent_id = meows.id)::bigint AS reply_count,
+  (SELECT COUNT(*) FROM reposts WHERE reposts.meow_id = meows.id)::bigint AS repost_count,
+  EXISTS (SELECT 1 FROM likes WHERE likes.meow_id = meows.id AND likes.user_id = sqlc.narg(viewer_id)) AS liked_by_me,
+  EXISTS (SELECT 1 FROM reposts WHERE reposts.meow_id = meows.id AND reposts.user_id = sqlc.narg(viewer_id)) AS reposted_by_me
+FROM meows
+JOIN users ON users.id = meows.user_id
+WHERE meows.user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(before_created_at)::timestamp IS NULL
+    OR (meows.created_at, meows.id) < (sqlc.narg(before_created_at), sqlc.narg(before_id)::uuid))
+ORDER BY meows.created_at DESC, meows.id DESC
+LIMIT sqlc.arg(page_size);
+-- name: ListMeowsByParent :many
+SELECT sqlc.embed(meows), users.username, users.display_name,
+  (SELECT COUNT(*) FROM likes WHERE likes.meow_id = meows.id)::bigint AS like_count,
+  (SELECT COUNT(*) FROM meows AS replies WHERE replies.parent_id = meows.id)::bigint AS reply_count,
+  (SELECT COUNT(*) FROM reposts WHERE reposts.meow_id = meows.id)::bigint AS repost_count,
+  EXISTS (SELECT 1 FROM likes WHERE likes.meow_id = meows.id AND likes.user_id = sqlc.narg(viewer_id)) AS liked_by_me,
+  EXISTS (SELECT 1 FROM reposts WHERE reposts.meow_id = meows.id AND reposts.user_id = sqlc.narg(viewer_id)) AS reposted_by_me
+FROM meows
+JOIN users ON users.id = meows.user_id
+WHERE meows.parent_id = sqlc.arg(parent_id)
+  AND (sqlc.narg(before_created_at)::timestamp IS NULL
+    OR (meows.created_at, meows.id) < (sqlc.narg(before_created_at), sqlc.narg(before_id)::uuid))
+ORDER BY meows.created_at DESC, meows.id DESC
+LIMIT sqlc.arg(page_size);
+-- name: ListMeowsByTag :many
+SELECT sqlc.embed(meows), users.username, users.display_name,
+  (SELECT COUNT(*) FROM likes WHERE likes.meow_id = meows.id)::bigint AS like_count,
+  (SELECT COUNT(*) FROM meows AS replies WHERE replies.parent_id = meows.id)::bigint AS reply_count,
+  (SELECT COUNT(*) FROM reposts WHERE reposts.meow_id = meows.id)::bigint AS repost_count,
+  EXISTS (SELECT 1 FROM likes WHERE likes.meow_id = meows.id AND likes.user_id = sqlc.narg(viewer_id)) AS liked_by_me,
+  EXISTS (SELECT 1 FROM reposts WHERE reposts.meow_id = meows.id AND reposts.user_id = sqlc.narg(viewer_id)) AS reposted_by_me
+FROM meows
+JOIN users ON users.id = meows.user_id
+JOIN meow_tags ON meow_tags.meow_id = meows.id
+WHERE meow_tags.tag = sqlc.arg(tag)
+  AND (sqlc.narg(before_created_at)::timestamp IS NULL
+    OR (meows.created_at, meows.id) < (sqlc.narg(before_created_at), sqlc.narg(before_id)::uuid))
+ORDER BY meows.created_at DESC, meows.id DESC
+LIMIT sqlc.arg(page_size);
+-- name: SearchMeows :many
+SELECT sqlc.embed(meows), users.username, users.display_name,
+  (SELECT COUNT(*) FROM likes WHERE likes.meow_id = meows.id)::bigint AS like_count,
+  (SELECT COUNT(*) FROM meows AS replies WHERE replies.parent_id = meows.id)::bigint AS reply_count,
+  (SELECT COUNT(*) FROM reposts WHERE reposts.meow_id = meows.id)::bigint AS repost_count,
+  EXISTS (SELECT 1 FROM likes WHERE likes.meow_id = meows.id AND likes.user_id = sqlc.narg(viewer_id)) AS liked_by_me,
+  EXISTS (SELECT 1 FROM reposts WHERE reposts.meow_id = meows.id AND reposts.user_id = sqlc.narg(viewer_id)) AS reposted_by_me,
+  ts_rank(meows.search, websearch_to_tsquery('english', sqlc.arg(query)::text))::real AS rank
+FROM meows
+JOIN users ON users.id = meows.user_id
+WHERE meows.search @@ websearch_to_tsquery('english', sqlc.arg(query)::text)
+  AND (sqlc.narg(before_rank)::real IS NULL
+    OR (ts_rank(meows.search, websearch_to_tsquery('english', sqlc.arg(query)::text)), meows.created_at, meows.id)
+      < (sqlc.narg(before_rank), sqlc.narg(before_created_at)::timestamp, sqlc.narg(before_id)::uuid))
+ORDER BY rank DESC, meows.created_at DESC, meows.id DESC
+LIMIT sqlc.arg(page_size);
+-- name: HomeTimeline :many
+SELECT sqlc.embed(meows), users.username, users.display_name,
+  (SELECT COUNT(*) FROM likes WHERE likes.meow_id = meows.id)::bigint AS like_count,
+  (SELECT COUNT(*) FROM meows AS replies WHERE replies.parent_id = meows.id)::bigint AS reply_count,
+  (SELECT COUNT(*) FROM reposts WHERE reposts.meow_id = meows.id)::bigint AS repost_count,
+  EXISTS (SELECT 1 FROM likes WHERE likes.meow_id = meows.id AND likes.user_id = sqlc.arg(user_id)) AS liked_by_me,
+  EXISTS (SELECT 1 FROM reposts WHERE reposts.meow_id = meows.id AND reposts.user_id = sqlc.arg(user_id)) AS reposted_by_me
+FROM meows
+JOIN users ON users.id = meows.user_id
+WHERE (meows.user_id = sqlc.arg(user_id)
+    OR meows.user_id IN (SELECT followed_id FROM follows WHERE follower_id = sqlc.arg(user_id)))
+  AND (sqlc.narg(before_created_at)::timestamp IS NULL
+    OR (meows.created_at, meows.id) < (sqlc.narg(before_created_at), sqlc.narg(before_id)::uuid))
+ORDER BY meows.created_at DESC, meows.id DESC
+LIMIT sqlc.arg(page_size);
+-- name: ListMeowsSince :many
+SELECT sqlc.embed(meows), users.username, users.display_name,
+  (SELECT COUNT(*) FROM likes WHERE likes.meow_id = meows.id)::bigint AS like_count,
+  (SELECT COUNT(*) FROM meows AS replies WHERE replies.parent_id = meows.id)::bigint AS reply_count,
+  (SELECT COUNT(*) FROM reposts WHERE reposts.meow_id = meows.id)::bigint AS repost_count,
+  EXISTS (SELECT 1 FROM likes WHERE likes.meow_id = meows.id AND likes.user_id = sqlc.narg(viewer_id)) AS liked_by_me,
+  EXISTS (SELECT 1 FROM reposts WHERE reposts.meow_id = meows.id AND reposts.user_id = sqlc.narg(viewer_id)) AS reposted_by_me
+FROM meows
+JOIN users ON users.id = meows.user_id
+WHERE (meows.created_at, meows.id) > (sqlc.arg(after_created_at)::timestamp, sqlc.arg(after_id)::uuid)
+  AND (NOT sqlc.arg(home)::boolean
+    OR meows.user_id = sqlc.narg(viewer_id)
+    OR meows.user_id IN (SELECT followed_id FROM follows WHERE follower_id = sqlc.narg(viewer_id)))
+ORDER BY meows.created_at, meows.id
+LIMIT sqlc.arg(page_size);
+-- name: LikeMeow :exec
+INSERT INTO likes (user_id, meow_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+-- name: UnlikeMeow :exec
+DELETE FROM likes
+WHERE user_id = $1
+  AND meow_id = $2;
+-- name: RepostMeow :exec
+INSERT INTO reposts (user_id, meow_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+-- name: UnrepostMeow :exec
+DELETE FROM reposts
+WHERE user_id = $1
+  AND meow_id = $2;
+-- name: DeleteMeowTags :exec
+DELETE FROM meow_tags
+WHERE meow_id = $1;
+-- name: AddMeowTags :exec
+INSERT INTO meow_tags (meow_id, tag)
+SELECT sqlc.arg(meow_id)::uuid, unnest(sqlc.arg(tags)::text[])
+ON CONFLICT DO NOTHING;
+-- name: DeleteMeowMentions :exec
+DELETE FROM meow_mentions
+WHERE meow_id = $1;
+-- name: AddMeowMentions :exec
+INSERT INTO meow_mentions (meow_id, user_id)
+SELECT sqlc.arg(meow_id)::uuid, users.id
+FROM users
+WHERE users.username = ANY (sqlc.arg(usernames)::text[])
+ON CONFLICT DO NOTHING;
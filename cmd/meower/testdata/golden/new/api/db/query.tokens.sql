@@ -0,0 +1,23 @@
+-- name: CreateToken :one
+INSERT INTO tokens (user_id, expires_at)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetActiveToken :one
+SELECT * FROM tokens
+WHERE id = $1
+  AND revoked_at IS NULL
+  AND expires_at > NOW()
+LIMIT 1;
+
+-- name: RevokeToken :exec
+UPDATE tokens
+SET revoked_at = NOW()
+WHERE id = $1
+  AND revoked_at IS NULL;
+
+-- name: RevokeUserTokens :exec
+UPDATE tokens
+SET revoked_at = NOW()
+WHERE user_id = $1
+  AND revoked_at IS NULL;
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/test/test-project/api/config"
+	"github.com/test/test-project/api/server"
+)
+
+func main() {
+	var cfg config.API
+	if err := config.Load(&cfg); err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
+	}
+
+	// Shut down gracefully on Ctrl+C and `docker compose stop`
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := server.Serve(ctx, &cfg); err != nil {
+		log.Fatal(err)
+	}
+}
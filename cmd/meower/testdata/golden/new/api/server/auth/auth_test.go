@@ -0,0 +1,263 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	userV1 "github.com/test/test-project/api/proto/user/v1"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testKey = "MDEyMzQ1Njc4OWFiY2RlZg=="
+
+// tokenDB is an in-memory tokens table implementing db.DBTX for the token
+// queries
+type tokenDB struct {
+	tokens map[pgtype.UUID]*tokenRow
+	next   byte
+}
+
+type tokenRow struct {
+	userID    pgtype.UUID
+	expiresAt pgtype.Timestamp
+	revoked   bool
+}
+
+func newTokenDB() *tokenDB {
+	return &tokenDB{tokens: map[pgtype.UUID]*tokenRow{}}
+}
+
+func (d *tokenDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	switch {
+	case strings.Contains(sql, "name: RevokeToken "):
+		if row, ok := d.tokens[args[0].(pgtype.UUID)]; ok {
+			row.revoked = true
+		}
+	case strings.Contains(sql, "name: RevokeUserTokens "):
+		for _, row := range d.tokens {
+			if row.userID == args[0].(pgtype.UUID) {
+				row.revoked = true
+			}
+		}
+	}
+	return pgconn.NewCommandTag("UPDATE"), nil
+}
+
+func (d *tokenDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return nil, errors.New("unexpected query")
+}
+
+func (d *tokenDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	switch {
+	case strings.Contains(sql, "name: CreateToken "):
+		d.next++
+		id := pgtype.UUID{Bytes: [16]byte{d.next}, Valid: true}
+		d.tokens[id] = &tokenRow{userID: args[0].(pgtype.UUID), expiresAt: args[1].(pgtype.Timestamp)}
+		return d.scan(id)
+	case strings.Contains(sql, "name: GetActiveToken "):
+		id := args[0].(pgtype.UUID)
+		if row, ok := d.tokens[id]; !ok || row.revoked || !row.expiresAt.Time.After(time.Now()) {
+			return errRow{pgx.ErrNoRows}
+		}
+		return d.scan(id)
+	}
+	return errRow{errors.New("unexpected query")}
+}
+
+// scan returns the row of the token id, scanned as a db.Token
+func (d *tokenDB) scan(id pgtype.UUID) pgx.Row {
+	row := d.tokens[id]
+	return scanRow(func(dest ...any) error {
+		*dest[0].(*pgtype.UUID) = id
+		*dest[1].(*pgtype.UUID) = row.userID
+		*dest[3].(*pgtype.Timestamp) = row.expiresAt
+		return nil
+	})
+}
+
+type scanRow func(dest ...any) error
+
+func (f scanRow) Scan(dest ...any) error { return f(dest...) }
+
+type errRow struct{ err error }
+
+func (r errRow) Scan(dest ...any) error { return r.err }
+
+func newTestTokens(t *testing.T, dbtx *tokenDB, ttl time.Duration) *Tokens {
+	t.Helper()
+
+	tokens, err := NewTokens(dbtx, testKey, ttl)
+	if err != nil {
+		t.Fatalf("NewTokens() error = %v", err)
+	}
+	return tokens
+}
+
+var testUser = pgtype.UUID{Bytes: [16]byte{0xca, 0x7}, Valid: true}
+
+func TestNewTokens(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{name: "valid key", key: testKey},
+		{name: "not base64", key: "not a key!", wantErr: true},
+		{name: "too short", key: "c2hvcnQ=", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTokens(newTokenDB(), tt.key, time.Hour)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewTokens() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		// token returns the token to verify
+		token func(t *testing.T, dbtx *tokenDB) string
+		want  error
+	}{
+		{
+			name: "valid",
+			token: func(t *testing.T, dbtx *tokenDB) string {
+				token, _ := newTestTokens(t, dbtx, time.Hour).Issue(ctx, testUser)
+				return token
+			},
+		},
+		{
+			name: "tampered",
+			token: func(t *testing.T, dbtx *tokenDB) string {
+				token, _ := newTestTokens(t, dbtx, time.Hour).Issue(ctx, testUser)
+				return token[:len(token)-2] + "AA"
+			},
+			want: ErrInvalidToken,
+		},
+		{
+			name: "signed with another key",
+			token: func(t *testing.T, dbtx *tokenDB) string {
+				other, _ := NewTokens(dbtx, "ZmVkY2JhOTg3NjU0MzIxMA==", time.Hour)
+				token, _ := other.Issue(ctx, testUser)
+				return token
+			},
+			want: ErrInvalidToken,
+		},
+		{
+			name:  "malformed",
+			token: func(t *testing.T, dbtx *tokenDB) string { return "meow" },
+			want:  ErrInvalidToken,
+		},
+		{
+			name: "expired",
+			token: func(t *testing.T, dbtx *tokenDB) string {
+				token, _ := newTestTokens(t, dbtx, -time.Minute).Issue(ctx, testUser)
+				return token
+			},
+			want: ErrExpiredToken,
+		},
+		{
+			name: "revoked",
+			token: func(t *testing.T, dbtx *tokenDB) string {
+				tokens := newTestTokens(t, dbtx, time.Hour)
+				token, _ := tokens.Issue(ctx, testUser)
+				session, _ := tokens.Verify(ctx, token)
+				tokens.Revoke(ctx, session.TokenID)
+				return token
+			},
+			want: ErrRevokedToken,
+		},
+		{
+			name: "user revoked",
+			token: func(t *testing.T, dbtx *tokenDB) string {
+				tokens := newTestTokens(t, dbtx, time.Hour)
+				token, _ := tokens.Issue(ctx, testUser)
+				tokens.RevokeUser(ctx, testUser)
+				return token
+			},
+			want: ErrRevokedToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbtx := newTokenDB()
+			token := tt.token(t, dbtx)
+
+			session, err := newTestTokens(t, dbtx, time.Hour).Verify(ctx, token)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.want)
+			}
+			if err == nil && session.UserID != testUser {
+				t.Errorf("Verify() user = %x, want %x", session.UserID.Bytes, testUser.Bytes)
+			}
+		})
+	}
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	dbtx := newTokenDB()
+	tokens := newTestTokens(t, dbtx, time.Hour)
+	token, err := tokens.Issue(context.Background(), testUser)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+
+	private := "/" + userV1.UserService_ServiceDesc.ServiceName + "/Logout"
+	public := "/" + userV1.UserService_ServiceDesc.ServiceName + "/Login"
+	health := "/" + grpc_health_v1.Health_ServiceDesc.ServiceName + "/Check"
+
+	tests := []struct {
+		name     string
+		method   string
+		token    string
+		wantCode codes.Code
+		wantUser bool
+	}{
+		{name: "private with token", method: private, token: token, wantUser: true},
+		{name: "private without token", method: private, wantCode: codes.Unauthenticated},
+		{name: "private with invalid token", method: private, token: "meow", wantCode: codes.Unauthenticated},
+		{name: "public without token", method: public},
+		{name: "public with token", method: public, token: token, wantUser: true},
+		{name: "public with invalid token", method: public, token: "meow"},
+		{name: "public service", method: health},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.token != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+tt.token))
+			}
+
+			var gotUser bool
+			handler := func(ctx context.Context, req any) (any, error) {
+				_, gotUser = UserID(ctx)
+				return nil, nil
+			}
+			_, err := tokens.UnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("UnaryInterceptor() code = %v, want %v", code, tt.wantCode)
+			}
+			if gotUser != tt.wantUser {
+				t.Errorf("authenticated = %v, want %v", gotUser, tt.wantUser)
+			}
+		})
+	}
+}
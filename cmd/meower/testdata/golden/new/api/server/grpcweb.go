@@ -0,0 +1,33 @@
+package server
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/test/test-project/api/config"
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"google.golang.org/grpc"
+)
+
+// newGRPCWebServer returns an HTTP/1.1 server translating gRPC-Web requests,
+// such as those of @connectrpc/connect-web's gRPC-Web transport, to calls on
+// g. CORS preflight requests are answered for cfg.AllowedOrigins.
+func newGRPCWebServer(g *grpc.Server, cfg *config.API) *http.Server {
+	wrapped := grpcweb.WrapServer(g,
+		grpcweb.WithOriginFunc(originAllowed(cfg.AllowedOrigins)),
+	)
+
+	return &http.Server{
+		Addr:              cfg.GRPCWebAddr,
+		Handler:           wrapped,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
+
+// originAllowed returns a CORS origin check accepting the given origins
+func originAllowed(origins []string) func(origin string) bool {
+	return func(origin string) bool {
+		return slices.Contains(origins, "*") || slices.Contains(origins, origin)
+	}
+}
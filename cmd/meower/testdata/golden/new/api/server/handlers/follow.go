@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+
+	"github.com/test/test-project/api/db"
+	followV1 "github.com/test/test-project/api/proto/follow/v1"
+	"github.com/test/test-project/api/server/auth"
+	"github.com/test/test-project/api/server/pagination"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type followServiceServer struct {
+	followV1.UnimplementedFollowServiceServer
+	db db.DBTX
+}
+
+func NewFollowServer(dbtx db.DBTX) followV1.FollowServiceServer {
+	return &followServiceServer{db: dbtx}
+}
+
+// Helper function to convert the columns of a listed user to a proto profile
+func dbProfileToProto(id pgtype.UUID, username, displayName string) *followV1.Profile {
+	return &followV1.Profile{
+		Id:          hex.EncodeToString(id.Bytes[:]),
+		Username:    username,
+		DisplayName: displayName,
+	}
+}
+
+// Helper function to get the ID of a user by username
+func (s *followServiceServer) userID(ctx context.Context, username string) (pgtype.UUID, error) {
+	user, err := db.New(s.db).GetUserByUsername(ctx, username)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return pgtype.UUID{}, status.Errorf(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		return pgtype.UUID{}, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	return user.ID, nil
+}
+
+// Follow makes the caller follow a user, doing nothing if they already do
+func (s *followServiceServer) Follow(ctx context.Context, req *followV1.FollowRequest) (*followV1.FollowResponse, error) {
+	callerID, ok := auth.UserID(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "log in to follow users")
+	}
+
+	userID, err := s.userID(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+	if userID == callerID {
+		return nil, status.Errorf(codes.InvalidArgument, "you can't follow yourself")
+	}
+
+	if err := db.New(s.db).FollowUser(ctx, db.FollowUserParams{FollowerID: callerID, FollowedID: userID}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to follow user: %v", err)
+	}
+
+	return &followV1.FollowResponse{}, nil
+}
+
+// Unfollow makes the caller stop following a user, doing nothing if they
+// don't
+func (s *followServiceServer) Unfollow(ctx context.Context, req *followV1.UnfollowRequest) (*followV1.UnfollowResponse, error) {
+	callerID, ok := auth.UserID(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "log in to unfollow users")
+	}
+
+	userID, err := s.userID(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.New(s.db).UnfollowUser(ctx, db.UnfollowUserParams{FollowerID: callerID, FollowedID: userID}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unfollow user: %v", err)
+	}
+
+	return &followV1.UnfollowResponse{}, nil
+}
+
+func (s *followServiceServer) ListFollowers(ctx context.Context, req *followV1.ListFollowersRequest) (*followV1.ListFollowersResponse, error) {
+	cursor, err := pagination.Decode(req.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	size := pagination.Size(req.PageSize)
+
+	userID, err := s.userID(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	followers, err := db.New(s.db).ListFollowers(ctx, db.ListFollowersParams{
+		UserID:          userID,
+		BeforeCreatedAt: cursor.CreatedAt,
+		BeforeID:        cursor.ID,
+		PageSize:        size + 1,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list followers: %v", err)
+	}
+	followers, next := pagination.Page(followers, size, func(row db.ListFollowersRow) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: row.CreatedAt, ID: row.ID}
+	})
+
+	var resp []*followV1.Profile
+	for _, row := range followers {
+		resp = append(resp, dbProfileToProto(row.ID, row.Username, row.DisplayName))
+	}
+
+	return &followV1.ListFollowersResponse{Users: resp, NextPageToken: next}, nil
+}
+
+func (s *followServiceServer) ListFollowing(ctx context.Context, req *followV1.ListFollowingRequest) (*followV1.ListFollowingResponse, error) {
+	cursor, err := pagination.Decode(req.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	size := pagination.Size(req.PageSize)
+
+	userID, err := s.userID(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	following, err := db.New(s.db).ListFollowing(ctx, db.ListFollowingParams{
+		UserID:          userID,
+		BeforeCreatedAt: cursor.CreatedAt,
+		BeforeID:        cursor.ID,
+		PageSize:        size + 1,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list followed users: %v", err)
+	}
+	following, next := pagination.Page(following, size, func(row db.ListFollowingRow) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: row.CreatedAt, ID: row.ID}
+	})
+
+	var resp []*followV1.Profile
+	for _, row := range following {
+		resp = append(resp, dbProfileToProto(row.ID, row.Username, row.DisplayName))
+	}
+
+	return &followV1.ListFollowingResponse{Users: resp, NextPageToken: next}, nil
+}
+
+func (s *followServiceServer) GetFollowStats(ctx context.Context, req *followV1.GetFollowStatsRequest) (*followV1.GetFollowStatsResponse, error) {
+	userID, err := s.userID(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	queries := db.New(s.db)
+	resp := &followV1.GetFollowStatsResponse{}
+	if resp.FollowersCount, err = queries.CountFollowers(ctx, userID); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count followers: %v", err)
+	}
+	if resp.FollowingCount, err = queries.CountFollowing(ctx, userID); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count followed users: %v", err)
+	}
+
+	if callerID, ok := auth.UserID(ctx); ok && callerID != userID {
+		resp.Followed, err = queries.IsFollowing(ctx, db.IsFollowingParams{FollowerID: callerID, FollowedID: userID})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get follow: %v", err)
+		}
+	}
+
+	return resp, nil
+}
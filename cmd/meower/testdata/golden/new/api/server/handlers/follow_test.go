@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	followV1 "github.com/test/test-project/api/proto/follow/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newFollowClient(t *testing.T, fake *fakeDB) followV1.FollowServiceClient {
+	conn := startServer(t, func(g *grpc.Server) {
+		followV1.RegisterFollowServiceServer(g, NewFollowServer(fake))
+	})
+	return followV1.NewFollowServiceClient(conn)
+}
+
+// followedRow returns the users table row of "cat", the user testUUID(2)
+func followedRow(t *testing.T) []any {
+	row := userRow(t, "cat", "secret")
+	row[0] = testUUID(2)
+	return row
+}
+
+// profileRow returns a row of ListFollowers or ListFollowing
+func profileRow(id byte, username string) []any {
+	return []any{testUUID(id), username, "Test User", testTimestamp()}
+}
+
+func TestFollowServiceFollow(t *testing.T) {
+	tests := []struct {
+		name string
+		// caller is the user calling, 0 for anonymous calls
+		caller    byte
+		username  string
+		call      func(ctx context.Context, c followV1.FollowServiceClient, username string) error
+		wantCode  codes.Code
+		wantQuery string
+	}{
+		{
+			name:   "follow",
+			caller: 1, username: "cat",
+			call: func(ctx context.Context, c followV1.FollowServiceClient, username string) error {
+				_, err := c.Follow(ctx, &followV1.FollowRequest{Username: username})
+				return err
+			},
+			wantQuery: "FollowUser",
+		},
+		{
+			name:   "unfollow",
+			caller: 1, username: "cat",
+			call: func(ctx context.Context, c followV1.FollowServiceClient, username string) error {
+				_, err := c.Unfollow(ctx, &followV1.UnfollowRequest{Username: username})
+				return err
+			},
+			wantQuery: "UnfollowUser",
+		},
+		{
+			name:   "follow yourself",
+			caller: 2, username: "cat",
+			call: func(ctx context.Context, c followV1.FollowServiceClient, username string) error {
+				_, err := c.Follow(ctx, &followV1.FollowRequest{Username: username})
+				return err
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:   "follow unknown user",
+			caller: 1, username: "dog",
+			call: func(ctx context.Context, c followV1.FollowServiceClient, username string) error {
+				_, err := c.Follow(ctx, &followV1.FollowRequest{Username: username})
+				return err
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "follow anonymously",
+			username: "cat",
+			call: func(ctx context.Context, c followV1.FollowServiceClient, username string) error {
+				_, err := c.Follow(ctx, &followV1.FollowRequest{Username: username})
+				return err
+			},
+			wantCode: codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeDB{rows: map[string][][]any{}}
+			if tt.username == "cat" {
+				fake.rows["GetUserByUsername"] = [][]any{followedRow(t)}
+			}
+			client := newFollowClient(t, fake)
+
+			ctx := context.Background()
+			if tt.caller != 0 {
+				ctx = asUser(ctx, testUUID(tt.caller))
+			}
+			err := tt.call(ctx, client, tt.username)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("code = %v, want %v (%v)", status.Code(err), tt.wantCode, err)
+			}
+			if tt.wantQuery != "" && !slices.Contains(fake.queries, tt.wantQuery) {
+				t.Errorf("queries = %v, want %s", fake.queries, tt.wantQuery)
+			}
+		})
+	}
+}
+
+func TestFollowServiceListFollowers(t *testing.T) {
+	fake := &fakeDB{rows: map[string][][]any{
+		"GetUserByUsername": {followedRow(t)},
+		"ListFollowers":     {profileRow(3, "kitten"), profileRow(1, "meower")},
+	}}
+	client := newFollowClient(t, fake)
+
+	resp, err := client.ListFollowers(context.Background(), &followV1.ListFollowersRequest{Username: "cat", PageSize: 1})
+	if err != nil {
+		t.Fatalf("ListFollowers() error = %v", err)
+	}
+	if len(resp.GetUsers()) != 1 || resp.GetUsers()[0].GetUsername() != "kitten" {
+		t.Errorf("Users = %v, want a page with kitten", resp.GetUsers())
+	}
+	if resp.GetNextPageToken() == "" {
+		t.Error("NextPageToken is empty, want the token of the next page")
+	}
+
+	_, err = client.ListFollowers(context.Background(), &followV1.ListFollowersRequest{Username: "cat", PageToken: "meow"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListFollowers() with an invalid token code = %v, want %v", status.Code(err), codes.InvalidArgument)
+	}
+}
+
+func TestFollowServiceGetFollowStats(t *testing.T) {
+	tests := []struct {
+		name         string
+		caller       byte
+		wantFollowed bool
+	}{
+		{name: "anonymous"},
+		{name: "follower", caller: 1, wantFollowed: true},
+		{name: "own profile", caller: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeDB{rows: map[string][][]any{
+				"GetUserByUsername": {followedRow(t)},
+				"CountFollowers":    {{int64(3)}},
+				"CountFollowing":    {{int64(1)}},
+				"IsFollowing":       {{true}},
+			}}
+			client := newFollowClient(t, fake)
+
+			ctx := context.Background()
+			if tt.caller != 0 {
+				ctx = asUser(ctx, testUUID(tt.caller))
+			}
+			resp, err := client.GetFollowStats(ctx, &followV1.GetFollowStatsRequest{Username: "cat"})
+			if err != nil {
+				t.Fatalf("GetFollowStats() error = %v", err)
+			}
+			if resp.GetFollowersCount() != 3 || resp.GetFollowingCount() != 1 {
+				t.Errorf("counts = %d, %d, want 3, 1", resp.GetFollowersCount(), resp.GetFollowingCount())
+			}
+			if resp.GetFollowed() != tt.wantFollowed {
+				t.Errorf("Followed = %v, want %v", resp.GetFollowed(), tt.wantFollowed)
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package handlers
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/test/test-project/api/db"
+	"github.com/test/test-project/api/server/auth"
+	"github.com/test/test-project/api/server/interceptors"
+	"github.com/charmbracelet/log"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startServer serves the services added by register on an in-memory bufconn
+// listener, behind the interceptors of the API server, and returns a client
+// connection to it. Both are closed when the test ends. Calls are anonymous
+// unless made with asUser.
+func startServer(t *testing.T, register func(*grpc.Server)) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	g := grpc.NewServer(append(interceptors.ServerOptions(log.New(io.Discard)),
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			return handler(testSession(ctx), req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			return handler(srv, &sessionStream{ServerStream: ss, ctx: testSession(ss.Context())})
+		}),
+	)...)
+	register(g)
+
+	go func() {
+		if err := g.Serve(lis); err != nil {
+			t.Errorf("failed to serve: %v", err)
+		}
+	}()
+	t.Cleanup(g.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial bufconn: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+// testUserKey is the metadata key read by the test server in place of a
+// session token, which package auth tests
+const testUserKey = "test-user-id"
+
+// asUser returns a copy of ctx calling the test server as the user
+func asUser(ctx context.Context, userID pgtype.UUID) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, testUserKey, hex.EncodeToString(userID.Bytes[:]))
+}
+
+// testSession adds the session of the user sent by asUser to ctx
+func testSession(ctx context.Context) context.Context {
+	values := metadata.ValueFromIncomingContext(ctx, testUserKey)
+	if len(values) == 0 {
+		return ctx
+	}
+	session := auth.Session{TokenID: testUUID(9), ExpiresAt: time.Now().Add(time.Hour)}
+	if err := session.UserID.Scan(values[0]); err != nil {
+		return ctx
+	}
+	return auth.NewContext(ctx, session)
+}
+
+// sessionStream replaces the context of a stream
+type sessionStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *sessionStream) Context() context.Context {
+	return s.ctx
+}
+
+// newTokens returns session tokens recorded in dbtx
+func newTokens(t *testing.T, dbtx db.DBTX) *auth.Tokens {
+	t.Helper()
+
+	tokens, err := auth.NewTokens(dbtx, "MDEyMzQ1Njc4OWFiY2RlZg==", time.Hour)
+	if err != nil {
+		t.Fatalf("failed to create tokens: %v", err)
+	}
+	return tokens
+}
+
+// fakeDB is an in-memory db.DBTX. Queries are answered with the rows stored
+// under their sqlc name (the "-- name:" comment sqlc puts in every query), so
+// tests don't need a running PostgreSQL.
+type fakeDB struct {
+	// rows maps a query name to the rows it returns, each row holding one
+	// value per selected column in table order
+	rows map[string][][]any
+	// err, when set, is returned by every query
+	err error
+	// failQuery, when set, names the only query that returns err
+	failQuery string
+	// queries records the name of every query run, in order, along with
+	// COMMIT and ROLLBACK for transactions
+	queries []string
+}
+
+// failing returns the error query should fail with, if any
+func (f *fakeDB) failing(query string) error {
+	if f.failQuery != "" && f.failQuery != query {
+		return nil
+	}
+	return f.err
+}
+
+func (f *fakeDB) Begin(ctx context.Context) (pgx.Tx, error) {
+	if f.failQuery == "" && f.err != nil {
+		return nil, f.err
+	}
+	return &fakeTx{db: f}, nil
+}
+
+// fakeTx is a transaction of a fakeDB. Its queries go to the fakeDB; only
+// the outcome of the transaction is recorded.
+type fakeTx struct {
+	pgx.Tx
+	db   *fakeDB
+	done bool
+}
+
+func (tx *fakeTx) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return tx.db.Exec(ctx, sql, args...)
+}
+
+func (tx *fakeTx) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return tx.db.Query(ctx, sql, args...)
+}
+
+func (tx *fakeTx) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return tx.db.QueryRow(ctx, sql, args...)
+}
+
+func (tx *fakeTx) Commit(ctx context.Context) error {
+	return tx.end("COMMIT")
+}
+
+func (tx *fakeTx) Rollback(ctx context.Context) error {
+	return tx.end("ROLLBACK")
+}
+
+func (tx *fakeTx) end(outcome string) error {
+	if tx.done {
+		return pgx.ErrTxClosed
+	}
+	tx.done = true
+	tx.db.queries = append(tx.db.queries, outcome)
+	return nil
+}
+
+func (f *fakeDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	name := queryName(sql)
+	f.queries = append(f.queries, name)
+	if err := f.failing(name); err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	return pgconn.NewCommandTag("OK"), nil
+}
+
+func (f *fakeDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	name := queryName(sql)
+	f.queries = append(f.queries, name)
+	if err := f.failing(name); err != nil {
+		return nil, err
+	}
+	return &fakeRows{rows: f.rows[name], index: -1}, nil
+}
+
+func (f *fakeDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	name := queryName(sql)
+	f.queries = append(f.queries, name)
+	if err := f.failing(name); err != nil {
+		return fakeRow{err: err}
+	}
+	if len(f.rows[name]) == 0 {
+		return fakeRow{err: pgx.ErrNoRows}
+	}
+	return fakeRow{values: f.rows[name][0]}
+}
+
+// queryName extracts the sqlc query name from "-- name: CreateMeow :one"
+func queryName(sql string) string {
+	line, _, _ := strings.Cut(sql, "\n")
+	fields := strings.Fields(strings.TrimPrefix(line, "-- name:"))
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// fakeRow is a single result row
+type fakeRow struct {
+	values []any
+	err    error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	return scanValues(r.values, dest)
+}
+
+// scanValues copies each value into the matching destination pointer
+func scanValues(values, dest []any) error {
+	if len(values) != len(dest) {
+		return fmt.Errorf("fake row has %d values, scanned into %d destinations", len(values), len(dest))
+	}
+	for i, value := range values {
+		target := reflect.ValueOf(dest[i]).Elem()
+		if value == nil {
+			target.SetZero()
+			continue
+		}
+		target.Set(reflect.ValueOf(value))
+	}
+	return nil
+}
+
+// fakeRows iterates over rows returned by Query
+type fakeRows struct {
+	rows  [][]any
+	index int
+}
+
+func (r *fakeRows) Close()                                       {}
+func (r *fakeRows) Err() error                                   { return nil }
+func (r *fakeRows) CommandTag() pgconn.CommandTag                { return pgconn.NewCommandTag("SELECT") }
+func (r *fakeRows) FieldDescriptions() []pgconn.FieldDescription { return nil }
+func (r *fakeRows) RawValues() [][]byte                          { return nil }
+func (r *fakeRows) Conn() *pgx.Conn                              { return nil }
+
+func (r *fakeRows) Next() bool {
+	r.index++
+	return r.index < len(r.rows)
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	return scanValues(r.rows[r.index], dest)
+}
+
+func (r *fakeRows) Values() ([]any, error) {
+	return r.rows[r.index], nil
+}
+
+// testUUID returns a valid UUID whose bytes are all n
+func testUUID(n byte) pgtype.UUID {
+	uuid := pgtype.UUID{Valid: true}
+	for i := range uuid.Bytes {
+		uuid.Bytes[i] = n
+	}
+	return uuid
+}
+
+// testTimestamp returns a fixed, valid timestamp
+func testTimestamp() pgtype.Timestamp {
+	return pgtype.Timestamp{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), Valid: true}
+}
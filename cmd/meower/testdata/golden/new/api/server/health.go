@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// pinger is a dependency health checks ping, such as *pgxpool.Pool
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// servedServices returns the application services registered on g, leaving
+// out the health and reflection services
+func servedServices(g *grpc.Server) []string {
+	var services []string
+	for name := range g.GetServiceInfo() {
+		if name == grpc_health_v1.Health_ServiceDesc.ServiceName || strings.HasPrefix(name, "grpc.reflection.") {
+			continue
+		}
+		services = append(services, name)
+	}
+	return services
+}
+
+// watchHealth pings db every interval until ctx is cancelled, and reports
+// the result as the status of the server ("") and of every service. All of
+// them need the database, so they are SERVING or NOT_SERVING together.
+// Status changes are logged to logger.
+func watchHealth(ctx context.Context, logger *log.Logger, healthServer *health.Server, db pinger, services []string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	serving := true
+	for {
+		ping, cancel := context.WithTimeout(ctx, pingTimeout)
+		err := db.Ping(ping)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+
+		status := grpc_health_v1.HealthCheckResponse_SERVING
+		if err != nil {
+			status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+		}
+		switch {
+		case err != nil && serving:
+			logger.Error("Database unreachable, reporting NOT_SERVING", "err", err)
+		case err == nil && !serving:
+			logger.Info("Database reachable again, reporting SERVING")
+		}
+		serving = err == nil
+
+		healthServer.SetServingStatus("", status)
+		for _, service := range services {
+			healthServer.SetServingStatus(service, status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package pagination
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestSize(t *testing.T) {
+	tests := []struct {
+		requested int32
+		want      int32
+	}{
+		{requested: 0, want: DefaultSize},
+		{requested: -1, want: DefaultSize},
+		{requested: 5, want: 5},
+		{requested: MaxSize + 1, want: MaxSize},
+	}
+
+	for _, tt := range tests {
+		if got := Size(tt.requested); got != tt.want {
+			t.Errorf("Size(%d) = %d, want %d", tt.requested, got, tt.want)
+		}
+	}
+}
+
+func TestToken(t *testing.T) {
+	cursor := Cursor{
+		CreatedAt: pgtype.Timestamp{Time: time.Date(2025, 1, 2, 3, 4, 5, 6000, time.UTC), Valid: true},
+		ID:        pgtype.UUID{Bytes: [16]byte{1, 2, 3}, Valid: true},
+	}
+	ranked := cursor
+	ranked.Rank = pgtype.Float4{Float32: 0.0607927, Valid: true}
+	ranked.Query = "cats"
+
+	got, err := Decode(cursor.Token())
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, cursor) {
+		t.Errorf("Decode(Token()) = %v, want %v", got, cursor)
+	}
+
+	got, err = DecodeRanked(ranked.Token(), "cats")
+	if err != nil {
+		t.Fatalf("DecodeRanked() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, ranked) {
+		t.Errorf("DecodeRanked(Token()) = %v, want %v", got, ranked)
+	}
+
+	// The two kinds of tokens are not interchangeable
+	if _, err := Decode(ranked.Token()); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Decode(ranked token) error = %v, want %v", err, ErrInvalidToken)
+	}
+	if _, err := DecodeRanked(cursor.Token(), "cats"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("DecodeRanked(unranked token) error = %v, want %v", err, ErrInvalidToken)
+	}
+	if _, err := DecodeRanked(ranked.Token(), "dogs"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("DecodeRanked(token of another query) error = %v, want %v", err, ErrInvalidToken)
+	}
+
+	if first, err := Decode(""); err != nil || first.CreatedAt.Valid || first.ID.Valid {
+		t.Errorf(`Decode("") = %v, %v, want the zero Cursor`, first, err)
+	}
+	for _, token := range []string{"not a token!", "AAAA"} {
+		if _, err := Decode(token); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("Decode(%q) error = %v, want %v", token, err, ErrInvalidToken)
+		}
+	}
+}
+
+func TestPage(t *testing.T) {
+	cursor := func(n int) Cursor {
+		return Cursor{CreatedAt: pgtype.Timestamp{Time: time.UnixMicro(int64(n)).UTC(), Valid: true}, ID: pgtype.UUID{Valid: true}}
+	}
+
+	tests := []struct {
+		name      string
+		rows      []int
+		wantRows  []int
+		wantToken string
+	}{
+		{name: "last page", rows: []int{3, 2}, wantRows: []int{3, 2}},
+		{name: "full last page", rows: []int{3, 2, 1}, wantRows: []int{3, 2, 1}},
+		{name: "more pages", rows: []int{4, 3, 2, 1}, wantRows: []int{4, 3, 2}, wantToken: cursor(2).Token()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, token := Page(tt.rows, 3, cursor)
+			if !reflect.DeepEqual(rows, tt.wantRows) || token != tt.wantToken {
+				t.Errorf("Page() = %v, %q, want %v, %q", rows, token, tt.wantRows, tt.wantToken)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package pubsub
+
+import "testing"
+
+func TestBroker(t *testing.T) {
+	broker := NewBroker[int]()
+	first, unsubscribeFirst := broker.Subscribe()
+	second, unsubscribeSecond := broker.Subscribe()
+	defer unsubscribeSecond()
+
+	broker.Publish(1)
+	for _, events := range []<-chan int{first, second} {
+		if got := <-events; got != 1 {
+			t.Errorf("received %d, want 1", got)
+		}
+	}
+
+	unsubscribeFirst()
+	unsubscribeFirst()
+	if _, ok := <-first; ok {
+		t.Error("channel open after unsubscribing, want closed")
+	}
+
+	broker.Publish(2)
+	if got := <-second; got != 2 {
+		t.Errorf("received %d after another subscriber left, want 2", got)
+	}
+}
+
+func TestBrokerDropsSlowSubscribers(t *testing.T) {
+	broker := NewBroker[int]()
+	events, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+
+	for i := range BufferSize + 1 {
+		broker.Publish(i)
+	}
+
+	received := 0
+	for range events {
+		received++
+	}
+	if received != BufferSize {
+		t.Errorf("received %d events before the channel closed, want %d", received, BufferSize)
+	}
+}
@@ -0,0 +1,29 @@
+// Package docs serves the OpenAPI document of the JSON gateway along with a
+// viewer. openapi.json is generated from the .proto files with
+// `meower docs openapi`.
+package docs
+
+import (
+	_ "embed"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+//go:embed openapi.json
+var spec []byte
+
+//go:embed index.html
+var viewer []byte
+
+// Register serves the viewer at /api/docs and the document at
+// /api/docs/openapi.json
+func Register(router fiber.Router) {
+	router.Get("/api/docs", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.Send(viewer)
+	})
+	router.Get("/api/docs/openapi.json", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.Send(spec)
+	})
+}
@@ -0,0 +1,275 @@
+// Package gateway exposes the unary RPCs of the gRPC API as JSON over HTTP,
+// so clients other than the web UI can use the API without speaking gRPC.
+// The routes in routes.go are generated from the .proto files with
+// `meower proto gateway`.
+package gateway
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Route maps an HTTP method and path to an RPC
+type Route struct {
+	Method string
+	// Path is a Fiber path whose :params set request fields of the same name
+	Path string
+	// Body is "*" when the JSON body is the whole request, the name of the
+	// request field it fills, or "" when the request only comes from path
+	// and query parameters
+	Body string
+	Call Call
+}
+
+// Call invokes an RPC with a request filled in by decode
+type Call func(ctx context.Context, decode func(proto.Message) error) (proto.Message, error)
+
+// Unary adapts a method of a generated gRPC client, such as
+// meowV1.MeowServiceClient.CreateMeow, to a Call
+func Unary[Req any, PReq interface {
+	*Req
+	proto.Message
+}, Resp proto.Message](rpc func(context.Context, PReq, ...grpc.CallOption) (Resp, error)) Call {
+	return func(ctx context.Context, decode func(proto.Message) error) (proto.Message, error) {
+		req := PReq(new(Req))
+		if err := decode(req); err != nil {
+			return nil, err
+		}
+		return rpc(ctx, req)
+	}
+}
+
+// Register mounts the route of every RPC on router
+func Register(router fiber.Router, conn grpc.ClientConnInterface) {
+	for _, route := range routes(conn) {
+		router.Add(route.Method, route.Path, handle(route))
+	}
+}
+
+// handle serves a route: it decodes the request, calls the RPC and writes the
+// response, or the gRPC status as JSON with the matching HTTP status code.
+// The Authorization header, holding the session token returned by Login, is
+// forwarded to the API.
+func handle(route Route) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.UserContext()
+		if authorization := c.Get(fiber.HeaderAuthorization); authorization != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", authorization)
+		}
+
+		resp, err := route.Call(ctx, func(req proto.Message) error {
+			return decode(c, route, req)
+		})
+		if err != nil {
+			st := status.Convert(err)
+			body, _ := protojson.Marshal(st.Proto())
+			c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			return c.Status(HTTPStatus(st.Code())).Send(body)
+		}
+
+		body, err := protojson.Marshal(resp)
+		if err != nil {
+			return err
+		}
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.Send(body)
+	}
+}
+
+// decode fills req from the JSON body, then from path parameters and, unless
+// the body holds the whole request, from query parameters
+func decode(c *fiber.Ctx, route Route, req proto.Message) error {
+	msg := req.ProtoReflect()
+
+	if body := c.Body(); route.Body != "" && len(body) > 0 {
+		target := req
+		if route.Body != "*" {
+			fd := msg.Descriptor().Fields().ByName(protoreflect.Name(route.Body))
+			if fd == nil || fd.Message() == nil {
+				return status.Errorf(codes.Internal, "route body field %q is not a message field", route.Body)
+			}
+			target = msg.Mutable(fd).Message().Interface()
+		}
+		if err := protojson.Unmarshal(body, target); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid JSON body: %v", err)
+		}
+	}
+
+	for _, name := range c.Route().Params {
+		if err := setField(msg, name, []string{c.Params(name)}); err != nil {
+			return err
+		}
+	}
+
+	if route.Body != "*" {
+		query := make(map[string][]string)
+		c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+			query[string(key)] = append(query[string(key)], string(value))
+		})
+		for name, values := range query {
+			if err := setField(msg, name, values); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// setField sets the field called name, by its proto or JSON name, from string
+// values. Repeated fields take every value, others the last one.
+func setField(msg protoreflect.Message, name string, values []string) error {
+	fields := msg.Descriptor().Fields()
+	fd := fields.ByName(protoreflect.Name(name))
+	if fd == nil {
+		fd = fields.ByJSONName(name)
+	}
+	if fd == nil {
+		return status.Errorf(codes.InvalidArgument, "unknown field %q", name)
+	}
+
+	switch {
+	case fd.IsMap():
+		return status.Errorf(codes.InvalidArgument, "map field %q cannot be set from a parameter", name)
+	case fd.IsList():
+		list := msg.Mutable(fd).List()
+		for _, value := range values {
+			v, err := parseValue(fd, value)
+			if err != nil {
+				return err
+			}
+			list.Append(v)
+		}
+		return nil
+	case fd.Message() != nil && isWrapper(fd.Message()):
+		// google.protobuf.StringValue and friends hold their value in "value"
+		return setField(msg.Mutable(fd).Message(), "value", values)
+	}
+
+	v, err := parseValue(fd, values[len(values)-1])
+	if err != nil {
+		return err
+	}
+	msg.Set(fd, v)
+	return nil
+}
+
+// isWrapper reports whether md is a google.protobuf wrapper type such as StringValue
+func isWrapper(md protoreflect.MessageDescriptor) bool {
+	name := string(md.FullName())
+	return strings.HasPrefix(name, "google.protobuf.") && strings.HasSuffix(name, "Value") && md.Fields().ByName("value") != nil
+}
+
+// parseValue parses a parameter into a scalar or enum field value
+func parseValue(fd protoreflect.FieldDescriptor, s string) (protoreflect.Value, error) {
+	invalid := func(err error) (protoreflect.Value, error) {
+		return protoreflect.Value{}, status.Errorf(codes.InvalidArgument, "invalid value %q for field %q: %v", s, fd.Name(), err)
+	}
+
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfBool(b), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfInt32(int32(n)), nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfInt64(n), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfUint32(uint32(n)), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfUint64(n), nil
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(s, 32)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfFloat32(float32(f)), nil
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfFloat64(f), nil
+	case protoreflect.BytesKind:
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			if b, err = base64.URLEncoding.DecodeString(s); err != nil {
+				return invalid(err)
+			}
+		}
+		return protoreflect.ValueOfBytes(b), nil
+	case protoreflect.EnumKind:
+		if value := fd.Enum().Values().ByName(protoreflect.Name(s)); value != nil {
+			return protoreflect.ValueOfEnum(value.Number()), nil
+		}
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), nil
+	}
+
+	return protoreflect.Value{}, status.Errorf(codes.InvalidArgument, "field %q cannot be set from a parameter", fd.Name())
+}
+
+// HTTPStatus returns the HTTP status code matching a gRPC status code
+func HTTPStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return fiber.StatusOK
+	case codes.Canceled:
+		return 499 // Client Closed Request
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return fiber.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return fiber.StatusGatewayTimeout
+	case codes.NotFound:
+		return fiber.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return fiber.StatusConflict
+	case codes.PermissionDenied:
+		return fiber.StatusForbidden
+	case codes.Unauthenticated:
+		return fiber.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return fiber.StatusTooManyRequests
+	case codes.Unimplemented:
+		return fiber.StatusNotImplemented
+	case codes.Unavailable:
+		return fiber.StatusServiceUnavailable
+	}
+	// Unknown, Internal, DataLoss and anything else
+	return fiber.StatusInternalServerError
+}
@@ -0,0 +1,186 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/typepb"
+)
+
+// echo stands in for a generated client method: it returns its request,
+// NotFound for a field named "missing", or Unauthenticated for a field named
+// "private" requested without a session token
+func echo(ctx context.Context, req *typepb.Field, opts ...grpc.CallOption) (*typepb.Field, error) {
+	switch req.GetName() {
+	case "missing":
+		return nil, status.Error(codes.NotFound, "field not found")
+	case "private":
+		if md, _ := metadata.FromOutgoingContext(ctx); !slices.Equal(md.Get("authorization"), []string{"Bearer token"}) {
+			return nil, status.Error(codes.Unauthenticated, "missing session token")
+		}
+	}
+	return req, nil
+}
+
+func TestGateway(t *testing.T) {
+	app := fiber.New()
+	for _, route := range []Route{
+		{Method: "GET", Path: "/api/v1/fields/:name", Call: Unary(echo)},
+		{Method: "POST", Path: "/api/v1/fields", Body: "*", Call: Unary(echo)},
+		{Method: "PATCH", Path: "/api/v1/fields/:name", Body: "*", Call: Unary(echo)},
+	} {
+		app.Add(route.Method, route.Path, handle(route))
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		// authorization is the Authorization header of the request
+		authorization string
+		wantStatus    int
+		want          *typepb.Field
+		wantErr       string
+	}{
+		{
+			name:       "path and query parameters",
+			method:     "GET",
+			target:     "/api/v1/fields/id?number=3&packed=true&kind=TYPE_STRING&jsonName=ID",
+			wantStatus: fiber.StatusOK,
+			want:       &typepb.Field{Name: "id", Number: 3, Packed: true, Kind: typepb.Field_TYPE_STRING, JsonName: "ID"},
+		},
+		{
+			name:       "JSON body",
+			method:     "POST",
+			target:     "/api/v1/fields",
+			body:       `{"name": "content", "number": 2, "kind": "TYPE_STRING"}`,
+			wantStatus: fiber.StatusOK,
+			want:       &typepb.Field{Name: "content", Number: 2, Kind: typepb.Field_TYPE_STRING},
+		},
+		{
+			name:       "path parameter overrides body",
+			method:     "PATCH",
+			target:     "/api/v1/fields/id",
+			body:       `{"name": "other", "number": 1}`,
+			wantStatus: fiber.StatusOK,
+			want:       &typepb.Field{Name: "id", Number: 1},
+		},
+		{
+			name:       "gRPC status",
+			method:     "GET",
+			target:     "/api/v1/fields/missing",
+			wantStatus: fiber.StatusNotFound,
+			wantErr:    "field not found",
+		},
+		{
+			name:          "authorization forwarded",
+			method:        "GET",
+			target:        "/api/v1/fields/private",
+			authorization: "Bearer token",
+			wantStatus:    fiber.StatusOK,
+			want:          &typepb.Field{Name: "private"},
+		},
+		{
+			name:       "authorization missing",
+			method:     "GET",
+			target:     "/api/v1/fields/private",
+			wantStatus: fiber.StatusUnauthorized,
+			wantErr:    "missing session token",
+		},
+		{
+			name:       "invalid number",
+			method:     "GET",
+			target:     "/api/v1/fields/id?number=three",
+			wantStatus: fiber.StatusBadRequest,
+			wantErr:    `invalid value \"three\" for field \"number\"`,
+		},
+		{
+			name:       "unknown field",
+			method:     "GET",
+			target:     "/api/v1/fields/id?color=blue",
+			wantStatus: fiber.StatusBadRequest,
+			wantErr:    `unknown field \"color\"`,
+		},
+		{
+			name:       "invalid JSON body",
+			method:     "POST",
+			target:     "/api/v1/fields",
+			body:       `{"name":`,
+			wantStatus: fiber.StatusBadRequest,
+			wantErr:    "invalid JSON body",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			if tt.authorization != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.authorization)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("failed to read response: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if tt.wantErr != "" {
+				if !strings.Contains(string(body), tt.wantErr) {
+					t.Errorf("body = %s, want it to contain %s", body, tt.wantErr)
+				}
+				return
+			}
+
+			got := &typepb.Field{}
+			if err := protojson.Unmarshal(body, got); err != nil {
+				t.Fatalf("invalid response %s: %v", body, err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("response = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		code codes.Code
+		want int
+	}{
+		{codes.OK, fiber.StatusOK},
+		{codes.InvalidArgument, fiber.StatusBadRequest},
+		{codes.Unauthenticated, fiber.StatusUnauthorized},
+		{codes.PermissionDenied, fiber.StatusForbidden},
+		{codes.NotFound, fiber.StatusNotFound},
+		{codes.AlreadyExists, fiber.StatusConflict},
+		{codes.ResourceExhausted, fiber.StatusTooManyRequests},
+		{codes.Unimplemented, fiber.StatusNotImplemented},
+		{codes.Unavailable, fiber.StatusServiceUnavailable},
+		{codes.DeadlineExceeded, fiber.StatusGatewayTimeout},
+		{codes.Internal, fiber.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		if got := HTTPStatus(tt.code); got != tt.want {
+			t.Errorf("HTTPStatus(%v) = %d, want %d", tt.code, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Code generated by meower proto gateway. DO NOT EDIT.
+
+package gateway
+
+import (
+	followV1 "github.com/test/test-project/api/proto/follow/v1"
+	meowV1 "github.com/test/test-project/api/proto/meow/v1"
+	userV1 "github.com/test/test-project/api/proto/user/v1"
+	"google.golang.org/grpc"
+)
+
+// routes returns the JSON route of every unary RPC
+func routes(conn grpc.ClientConnInterface) []Route {
+	followServiceV1 := followV1.NewFollowServiceClient(conn)
+	meowServiceV1 := meowV1.NewMeowServiceClient(conn)
+	userServiceV1 := userV1.NewUserServiceClient(conn)
+
+	return []Route{
+		{Method: "POST", Path: "/api/v1/follows/follow", Body: "*", Call: Unary(followServiceV1.Follow)},
+		{Method: "POST", Path: "/api/v1/follows/unfollow", Body: "*", Call: Unary(followServiceV1.Unfollow)},
+		{Method: "POST", Path: "/api/v1/follows/list-followers", Body: "*", Call: Unary(followServiceV1.ListFollowers)},
+		{Method: "POST", Path: "/api/v1/follows/list-following", Body: "*", Call: Unary(followServiceV1.ListFollowing)},
+		{Method: "POST", Path: "/api/v1/follows/get-follow-stats", Body: "*", Call: Unary(followServiceV1.GetFollowStats)},
+		{Method: "POST", Path: "/api/v1/meows", Body: "*", Call: Unary(meowServiceV1.CreateMeow)},
+		{Method: "GET", Path: "/api/v1/meows", Call: Unary(meowServiceV1.IndexMeow)},
+		{Method: "GET", Path: "/api/v1/meows/by-user", Call: Unary(meowServiceV1.ListMeowsByUser)},
+		{Method: "GET", Path: "/api/v1/meows/by-parent", Call: Unary(meowServiceV1.ListMeowsByParent)},
+		{Method: "GET", Path: "/api/v1/meows/by-tag", Call: Unary(meowServiceV1.ListMeowsByTag)},
+		{Method: "POST", Path: "/api/v1/meows/search-meows", Body: "*", Call: Unary(meowServiceV1.SearchMeows)},
+		{Method: "POST", Path: "/api/v1/meows/home-timeline", Body: "*", Call: Unary(meowServiceV1.HomeTimeline)},
+		{Method: "POST", Path: "/api/v1/meows/like-meow", Body: "*", Call: Unary(meowServiceV1.LikeMeow)},
+		{Method: "POST", Path: "/api/v1/meows/unlike-meow", Body: "*", Call: Unary(meowServiceV1.UnlikeMeow)},
+		{Method: "POST", Path: "/api/v1/meows/repost-meow", Body: "*", Call: Unary(meowServiceV1.RepostMeow)},
+		{Method: "POST", Path: "/api/v1/meows/unrepost-meow", Body: "*", Call: Unary(meowServiceV1.UnrepostMeow)},
+		{Method: "POST", Path: "/api/v1/users", Body: "*", Call: Unary(userServiceV1.CreateUser)},
+		{Method: "GET", Path: "/api/v1/users/by-email", Call: Unary(userServiceV1.GetUserByEmail)},
+		{Method: "GET", Path: "/api/v1/users/by-username", Call: Unary(userServiceV1.GetUserByUsername)},
+		{Method: "GET", Path: "/api/v1/users", Call: Unary(userServiceV1.ListUsers)},
+		{Method: "POST", Path: "/api/v1/users/login", Body: "*", Call: Unary(userServiceV1.Login)},
+		{Method: "POST", Path: "/api/v1/users/logout", Body: "*", Call: Unary(userServiceV1.Logout)},
+		{Method: "POST", Path: "/api/v1/users/request-password-reset", Body: "*", Call: Unary(userServiceV1.RequestPasswordReset)},
+		{Method: "POST", Path: "/api/v1/users/reset-password", Body: "*", Call: Unary(userServiceV1.ResetPassword)},
+		{Method: "POST", Path: "/api/v1/users/request-email-verification", Body: "*", Call: Unary(userServiceV1.RequestEmailVerification)},
+		{Method: "POST", Path: "/api/v1/users/verify-email", Body: "*", Call: Unary(userServiceV1.VerifyEmail)},
+		{Method: "GET", Path: "/api/v1/meows/:id", Call: Unary(meowServiceV1.GetMeow)},
+		{Method: "PATCH", Path: "/api/v1/meows/:id", Body: "*", Call: Unary(meowServiceV1.UpdateMeow)},
+		{Method: "DELETE", Path: "/api/v1/meows/:id", Call: Unary(meowServiceV1.DeleteMeow)},
+		{Method: "GET", Path: "/api/v1/users/:id", Call: Unary(userServiceV1.GetUser)},
+		{Method: "PATCH", Path: "/api/v1/users/:id", Body: "*", Call: Unary(userServiceV1.UpdateUser)},
+		{Method: "DELETE", Path: "/api/v1/users/:id", Call: Unary(userServiceV1.DeleteUser)},
+	}
+}
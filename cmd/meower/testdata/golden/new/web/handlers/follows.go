@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	followV1 "github.com/test/test-project/api/proto/follow/v1"
+
+	"github.com/test/test-project/web/routes"
+	"github.com/test/test-project/web/views"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Follows struct{ *App }
+
+func (h *Follows) Follow(c *fiber.Ctx) error {
+	req := &followV1.FollowRequest{Username: c.Params("username")}
+
+	if _, err := h.API.FollowService.Follow(c.UserContext(), req); err != nil {
+		return err
+	}
+
+	return c.Redirect(routes.UserShow.URL(c, fiber.Map{"username": req.Username}))
+}
+
+func (h *Follows) Unfollow(c *fiber.Ctx) error {
+	req := &followV1.UnfollowRequest{Username: c.Params("username")}
+
+	if _, err := h.API.FollowService.Unfollow(c.UserContext(), req); err != nil {
+		return err
+	}
+
+	return c.Redirect(routes.UserShow.URL(c, fiber.Map{"username": req.Username}))
+}
+
+// Followers lists the users following a user
+func (h *Follows) Followers(c *fiber.Ctx) error {
+	req := &followV1.ListFollowersRequest{Username: c.Params("username"), PageToken: c.Query("page_token")}
+
+	resp, err := h.API.FollowService.ListFollowers(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return renderTempl(c, views.UserFollows(c, req.Username, "Followers", resp.Users, resp.NextPageToken))
+}
+
+// Following lists the users a user follows
+func (h *Follows) Following(c *fiber.Ctx) error {
+	req := &followV1.ListFollowingRequest{Username: c.Params("username"), PageToken: c.Query("page_token")}
+
+	resp, err := h.API.FollowService.ListFollowing(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return renderTempl(c, views.UserFollows(c, req.Username, "Following", resp.Users, resp.NextPageToken))
+}
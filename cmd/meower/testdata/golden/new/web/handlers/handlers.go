@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/test/test-project/web/gateway"
+	"github.com/test/test-project/web/grpc"
+	"github.com/test/test-project/web/views"
+
+	"github.com/a-h/templ"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/gofiber/fiber/v2/middleware/session"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/gofiber/storage/redis/v3"
+	"google.golang.org/grpc/status"
+
+	"github.com/charmbracelet/log"
+)
+
+type App struct {
+	Web          *fiber.App
+	API          *grpc.Client
+	SessionStore *session.Store
+	Redis        *redis.Storage
+}
+
+func ErrorHandler(ctx *fiber.Ctx, err error) error {
+	log.Error(err)
+	// Status code defaults to 500
+	code := fiber.StatusInternalServerError
+
+	// Retrieve the custom status code if it's a *fiber.Error, or the one
+	// matching the status of a failed API call
+	var e *fiber.Error
+	if errors.As(err, &e) {
+		code = e.Code
+	} else if st, ok := status.FromError(err); ok {
+		code = gateway.HTTPStatus(st.Code())
+	}
+
+	switch code {
+	case 500:
+		err = renderTempl(ctx, views.Error500(ctx), templ.WithStatus(code))
+	case 404:
+		err = renderTempl(ctx, views.Error404(ctx), templ.WithStatus(code))
+	default:
+		return ctx.Status(code).SendString(utils.StatusMessage(code))
+	}
+
+	if err != nil {
+		// In case we cannot render the template
+		return ctx.Status(fiber.StatusInternalServerError).SendString("Internal Server Error")
+	}
+
+	return nil
+}
+
+// helper function to render a component using a-h/templ
+func renderTempl(c *fiber.Ctx, component templ.Component, options ...func(*templ.ComponentHandler)) error {
+	componentHandler := templ.Handler(component)
+	for _, o := range options {
+		o(componentHandler)
+	}
+	return adaptor.HTTPHandler(componentHandler)(c)
+}
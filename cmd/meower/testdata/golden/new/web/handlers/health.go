@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// checkTimeout bounds each dependency check of the readiness probe
+const checkTimeout = 2 * time.Second
+
+// Check returns an error when a dependency is unavailable
+type Check func(ctx context.Context) error
+
+// Health answers the liveness and readiness probes of the web server
+type Health struct {
+	// Checks are the dependencies the server needs to handle requests, by name
+	Checks map[string]Check
+}
+
+// NewHealth returns probes checking the gRPC API and Redis of app
+func NewHealth(app *App) *Health {
+	api := grpc_health_v1.NewHealthClient(app.API.Conn())
+
+	return &Health{Checks: map[string]Check{
+		"api": func(ctx context.Context) error {
+			resp, err := api.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+			if err != nil {
+				return err
+			}
+			if resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+				return fmt.Errorf("status %s", resp.GetStatus())
+			}
+			return nil
+		},
+		"redis": func(ctx context.Context) error {
+			return app.Redis.Conn().Ping(ctx).Err()
+		},
+	}}
+}
+
+// Live reports that the server is up, whatever the state of its dependencies
+func (h *Health) Live(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"status": "ok"})
+}
+
+// Ready runs every check concurrently and answers 503 Service Unavailable
+// when one of them fails
+func (h *Health) Ready(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), checkTimeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	checks, ready := fiber.Map{}, true
+	for name, check := range h.Checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := check(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				checks[name], ready = err.Error(), false
+				return
+			}
+			checks[name] = "ok"
+		}()
+	}
+	wg.Wait()
+
+	if !ready {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"status": "unavailable", "checks": checks})
+	}
+	return c.JSON(fiber.Map{"status": "ok", "checks": checks})
+}
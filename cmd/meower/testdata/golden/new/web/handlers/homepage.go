@@ -0,0 +1,13 @@
+package handlers
+
+import (
+	"github.com/test/test-project/web/views"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Homepage struct{ *App }
+
+func (h *Homepage) Homepage(c *fiber.Ctx) error {
+	return renderTempl(c, views.Homepage(c))
+}
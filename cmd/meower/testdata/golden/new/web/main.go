@@ -0,0 +1,117 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/test/test-project/api/config"
+	"github.com/test/test-project/web/docs"
+	"github.com/test/test-project/web/gateway"
+	"github.com/test/test-project/web/grpc"
+	"github.com/test/test-project/web/handlers"
+	"github.com/test/test-project/web/routes"
+	"github.com/test/test-project/web/routing"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/compress"
+	"github.com/gofiber/fiber/v2/middleware/csrf"
+	"github.com/gofiber/fiber/v2/middleware/encryptcookie"
+	"github.com/gofiber/fiber/v2/middleware/logger"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/gofiber/fiber/v2/middleware/session"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/gofiber/storage/redis/v3"
+)
+
+func main() {
+	var cfg config.Web
+	if err := config.Load(&cfg); err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
+	}
+
+	// Connect to the internal gRPC API
+	GrpcClient := grpc.NewClient(cfg.APIEndpoint)
+
+	// Create Redis storage
+	redisStore := redis.New(redis.Config{
+		URL: cfg.RedisURL,
+	})
+
+	// Create session store with Redis storage
+	sessionStore := session.New(session.Config{
+		Storage:        redisStore,
+		KeyLookup:      "cookie:session_id",
+		CookieDomain:   "",
+		CookiePath:     "/",
+		CookieSecure:   cfg.IsProduction(),
+		CookieHTTPOnly: true,
+		CookieSameSite: "Lax",
+		Expiration:     365 * 24 * time.Hour, // 1 year
+	})
+
+	// Create the Fiber app
+	fiberApp := fiber.New(fiber.Config{
+		ErrorHandler:      handlers.ErrorHandler,
+		EnablePrintRoutes: true,
+	})
+
+	// Add middlewares
+	if cfg.IsProduction() {
+		// Enable gzip compression in production only, templ proxy does not
+		// support brotli. Event streams are left alone, to flush every event.
+		fiberApp.Use(compress.New(compress.Config{
+			Next: func(c *fiber.Ctx) bool {
+				return c.Path() == routes.MeowEvents.Path
+			},
+		}))
+		fiberApp.Use(csrf.New(csrf.Config{
+			// The JSON gateway is called by API clients, not from forms
+			Next: func(c *fiber.Ctx) bool {
+				return strings.HasPrefix(c.Path(), "/api/")
+			},
+		}))
+	} else {
+		// Enable request logging in development, except for health probes
+		// running every few seconds
+		fiberApp.Use(logger.New(logger.Config{
+			Next: func(c *fiber.Ctx) bool {
+				return c.Path() == routes.Healthz.Path || c.Path() == routes.Readyz.Path
+			},
+		}))
+	}
+	fiberApp.Use(requestid.New(requestid.Config{Generator: utils.UUIDv4}))
+	// Forward the request ID to the API through c.UserContext()
+	fiberApp.Use(func(c *fiber.Ctx) error {
+		c.SetUserContext(grpc.WithRequestID(c.UserContext(), c.GetRespHeader(fiber.HeaderXRequestID)))
+		return c.Next()
+	})
+	fiberApp.Use(encryptcookie.New(encryptcookie.Config{
+		Key: cfg.CookieSecretKey,
+	}))
+	// Authenticate calls to the API with the session token of the user
+	fiberApp.Use(handlers.APITokenMiddleware(sessionStore))
+
+	app := &handlers.App{
+		Web:          fiberApp,
+		API:          GrpcClient,
+		SessionStore: sessionStore,
+		Redis:        redisStore,
+	}
+
+	// Mount the JSON gateway to the gRPC API under /api
+	if cfg.GatewayEnabled {
+		gateway.Register(app.Web, app.API.Conn())
+	}
+
+	// Serve the OpenAPI document of the gateway and its viewer at /api/docs
+	if cfg.DocsEnabled {
+		docs.Register(app.Web)
+	}
+
+	// Mount public routes
+	routing.RegisterRoutes(app)
+	if err := app.Web.Listen(cfg.Addr); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+func TestURL(t *testing.T) {
+	app := fiber.New()
+	for _, r := range []route{MeowShow, MeowEdit, MeowTag, UserShow, UserFollowers} {
+		app.Get(r.Path, func(c *fiber.Ctx) error { return nil }).Name(r.Name)
+	}
+	c := app.AcquireCtx(&fasthttp.RequestCtx{})
+	defer app.ReleaseCtx(c)
+
+	tests := []struct {
+		name   string
+		route  route
+		params fiber.Map
+		want   string
+	}{
+		{name: "meow", route: MeowShow, params: fiber.Map{"id": "0a1b"}, want: "/meows/0a1b"},
+		{name: "nested", route: MeowEdit, params: fiber.Map{"id": "0a1b"}, want: "/meows/0a1b/edit"},
+		{name: "tag", route: MeowTag, params: fiber.Map{"tag": "cats"}, want: "/tags/cats"},
+		{name: "user", route: UserShow, params: fiber.Map{"username": "meower"}, want: "/@meower"},
+		{name: "user followers", route: UserFollowers, params: fiber.Map{"username": "meower"}, want: "/@meower/followers"},
+		{name: "unknown route", route: route{Name: "unknown"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.route.URL(c, tt.params); got != tt.want {
+				t.Errorf("URL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,4 @@
+// htmx, for the partial updates of views using hx-* attributes
+import htmx from "htmx.org";
+
+window.htmx = htmx;
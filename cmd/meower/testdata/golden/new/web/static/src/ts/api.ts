@@ -0,0 +1,25 @@
+// Clients calling the API straight from the browser over gRPC-Web, without a
+// round trip through the web server. The API serves gRPC-Web when started
+// with GRPC_WEB_ENABLED=true and must list this site in CORS_ALLOWED_ORIGINS.
+//
+// Import them from a script in static/src/js:
+//
+//   import { meowService } from "../ts/api";
+//   const { meows } = await meowService.indexMeow({});
+import { createClient } from "@connectrpc/connect";
+import { createGrpcWebTransport } from "@connectrpc/connect-web";
+
+import { MeowService } from "./gen/meow/v1/meow_pb";
+import { UserService } from "./gen/user/v1/user_pb";
+
+// apiURL is the gRPC-Web server: the content of <meta name="api-url">, or port
+// 8080 of the current host
+function apiURL(): string {
+  const meta = document.querySelector<HTMLMetaElement>('meta[name="api-url"]');
+  return meta?.content || `${window.location.protocol}//${window.location.hostname}:8080`;
+}
+
+const transport = createGrpcWebTransport({ baseUrl: apiURL() });
+
+export const meowService = createClient(MeowService, transport);
+export const userService = createClient(UserService, transport);
@@ -0,0 +1,407 @@
+// Package appconfig checks the configuration of a generated Meower project
+// without starting its servers.
+//
+// The project's api/config package declares each setting as a struct field
+// tagged with its environment variable, default value and validation rules.
+// ParseSchema reads those tags from the source, and Check resolves every
+// setting the way config.Load does at startup: from the environment, then a
+// .env file, then a YAML file, then the default. Docker Compose service
+// environments can be layered on top of the process environment, since
+// that is how the development servers receive most of their settings.
+package appconfig
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"net"
+	"net/url"
+	"path"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaPath is the file declaring the settings in a generated project
+const SchemaPath = "api/config/config.go"
+
+// Field is a setting of a configuration section
+type Field struct {
+	Name    string
+	Env     string
+	Type    string
+	Default string
+	Rules   []string
+}
+
+// Section is a configuration struct of the config package, such as API or
+// Web, read by the server of the same name
+type Section struct {
+	Name   string
+	Fields []Field
+}
+
+// Dir returns the directory of the server reading the section, such as web
+func (s Section) Dir() string {
+	return strings.ToLower(s.Name)
+}
+
+// ParseSchema reads the sections declared in the config package source,
+// every struct with env tagged fields
+func ParseSchema(src []byte) ([]Section, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, SchemaPath, src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	var sections []Section
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+
+			section := Section{Name: typeSpec.Name.Name}
+			for _, field := range structType.Fields.List {
+				if field.Tag == nil || len(field.Names) != 1 {
+					continue
+				}
+				tagValue, err := strconv.Unquote(field.Tag.Value)
+				if err != nil {
+					return nil, fmt.Errorf("%s: invalid tag: %w", fset.Position(field.Tag.Pos()), err)
+				}
+				tag := reflect.StructTag(tagValue)
+				env := tag.Get("env")
+				if env == "" {
+					continue
+				}
+
+				f := Field{
+					Name:    field.Names[0].Name,
+					Env:     env,
+					Type:    typeString(field.Type),
+					Default: tag.Get("default"),
+				}
+				if rules := tag.Get("validate"); rules != "" {
+					f.Rules = strings.Split(rules, ",")
+				}
+				section.Fields = append(section.Fields, f)
+			}
+			if len(section.Fields) > 0 {
+				sections = append(sections, section)
+			}
+		}
+	}
+	if len(sections) == 0 {
+		return nil, fmt.Errorf("%s declares no settings: no struct field has an env tag", SchemaPath)
+	}
+	return sections, nil
+}
+
+// typeString returns the source of a field type, such as []string or
+// time.Duration
+func typeString(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name
+	case *ast.SelectorExpr:
+		return typeString(expr.X) + "." + expr.Sel.Name
+	case *ast.ArrayType:
+		return "[]" + typeString(expr.Elt)
+	case *ast.StarExpr:
+		return "*" + typeString(expr.X)
+	default:
+		return fmt.Sprintf("%T", expr)
+	}
+}
+
+// Options selects the sources Check reads besides the project files
+type Options struct {
+	// LookupEnv reads the process environment, such as os.LookupEnv
+	LookupEnv func(name string) (string, bool)
+	// Compose also reads the environment of the Docker Compose service
+	// named after the section, in docker-compose.yml
+	Compose bool
+}
+
+// Result is the resolved value of a setting
+type Result struct {
+	Field Field
+	// Source is where the value comes from: environment, docker-compose.yml,
+	// a file path, default, or "" when the setting is unset
+	Source string
+	Err    error
+}
+
+// Check resolves and validates every setting of section from the project
+// in fsys. It fails when a source cannot be read.
+func Check(fsys fs.FS, section Section, opts Options) ([]Result, error) {
+	sources, err := loadSources(fsys, section, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(section.Fields))
+	for _, field := range section.Fields {
+		result := Result{Field: field}
+
+		value, ok := "", false
+		for _, src := range sources {
+			if value, ok = src.lookup(field.Env); ok {
+				result.Source = src.name
+				break
+			}
+		}
+		if !ok && field.Default != "" {
+			value, result.Source = field.Default, "default"
+		}
+
+		result.Err = validate(value, field.Rules)
+		if result.Err == nil {
+			result.Err = parse(field.Type, value)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// source is a set of values read from one place
+type source struct {
+	name   string
+	lookup func(name string) (string, bool)
+}
+
+// mapSource returns a source reading values
+func mapSource(name string, values map[string]string, key func(string) string) source {
+	return source{name: name, lookup: func(name string) (string, bool) {
+		value, ok := values[key(name)]
+		return value, ok
+	}}
+}
+
+func identity(name string) string { return name }
+
+// loadSources returns the sources of a section, by order of precedence
+func loadSources(fsys fs.FS, section Section, opts Options) ([]source, error) {
+	lookupEnv := opts.LookupEnv
+	if lookupEnv == nil {
+		lookupEnv = func(string) (string, bool) { return "", false }
+	}
+	sources := []source{{name: "environment", lookup: lookupEnv}}
+
+	var compose map[string]string
+	if opts.Compose {
+		values, err := composeEnvironment(fsys, section.Dir())
+		if err != nil {
+			return nil, err
+		}
+		compose = values
+		sources = append(sources, mapSource("docker-compose.yml", values, identity))
+	}
+	// Compose variables are part of the server environment, so they may
+	// name the YAML file as well
+	lookup := func(name string) (string, bool) {
+		if value, ok := lookupEnv(name); ok {
+			return value, true
+		}
+		value, ok := compose[name]
+		return value, ok
+	}
+
+	// The server looks for its files in its directory, then in the project root
+	dirs := []string{section.Dir(), "."}
+	if name := findFile(fsys, dirs, ".env"); name != "" {
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		values, err := godotenv.UnmarshalBytes(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		sources = append(sources, mapSource(name, values, identity))
+	}
+
+	name, required := "", false
+	if configFile, ok := lookup("CONFIG_FILE"); ok && configFile != "" {
+		name, required = path.Join(section.Dir(), configFile), true
+	} else {
+		name = findFile(fsys, dirs, "config.yaml")
+	}
+	if name != "" {
+		values, err := readYAML(fsys, name)
+		if err != nil && (required || !errors.Is(err, fs.ErrNotExist)) {
+			return nil, err
+		}
+		sources = append(sources, mapSource(name, values, strings.ToLower))
+	}
+	return sources, nil
+}
+
+// findFile returns the path of the first of dirs containing name, or ""
+func findFile(fsys fs.FS, dirs []string, name string) string {
+	for _, dir := range dirs {
+		p := path.Join(dir, name)
+		if _, err := fs.Stat(fsys, p); err == nil {
+			return p
+		}
+	}
+	return ""
+}
+
+// readYAML reads a mapping of scalars or lists of scalars, lists being joined
+// with commas like their environment variables
+func readYAML(fsys fs.FS, name string) (map[string]string, error) {
+	content, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	var raw map[string]any
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	values := make(map[string]string, len(raw))
+	for key, value := range raw {
+		switch value := value.(type) {
+		case nil:
+			values[key] = ""
+		case map[string]any:
+			return nil, fmt.Errorf("%s: %s must be a value or a list, not a mapping", name, key)
+		case []any:
+			items := make([]string, len(value))
+			for i, item := range value {
+				items[i] = fmt.Sprint(item)
+			}
+			values[key] = strings.Join(items, ",")
+		default:
+			values[key] = fmt.Sprint(value)
+		}
+	}
+	return values, nil
+}
+
+// composeEnvironment returns the environment of a docker-compose.yml service,
+// written either as a mapping or as a list of NAME=value
+func composeEnvironment(fsys fs.FS, service string) (map[string]string, error) {
+	content, err := fs.ReadFile(fsys, "docker-compose.yml")
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var compose struct {
+		Services map[string]struct {
+			Environment yaml.Node `yaml:"environment"`
+		} `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(content, &compose); err != nil {
+		return nil, fmt.Errorf("failed to parse docker-compose.yml: %w", err)
+	}
+
+	values := map[string]string{}
+	env := compose.Services[service].Environment
+	switch env.Kind {
+	case yaml.MappingNode:
+		var m map[string]string
+		if err := env.Decode(&m); err != nil {
+			return nil, fmt.Errorf("docker-compose.yml: %s environment: %w", service, err)
+		}
+		values = m
+	case yaml.SequenceNode:
+		var list []string
+		if err := env.Decode(&list); err != nil {
+			return nil, fmt.Errorf("docker-compose.yml: %s environment: %w", service, err)
+		}
+		for _, item := range list {
+			// A name without a value is passed through from the shell
+			if name, value, ok := strings.Cut(item, "="); ok {
+				values[name] = value
+			}
+		}
+	}
+	return values, nil
+}
+
+// validate checks value against the rules of the template's config package.
+// Empty values only fail the required rule.
+func validate(value string, rules []string) error {
+	for _, rule := range rules {
+		rule, arg, _ := strings.Cut(rule, "=")
+		if rule == "required" && value == "" {
+			return errors.New("is required")
+		}
+		if value == "" {
+			continue
+		}
+
+		switch rule {
+		case "url":
+			if u, err := url.Parse(value); err != nil || u.Scheme == "" || u.Host == "" {
+				return fmt.Errorf("must be an absolute URL, got %q", value)
+			}
+		case "hostport":
+			if _, port, err := net.SplitHostPort(value); err != nil || port == "" {
+				return fmt.Errorf("must be a host:port address, got %q", value)
+			}
+		case "oneof":
+			if values := strings.Split(arg, "|"); !slices.Contains(values, value) {
+				return fmt.Errorf("must be one of %s, got %q", strings.Join(values, ", "), value)
+			}
+		case "key":
+			key, err := base64.StdEncoding.DecodeString(value)
+			if err != nil || (len(key) != 16 && len(key) != 24 && len(key) != 32) {
+				return errors.New("must be a base64 encoded 16, 24 or 32 bytes key, generate one with `openssl rand -base64 32`")
+			}
+		case "required":
+		default:
+			return fmt.Errorf("has unknown validation rule %q", rule)
+		}
+	}
+	return nil
+}
+
+// parse checks that value converts to a field of the given type
+func parse(typ, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	switch typ {
+	case "string", "[]string":
+	case "bool":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("must be true or false, got %q", value)
+		}
+	case "int":
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("must be an integer, got %q", value)
+		}
+	case "time.Duration":
+		if _, err := time.ParseDuration(value); err != nil {
+			return fmt.Errorf("must be a duration such as 30s, got %q", value)
+		}
+	default:
+		return fmt.Errorf("has unsupported type %s", typ)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package appconfig
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// TestParseSchemaTemplate reads the config package shipped with the project
+// template
+func TestParseSchemaTemplate(t *testing.T) {
+	src, err := os.ReadFile(filepath.Join("..", "..", "cmd", "meower", "template", filepath.FromSlash(SchemaPath)))
+	if err != nil {
+		t.Fatalf("failed to read template file: %v", err)
+	}
+
+	sections, err := ParseSchema(src)
+	if err != nil {
+		t.Fatalf("ParseSchema() error = %v", err)
+	}
+
+	var names []string
+	for _, section := range sections {
+		names = append(names, section.Name)
+	}
+	if want := []string{"API", "Web"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("sections = %v, want %v", names, want)
+	}
+
+	want := Field{Name: "DatabaseURL", Env: "DATABASE_URL", Type: "string", Rules: []string{"required", "url"}}
+	if got := sections[0].Fields[2]; !reflect.DeepEqual(got, want) {
+		t.Errorf("API field = %+v, want %+v", got, want)
+	}
+	for _, field := range sections[0].Fields {
+		if field.Env == "CORS_ALLOWED_ORIGINS" && field.Type != "[]string" {
+			t.Errorf("CORS_ALLOWED_ORIGINS type = %s, want []string", field.Type)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	section := Section{Name: "Web", Fields: []Field{
+		{Env: "ENV", Type: "string", Default: "development", Rules: []string{"oneof=development|production"}},
+		{Env: "WEB_ADDR", Type: "string", Default: "0.0.0.0:3000", Rules: []string{"required", "hostport"}},
+		{Env: "API_ENDPOINT", Type: "string", Rules: []string{"required", "hostport"}},
+		{Env: "REDIS_URL", Type: "string", Rules: []string{"required", "url"}},
+		{Env: "COOKIE_SECRET_KEY", Type: "string", Rules: []string{"required", "key"}},
+		{Env: "DOCS_ENABLED", Type: "bool"},
+		{Env: "GATEWAY_ENABLED", Type: "bool"},
+		{Env: "TIMEOUT", Type: "time.Duration"},
+	}}
+
+	fsys := fstest.MapFS{
+		"docker-compose.yml": &fstest.MapFile{Data: []byte(`services:
+  web:
+    environment:
+      API_ENDPOINT: "api:50051"
+      GATEWAY_ENABLED: "yes"
+  api:
+    environment:
+      - DATABASE_URL=postgres://db:5432/meower
+`)},
+		"web/.env":    &fstest.MapFile{Data: []byte("REDIS_URL=redis://localhost:6379\nCOOKIE_SECRET_KEY=secret\n")},
+		".env":        &fstest.MapFile{Data: []byte("TIMEOUT=30s\n")},
+		"config.yaml": &fstest.MapFile{Data: []byte("timeout: forever\ndocs_enabled: true\n")},
+	}
+	env := map[string]string{"ENV": "staging"}
+
+	tests := []struct {
+		name    string
+		compose bool
+		want    map[string]string
+	}{
+		{
+			name:    "with docker compose",
+			compose: true,
+			want: map[string]string{
+				"ENV":               `environment: must be one of development, production, got "staging"`,
+				"WEB_ADDR":          "default",
+				"API_ENDPOINT":      "docker-compose.yml",
+				"REDIS_URL":         "web/.env",
+				"COOKIE_SECRET_KEY": "web/.env: must be a base64 encoded 16, 24 or 32 bytes key",
+				"DOCS_ENABLED":      "config.yaml",
+				"GATEWAY_ENABLED":   `docker-compose.yml: must be true or false, got "yes"`,
+				"TIMEOUT":           `config.yaml: must be a duration such as 30s, got "forever"`,
+			},
+		},
+		{
+			name: "without docker compose",
+			want: map[string]string{
+				"ENV":               `environment: must be one of development, production, got "staging"`,
+				"WEB_ADDR":          "default",
+				"API_ENDPOINT":      ": is required",
+				"REDIS_URL":         "web/.env",
+				"COOKIE_SECRET_KEY": "web/.env: must be a base64 encoded 16, 24 or 32 bytes key",
+				"DOCS_ENABLED":      "config.yaml",
+				"GATEWAY_ENABLED":   "",
+				"TIMEOUT":           `config.yaml: must be a duration such as 30s, got "forever"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := Check(fsys, section, Options{
+				LookupEnv: func(name string) (string, bool) {
+					value, ok := env[name]
+					return value, ok
+				},
+				Compose: tt.compose,
+			})
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+
+			for _, result := range results {
+				got := result.Source
+				if result.Err != nil {
+					got += ": " + result.Err.Error()
+				}
+				if want := tt.want[result.Field.Env]; !strings.HasPrefix(got, want) || (want == "" && got != "") {
+					t.Errorf("%s = %q, want %q", result.Field.Env, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckConfigFile(t *testing.T) {
+	section := Section{Name: "API", Fields: []Field{
+		{Env: "CORS_ALLOWED_ORIGINS", Type: "[]string"},
+	}}
+	env := map[string]string{"CONFIG_FILE": "../production.yaml"}
+	lookupEnv := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	fsys := fstest.MapFS{
+		"config.yaml":     &fstest.MapFile{Data: []byte("cors_allowed_origins: ignored\n")},
+		"production.yaml": &fstest.MapFile{Data: []byte("cors_allowed_origins:\n  - https://example.com\n")},
+	}
+	results, err := Check(fsys, section, Options{LookupEnv: lookupEnv})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if got := results[0].Source; got != "production.yaml" {
+		t.Errorf("source = %q, want production.yaml", got)
+	}
+
+	env["CONFIG_FILE"] = "missing.yaml"
+	if _, err := Check(fsys, section, Options{LookupEnv: lookupEnv}); err == nil {
+		t.Error("Check() error = nil, want an error for a missing CONFIG_FILE")
+	}
+}
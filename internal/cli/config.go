@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/AlyxPink/meower/internal/appconfig"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags for config check command
+var configNoCompose bool
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the configuration of the project's servers",
+	Long: titleStyle.Render("⚙️  Configuration") + "\n\n" +
+		subtitleStyle.Render("Settings are declared in api/config and read by the API and web servers from:") + "\n" +
+		subtitleStyle.Render("• Environment variables") + "\n" +
+		subtitleStyle.Render("• A .env file") + "\n" +
+		subtitleStyle.Render("• config.yaml, or the YAML file named by CONFIG_FILE") + "\n" +
+		subtitleStyle.Render("• Defaults declared in api/config") + "\n",
+}
+
+// configCheckCmd represents the config check command
+var configCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Report missing or invalid configuration values",
+	Long: titleStyle.Render("🔍 Check Configuration") + "\n\n" +
+		subtitleStyle.Render("Resolve every setting of api/config as the servers would at startup:") + "\n" +
+		subtitleStyle.Render("• Show where each value comes from") + "\n" +
+		subtitleStyle.Render("• Report required values that are missing and values failing validation") + "\n\n" +
+		subtitleStyle.Render("docker-compose.yml service environments count as set, use --no-compose to check a local 'go run'") + "\n",
+	Args: cobra.NoArgs,
+	RunE: runConfigCheckCommand,
+	// Errors are reported above; usage would only bury them
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configCheckCmd)
+
+	configCheckCmd.Flags().BoolVar(&configNoCompose, "no-compose", false, "ignore the environment set in docker-compose.yml")
+}
+
+func runConfigCheckCommand(cmd *cobra.Command, args []string) error {
+	if !isInMeowerProject() {
+		fmt.Println(errorStyle.Render("❌ Not in a Meower project"))
+		fmt.Println(subtitleStyle.Render("Run 'meower new project-name' to create a new project"))
+		return fmt.Errorf("not in a Meower project")
+	}
+
+	src, err := os.ReadFile(appconfig.SchemaPath)
+	if err != nil {
+		fmt.Println(errorStyle.Render("❌ No configuration package found:"), err)
+		return err
+	}
+	sections, err := appconfig.ParseSchema(src)
+	if err != nil {
+		fmt.Println(errorStyle.Render("❌ Error reading "+appconfig.SchemaPath+":"), err)
+		return err
+	}
+
+	fmt.Println(titleStyle.Render("🔍 Checking configuration"))
+
+	opts := appconfig.Options{LookupEnv: os.LookupEnv, Compose: !configNoCompose}
+	problems := 0
+	for _, section := range sections {
+		results, err := appconfig.Check(os.DirFS("."), section, opts)
+		if err != nil {
+			fmt.Println(errorStyle.Render("❌ Error loading "+section.Name+" configuration:"), err)
+			return err
+		}
+
+		fmt.Println()
+		fmt.Println(subtitleStyle.Render(fmt.Sprintf("%s (%s/)", section.Name, section.Dir())))
+		for _, result := range results {
+			switch {
+			case result.Err != nil:
+				problems++
+				fmt.Println(errorStyle.Render(fmt.Sprintf("  ❌ %s %v", result.Field.Env, result.Err)))
+			case result.Source == "":
+				fmt.Println(subtitleStyle.Render(fmt.Sprintf("  ○  %s not set", result.Field.Env)))
+			default:
+				fmt.Println(successStyle.Render(fmt.Sprintf("  ✅ %s", result.Field.Env)) + subtitleStyle.Render(" from "+result.Source))
+			}
+		}
+	}
+
+	fmt.Println()
+	if problems > 0 {
+		fmt.Println(errorStyle.Render(fmt.Sprintf("❌ %d missing or invalid values", problems)))
+		return fmt.Errorf("%d missing or invalid configuration values", problems)
+	}
+	fmt.Println(successStyle.Render("✅ Configuration is valid"))
+	return nil
+}
@@ -4,10 +4,10 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"unicode"
 
 	"github.com/AlyxPink/meower/internal/generators"
 	"github.com/AlyxPink/meower/internal/templates"
+	"github.com/AlyxPink/meower/internal/validation"
 
 	"github.com/spf13/cobra"
 )
@@ -23,8 +23,13 @@ var createHandlerCmd = &cobra.Command{
 		subtitleStyle.Render("Generate a complete gRPC service with:") + "\n" +
 		subtitleStyle.Render("• Protocol buffer service definition") + "\n" +
 		subtitleStyle.Render("• Server-side handler implementation") + "\n" +
+		subtitleStyle.Render("• Handler test over an in-memory gRPC connection") + "\n" +
 		subtitleStyle.Render("• Web client integration") + "\n" +
-		subtitleStyle.Render("• Route registration") + "\n",
+		subtitleStyle.Render("• Route registration") + "\n\n" +
+		subtitleStyle.Render("Methods are unary by default. Append a stream kind for streaming RPCs:") + "\n" +
+		subtitleStyle.Render("  -m List:server-stream,Upload:client-stream,Chat:bidi") + "\n\n" +
+		subtitleStyle.Render("Custom RPCs declare their own request and response fields:") + "\n" +
+		subtitleStyle.Render("  -m \"Publish(id:string)->(meow:Meow)\"") + "\n",
 	Args: cobra.ExactArgs(1),
 	RunE: runCreateHandlerCommand,
 }
@@ -32,7 +37,7 @@ var createHandlerCmd = &cobra.Command{
 func init() {
 	createCmd.AddCommand(createHandlerCmd)
 
-	createHandlerCmd.Flags().StringSliceVarP(&methods, "methods", "m", []string{"Create", "Get", "Update", "Delete", "List"}, "gRPC methods to generate")
+	createHandlerCmd.Flags().StringSliceVarP(&methods, "methods", "m", []string{"Create", "Get", "Update", "Delete", "List"}, "gRPC methods to generate: Name[:stream-kind] or Name(field:type,...)->(field:type,...)[:stream-kind]")
 }
 
 func runCreateHandlerCommand(cmd *cobra.Command, args []string) error {
@@ -51,6 +56,13 @@ func runCreateHandlerCommand(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Parse method specs
+	methodSpecs, err := generators.ParseMethods(methods, strings.TrimSuffix(serviceName, "Service"))
+	if err != nil {
+		fmt.Println(errorStyle.Render("❌ Invalid methods:"), err)
+		return nil
+	}
+
 	// Get current module path
 	modulePath, err := getCurrentModulePath()
 	if err != nil {
@@ -76,21 +88,28 @@ func runCreateHandlerCommand(cmd *cobra.Command, args []string) error {
 	// Generate protocol buffer definition
 	fmt.Println(subtitleStyle.Render("📝 Generating protobuf definition..."))
 	generator := generators.NewHandlerGenerator(vars)
-	if err := generator.GenerateProto(methods); err != nil {
+	if err := generator.GenerateProto(methodSpecs); err != nil {
 		fmt.Println(errorStyle.Render("❌ Error generating proto:"), err)
 		return nil
 	}
 
 	// Generate server handler
 	fmt.Println(subtitleStyle.Render("🖥️  Generating server handler..."))
-	if err := generator.GenerateServerHandler(methods); err != nil {
+	if err := generator.GenerateServerHandler(methodSpecs); err != nil {
 		fmt.Println(errorStyle.Render("❌ Error generating server handler:"), err)
 		return nil
 	}
 
+	// Generate server handler test
+	fmt.Println(subtitleStyle.Render("🧪 Generating handler test..."))
+	if err := generator.GenerateServerTest(methodSpecs); err != nil {
+		fmt.Println(errorStyle.Render("❌ Error generating handler test:"), err)
+		return nil
+	}
+
 	// Generate web handler
 	fmt.Println(subtitleStyle.Render("🌐 Generating web handler..."))
-	if err := generator.GenerateWebHandler(methods); err != nil {
+	if err := generator.GenerateWebHandler(methodSpecs); err != nil {
 		fmt.Println(errorStyle.Render("❌ Error generating web handler:"), err)
 		return nil
 	}
@@ -106,9 +125,11 @@ func runCreateHandlerCommand(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 	fmt.Println(titleStyle.Render("🚀 Next steps:"))
 	fmt.Println(subtitleStyle.Render("1. Run 'go generate ./...' to update protobuf files"))
-	fmt.Println(subtitleStyle.Render("2. Implement your business logic in the handler"))
-	fmt.Println(subtitleStyle.Render("3. Add any required database queries"))
-	fmt.Println(subtitleStyle.Render("4. Test your new endpoints"))
+	fmt.Println(subtitleStyle.Render("2. Implement your business logic in the handler, log with interceptors.Logger(ctx) to tag lines with the request ID"))
+	fmt.Println(subtitleStyle.Render("3. RPCs require a session token: get the caller with auth.UserID(ctx), or add option (auth.v1.public) = true to the ones anyone can call"))
+	fmt.Println(subtitleStyle.Render("4. Add any required database queries"))
+	fmt.Println(subtitleStyle.Render("5. Run 'go test ./server/handlers' in api/ to test your new endpoints behind the server's interceptors"))
+	fmt.Println(subtitleStyle.Render("6. Run 'meower proto gateway' to expose them in the JSON gateway"))
 
 	return nil
 }
@@ -151,32 +172,8 @@ func getCurrentModulePath() (string, error) {
 // - End with "Service" suffix for clarity and consistency
 // - Be long enough to be meaningful (minimum 8 characters)
 // - Not contain special characters that could break code generation
+//
+// The same rules are enforced on existing .proto files by `meower proto lint`.
 func validateServiceName(name string) error {
-	if name == "" {
-		return fmt.Errorf("service name cannot be empty")
-	}
-
-	// Must end with "Service" for consistency and clarity
-	if !strings.HasSuffix(name, "Service") {
-		return fmt.Errorf("service name must end with 'Service' (e.g. UserService, PostService, AuthService)")
-	}
-
-	// Minimum length check (at least "XService" = 8 chars)
-	if len(name) < 8 {
-		return fmt.Errorf("service name too short (minimum 8 characters: e.g., 'MyService')")
-	}
-
-	// Must start with uppercase (PascalCase)
-	if !unicode.IsUpper(rune(name[0])) {
-		return fmt.Errorf("service name must start with uppercase letter (PascalCase)")
-	}
-
-	// Check for invalid characters that could break code generation
-	for _, r := range name {
-		if !unicode.IsLetter(r) && !unicode.IsNumber(r) {
-			return fmt.Errorf("service name can only contain letters and numbers")
-		}
-	}
-
-	return nil
+	return validation.NewValidator().Service.ValidateGRPCServiceName(name)
 }
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/AlyxPink/meower/internal/generators"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags for create method command
+var (
+	methodRequest  string
+	methodResponse string
+	methodStream   string
+)
+
+// createMethodCmd represents the create method command
+var createMethodCmd = &cobra.Command{
+	Use:   "method [service-name] [method-name]",
+	Short: "Add an RPC to an existing gRPC service",
+	Long: titleStyle.Render("➕ Add gRPC Method") + "\n\n" +
+		subtitleStyle.Render("Add an RPC to a service that already exists:") + "\n" +
+		subtitleStyle.Render("• rpc and request/response messages appended to its .proto file") + "\n" +
+		subtitleStyle.Render("• Method stub appended to its server handler") + "\n\n" +
+		subtitleStyle.Render("Existing code is left untouched. Fields use the same name:type syntax") + "\n" +
+		subtitleStyle.Render("as create handler, and may refer to any message in the .proto file:") + "\n" +
+		subtitleStyle.Render("  meower create method MeowService Like --request \"meow_id:string\"") + "\n",
+	Args: cobra.ExactArgs(2),
+	RunE: runCreateMethodCommand,
+}
+
+func init() {
+	createCmd.AddCommand(createMethodCmd)
+
+	createMethodCmd.Flags().StringVar(&methodRequest, "request", "", "request fields: field:type,...")
+	createMethodCmd.Flags().StringVar(&methodResponse, "response", "", "response fields: field:type,...")
+	createMethodCmd.Flags().StringVar(&methodStream, "stream", "", "stream kind: server-stream, client-stream or bidi")
+}
+
+func runCreateMethodCommand(cmd *cobra.Command, args []string) error {
+	serviceName, methodName := args[0], args[1]
+
+	// Validate we're in a Meower project
+	if !isInMeowerProject() {
+		fmt.Println(errorStyle.Render("❌ Not in a Meower project"))
+		fmt.Println(subtitleStyle.Render("Run 'meower new project-name' to create a new project"))
+		return nil
+	}
+
+	// Validate service name
+	if err := validateServiceName(serviceName); err != nil {
+		fmt.Println(errorStyle.Render("❌ Invalid service name:"), err)
+		return nil
+	}
+
+	generator := generators.NewMethodGenerator()
+	service, err := generator.FindService(serviceName)
+	if err != nil {
+		fmt.Println(errorStyle.Render("❌ Service not found:"), err)
+		fmt.Println(subtitleStyle.Render("Run 'meower create handler " + serviceName + "' to create it"))
+		return nil
+	}
+
+	method, err := service.NewMethod(methodName, methodRequest, methodResponse, generators.StreamKind(methodStream))
+	if err != nil {
+		fmt.Println(errorStyle.Render("❌ Invalid method:"), err)
+		return nil
+	}
+
+	fmt.Println(titleStyle.Render("➕ Adding gRPC method"))
+	fmt.Println(subtitleStyle.Render("Service:"), serviceName)
+	fmt.Println(subtitleStyle.Render("Method:"), methodName)
+	fmt.Println()
+
+	if err := generator.AddMethod(service, method); err != nil {
+		fmt.Println(errorStyle.Render("❌ Error adding method:"), err)
+		return nil
+	}
+
+	fmt.Println(subtitleStyle.Render("📝 Updated"), service.ProtoPath)
+	fmt.Println(subtitleStyle.Render("🖥️  Updated"), service.HandlerPath)
+	fmt.Println(successStyle.Render("✅ Method added successfully!"))
+	fmt.Println()
+	fmt.Println(titleStyle.Render("🚀 Next steps:"))
+	fmt.Println(subtitleStyle.Render("1. Run 'go generate ./...' to update protobuf files"))
+	fmt.Println(subtitleStyle.Render("2. Implement " + methodName + " in " + service.HandlerPath))
+	fmt.Println(subtitleStyle.Render("3. Run 'meower proto gateway' to expose it in the JSON gateway"))
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/AlyxPink/meower/internal/generators"
+
+	"github.com/spf13/cobra"
+)
+
+// createVersionCmd represents the create version command
+var createVersionCmd = &cobra.Command{
+	Use:   "version [service-name] [version]",
+	Short: "Add a new API version of an existing gRPC service",
+	Long: titleStyle.Render("🏷️  Add API Version") + "\n\n" +
+		subtitleStyle.Render("Start a new major version of a service without breaking older clients:") + "\n" +
+		subtitleStyle.Render("• Previous .proto copied to the new version with its package updated") + "\n" +
+		subtitleStyle.Render("• Server handler delegating every RPC to the previous version") + "\n" +
+		subtitleStyle.Render("• Both versions registered in api/server/server.go and web/grpc/client.go") + "\n\n" +
+		subtitleStyle.Render("  meower create version MeowService v2") + "\n",
+	Args: cobra.ExactArgs(2),
+	RunE: runCreateVersionCommand,
+}
+
+func init() {
+	createCmd.AddCommand(createVersionCmd)
+}
+
+func runCreateVersionCommand(cmd *cobra.Command, args []string) error {
+	serviceName, version := args[0], args[1]
+
+	// Validate we're in a Meower project
+	if !isInMeowerProject() {
+		fmt.Println(errorStyle.Render("❌ Not in a Meower project"))
+		fmt.Println(subtitleStyle.Render("Run 'meower new project-name' to create a new project"))
+		return nil
+	}
+
+	// Validate service name
+	if err := validateServiceName(serviceName); err != nil {
+		fmt.Println(errorStyle.Render("❌ Invalid service name:"), err)
+		return nil
+	}
+
+	fmt.Println(titleStyle.Render("🏷️  Adding API version"))
+	fmt.Println(subtitleStyle.Render("Service:"), serviceName)
+	fmt.Println(subtitleStyle.Render("Version:"), version)
+	fmt.Println()
+
+	service, err := generators.NewVersionGenerator().AddVersion(serviceName, version)
+	if err != nil {
+		fmt.Println(errorStyle.Render("❌ Error adding version:"), err)
+		return nil
+	}
+
+	fmt.Println(subtitleStyle.Render("📝 Created"), service.ProtoPath)
+	fmt.Println(subtitleStyle.Render("🖥️  Created"), service.HandlerPath)
+	fmt.Println(subtitleStyle.Render("🔌 Updated api/server/server.go and web/grpc/client.go"))
+	fmt.Println(successStyle.Render("✅ Version added successfully!"))
+	fmt.Println()
+	fmt.Println(titleStyle.Render("🚀 Next steps:"))
+	fmt.Println(subtitleStyle.Render("1. Run 'go generate ./...' to update protobuf files"))
+	fmt.Println(subtitleStyle.Render("2. Evolve " + service.ProtoPath + " and override RPCs in " + service.HandlerPath))
+	fmt.Println(subtitleStyle.Render("3. Run 'meower proto breaking --against main' to keep older versions intact"))
+	fmt.Println(subtitleStyle.Render("4. Run 'meower proto gateway' to expose " + version + " in the JSON gateway"))
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/AlyxPink/meower/internal/generators"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags for docs openapi command
+var (
+	openAPITitle  string
+	openAPIOutput string
+)
+
+// docsCmd represents the docs command
+var docsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Generate API documentation",
+	Long: titleStyle.Render("📚 API Documentation") + "\n\n" +
+		subtitleStyle.Render("Generate documentation from the project's .proto files:") + "\n" +
+		subtitleStyle.Render("• OpenAPI 3 document of the JSON gateway") + "\n",
+}
+
+// docsOpenAPICmd represents the docs openapi command
+var docsOpenAPICmd = &cobra.Command{
+	Use:   "openapi",
+	Short: "Generate an OpenAPI 3 document from the project's .proto files",
+	Long: titleStyle.Render("📖 Generate OpenAPI Document") + "\n\n" +
+		subtitleStyle.Render("Convert api/proto to an OpenAPI 3 document describing the JSON gateway:") + "\n" +
+		subtitleStyle.Render("• One operation per route of 'meower proto gateway'") + "\n" +
+		subtitleStyle.Render("• Message and enum schemas, with comments as descriptions") + "\n" +
+		subtitleStyle.Render("• protojson encoding: timestamps as RFC 3339, 64-bit integers as strings") + "\n\n" +
+		subtitleStyle.Render("The web app serves the document and a viewer at /api/docs when DOCS_ENABLED=true") + "\n",
+	Args: cobra.NoArgs,
+	RunE: runDocsOpenAPICommand,
+	// Errors are reported above; usage would only bury them
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(docsCmd)
+	docsCmd.AddCommand(docsOpenAPICmd)
+
+	docsOpenAPICmd.Flags().StringVar(&openAPITitle, "title", "", "document title (default \"<project> API\")")
+	docsOpenAPICmd.Flags().StringVarP(&openAPIOutput, "output", "o", generators.OpenAPIPath, "file to write the document to")
+}
+
+func runDocsOpenAPICommand(cmd *cobra.Command, args []string) error {
+	if !isInMeowerProject() {
+		fmt.Println(errorStyle.Render("❌ Not in a Meower project"))
+		fmt.Println(subtitleStyle.Render("Run 'meower new project-name' to create a new project"))
+		return fmt.Errorf("not in a Meower project")
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	title := openAPITitle
+	if title == "" {
+		title = filepath.Base(wd) + " API"
+	}
+
+	// The generator writes relative to the project root
+	output := openAPIOutput
+	if filepath.IsAbs(output) {
+		if output, err = filepath.Rel(wd, output); err != nil {
+			return fmt.Errorf("invalid output path: %w", err)
+		}
+	}
+
+	fmt.Println(titleStyle.Render("📖 Generating OpenAPI document"))
+	fmt.Println()
+
+	result, err := generators.NewOpenAPIGenerator().Generate(title, filepath.ToSlash(output))
+	if err != nil {
+		fmt.Println(errorStyle.Render("❌ Error generating OpenAPI document:"), err)
+		return err
+	}
+
+	for _, skipped := range result.Skipped {
+		fmt.Println(warningStyle.Render("⚠️  Skipped " + skipped))
+	}
+	fmt.Println(successStyle.Render(fmt.Sprintf("✅ %d operations and %d schemas written to %s", result.Operations, result.Schemas, result.Path)))
+	return nil
+}
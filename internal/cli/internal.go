@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/AlyxPink/meower/internal/templates"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags for internal template commands
+var (
+	internalTemplateDir string
+	statusCheck         bool
+)
+
+// internalCmd groups commands used when developing Meower itself
+var internalCmd = &cobra.Command{
+	Use:    "internal",
+	Short:  "Commands for Meower contributors",
+	Hidden: true,
+}
+
+// internalTemplateCmd represents the internal template command
+var internalTemplateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Switch the template between development and embed modes",
+	Long: titleStyle.Render("🔧 Template Modes") + "\n\n" +
+		subtitleStyle.Render("go:embed skips directories containing a go.mod, so the template has two modes:") + "\n" +
+		subtitleStyle.Render("• dev: working go.mod/go.sum files for building and running the template") + "\n" +
+		subtitleStyle.Render("• embed: go.mod.template/go.sum.template files, ready to commit and embed") + "\n",
+}
+
+var internalTemplateDevCmd = &cobra.Command{
+	Use:   "dev",
+	Short: "Enable development mode",
+	Args:  cobra.NoArgs,
+	RunE:  runInternalTemplateDevCommand,
+}
+
+var internalTemplateEmbedCmd = &cobra.Command{
+	Use:   "embed",
+	Short: "Enable embed mode (run before committing)",
+	Args:  cobra.NoArgs,
+	RunE:  runInternalTemplateEmbedCommand,
+}
+
+var internalTemplateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the current template mode",
+	Args:  cobra.NoArgs,
+	RunE:  runInternalTemplateStatusCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(internalCmd)
+	internalCmd.AddCommand(internalTemplateCmd)
+	internalTemplateCmd.AddCommand(internalTemplateDevCmd, internalTemplateEmbedCmd, internalTemplateStatusCmd)
+
+	internalTemplateCmd.PersistentFlags().StringVarP(&internalTemplateDir, "dir", "d", "", "Template directory (default: cmd/meower/template in the enclosing repository)")
+	internalTemplateStatusCmd.Flags().BoolVar(&statusCheck, "check", false, "Exit with an error unless the tree is in embed mode and committed correctly")
+}
+
+func runInternalTemplateDevCommand(cmd *cobra.Command, args []string) error {
+	manager, err := newModeManager()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(titleStyle.Render("🔧 Enabling development mode"))
+	actions, err := manager.EnableDev()
+	printModeActions(actions)
+	if err != nil {
+		return fmt.Errorf("failed to enable development mode: %w", err)
+	}
+
+	fmt.Println(successStyle.Render("✅ Development mode enabled!"))
+	fmt.Println()
+	fmt.Println(titleStyle.Render("📋 Next steps:"))
+	fmt.Println(subtitleStyle.Render("1. cd cmd/meower/template"))
+	fmt.Println(subtitleStyle.Render("2. docker compose up proto -d"))
+	fmt.Println(subtitleStyle.Render("3. docker compose up -d"))
+	fmt.Println()
+	fmt.Println(subtitleStyle.Render("💡 When you're done: make stop"))
+
+	return nil
+}
+
+func runInternalTemplateEmbedCommand(cmd *cobra.Command, args []string) error {
+	manager, err := newModeManager()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(titleStyle.Render("📦 Enabling embed mode"))
+	actions, err := manager.EnableEmbed()
+	printModeActions(actions)
+	if err != nil {
+		return fmt.Errorf("failed to enable embed mode: %w", err)
+	}
+
+	fmt.Println(successStyle.Render("✅ Embed mode enabled, ready for git commit!"))
+	return nil
+}
+
+func runInternalTemplateStatusCommand(cmd *cobra.Command, args []string) error {
+	manager, err := newModeManager()
+	if err != nil {
+		return err
+	}
+
+	status, err := manager.Status()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(titleStyle.Render("📦 Template mode:"), string(status.Mode))
+	for _, module := range status.Modules {
+		switch {
+		case module.HasGoMod && module.OutOfSync:
+			fmt.Println(warningStyle.Render("⚠️  " + module.Dir + ": go.mod has changes not yet converted to go.mod.template"))
+		case module.HasGoMod:
+			fmt.Println(subtitleStyle.Render(module.Dir + ": dev (go.mod)"))
+		case module.HasTemplate:
+			fmt.Println(subtitleStyle.Render(module.Dir + ": embed (go.mod.template)"))
+		default:
+			fmt.Println(warningStyle.Render("⚠️  " + module.Dir + ": no go.mod or go.mod.template found"))
+		}
+		if module.TemplateHasDevName {
+			fmt.Println(errorStyle.Render("❌ "+module.Dir+"/go.mod.template references the dev-mode module name"), templates.DevModeModuleName)
+		}
+	}
+
+	if len(status.GeneratedFiles) > 0 {
+		fmt.Println(subtitleStyle.Render(fmt.Sprintf("%d generated files present", len(status.GeneratedFiles))))
+	}
+
+	if !status.GitChecked {
+		fmt.Println(warningStyle.Render("⚠️  Not a git repository, skipping committed-mode check"))
+	}
+	for _, path := range status.Committed {
+		fmt.Println(errorStyle.Render("❌ Tracked by git but only valid in dev mode:"), path)
+	}
+
+	if !statusCheck {
+		return nil
+	}
+
+	if status.CommittedInWrongMode() {
+		return fmt.Errorf("template is committed in the wrong mode, run 'meower internal template embed' and untrack dev-mode files")
+	}
+	if status.Mode != templates.ModeEmbed {
+		return fmt.Errorf("template is in %s mode, run 'meower internal template embed' before committing", status.Mode)
+	}
+
+	fmt.Println(successStyle.Render("✅ Template is ready to commit"))
+	return nil
+}
+
+// newModeManager resolves the template directory and creates a mode manager for it
+func newModeManager() (*templates.ModeManager, error) {
+	dir := internalTemplateDir
+	if dir == "" {
+		found, err := findTemplateDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = found
+	}
+
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("template directory not found: %s", dir)
+	}
+
+	return templates.NewModeManager(dir), nil
+}
+
+// findTemplateDir walks up from the working directory looking for the Meower
+// repository's cmd/meower/template directory
+func findTemplateDir() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		candidate := filepath.Join(dir, "cmd", "meower", "template")
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("cmd/meower/template not found, run from the Meower repository or pass --dir")
+		}
+		dir = parent
+	}
+}
+
+func printModeActions(actions []string) {
+	for _, action := range actions {
+		fmt.Println(subtitleStyle.Render("• " + action))
+	}
+}
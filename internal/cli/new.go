@@ -11,8 +11,9 @@ import (
 
 var (
 	// Flags for new command
-	modulePath string
-	force      bool
+	modulePath    string
+	force         bool
+	verifyProject bool
 )
 
 // newCmd represents the new command
@@ -35,6 +36,7 @@ func init() {
 
 	newCmd.Flags().StringVarP(&modulePath, "module", "m", "", "Go module path (e.g. github.com/user/project)")
 	newCmd.Flags().BoolVarP(&force, "force", "f", false, "Force creation even if directory exists")
+	newCmd.Flags().BoolVar(&verifyProject, "verify", false, "Run codegen, go build and go vet in the generated project")
 }
 
 // implements the core project scaffolding logic using the refactored architecture
@@ -44,10 +46,11 @@ func runNewCommand(cmd *cobra.Command, args []string) error {
 		ProjectName: args[0],
 		ModulePath:  modulePath,
 		Force:       force,
+		Verify:      verifyProject,
 	}
 
 	// Create and execute project generator
-	generator := NewProjectGenerator(config)
+	generator := NewProjectGenerator(config).WithContext(cmd.Context())
 	if err := generator.Generate(); err != nil {
 		fmt.Println(errorStyle.Render("❌ Project generation failed:"), err)
 		return err // Return error for proper exit codes in testing
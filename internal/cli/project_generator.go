@@ -1,12 +1,15 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/AlyxPink/meower/internal/templates"
 	"github.com/AlyxPink/meower/internal/validation"
+	"github.com/AlyxPink/meower/internal/verify"
 )
 
 // ProjectConfig holds all configuration needed for project generation
@@ -14,6 +17,7 @@ type ProjectConfig struct {
 	ProjectName string
 	ModulePath  string
 	Force       bool
+	Verify      bool
 	DestDir     string
 }
 
@@ -21,16 +25,32 @@ type ProjectConfig struct {
 type ProjectGenerator struct {
 	validator *validation.Validator
 	config    *ProjectConfig
+	out       templates.Writer
+	ctx       context.Context
 }
 
-// NewProjectGenerator creates a new project generator
+// NewProjectGenerator creates a new project generator that writes to config.DestDir
 func NewProjectGenerator(config *ProjectConfig) *ProjectGenerator {
+	return NewProjectGeneratorWithWriter(config, nil)
+}
+
+// NewProjectGeneratorWithWriter creates a project generator that writes to out.
+// A nil writer writes to disk under the destination directory.
+func NewProjectGeneratorWithWriter(config *ProjectConfig, out templates.Writer) *ProjectGenerator {
 	return &ProjectGenerator{
 		validator: validation.NewValidator(),
 		config:    config,
+		out:       out,
+		ctx:       context.Background(),
 	}
 }
 
+// WithContext sets the context that bounds long-running steps such as verification
+func (pg *ProjectGenerator) WithContext(ctx context.Context) *ProjectGenerator {
+	pg.ctx = ctx
+	return pg
+}
+
 // ValidateAndPrepare validates the project configuration and prepares for generation
 func (pg *ProjectGenerator) ValidateAndPrepare() error {
 	// Validate project name
@@ -56,6 +76,9 @@ func (pg *ProjectGenerator) ValidateAndPrepare() error {
 
 	// Set destination directory
 	pg.config.DestDir = filepath.Join(".", pg.config.ProjectName)
+	if pg.out == nil {
+		pg.out = templates.NewDiskWriter(pg.config.DestDir)
+	}
 
 	return nil
 }
@@ -63,7 +86,7 @@ func (pg *ProjectGenerator) ValidateAndPrepare() error {
 // CreateProjectStructure creates the basic project directory structure
 func (pg *ProjectGenerator) CreateProjectStructure() error {
 	// Create destination directory
-	if err := os.MkdirAll(pg.config.DestDir, 0o755); err != nil {
+	if err := pg.out.MkdirAll("."); err != nil {
 		return fmt.Errorf("failed to create project directory: %w", err)
 	}
 
@@ -88,7 +111,7 @@ func (pg *ProjectGenerator) ProcessTemplates() error {
 
 	fmt.Println(subtitleStyle.Render("📂 Copying project structure..."))
 
-	if err := processor.ProcessEmbeddedFiles(pg.config.DestDir); err != nil {
+	if err := processor.RenderEmbeddedFiles(pg.out); err != nil {
 		// Fallback to local files (for development)
 		return pg.fallbackToLocalFiles(vars)
 	}
@@ -112,6 +135,59 @@ func (pg *ProjectGenerator) PostProcess() error {
 	return nil
 }
 
+// Verify runs codegen, go build and go vet in the generated project and prints a summary
+func (pg *ProjectGenerator) Verify() error {
+	fmt.Println()
+	fmt.Println(titleStyle.Render("🔍 Verifying generated project..."))
+
+	report, err := verify.NewVerifier(pg.config.DestDir).WithOutput(os.Stdout).Run(pg.ctx)
+	if err != nil {
+		return err
+	}
+
+	printVerifyReport(report)
+
+	if report.Failed() {
+		_, failed, _ := report.Counts()
+		return fmt.Errorf("%d verification step(s) failed", failed)
+	}
+	return nil
+}
+
+// printVerifyReport prints one line per step, followed by the output of failed steps
+func printVerifyReport(report *verify.Report) {
+	for _, result := range report.Results {
+		label := fmt.Sprintf("%s: %s", result.Module, result.Name)
+		switch result.Status {
+		case verify.StatusPassed:
+			fmt.Println(successStyle.Render("✅ "+label), subtitleStyle.Render(result.Duration.Round(time.Millisecond).String()))
+		case verify.StatusSkipped:
+			fmt.Println(warningStyle.Render("⏭️  " + label + " (skipped)"))
+		case verify.StatusFailed:
+			fmt.Println(errorStyle.Render("❌ " + label))
+		}
+	}
+
+	for _, result := range report.Results {
+		if result.Status != verify.StatusFailed {
+			continue
+		}
+		fmt.Println()
+		fmt.Println(errorStyle.Render(fmt.Sprintf("%s: %s failed:", result.Module, result.Name)), result.Err)
+		if result.Command != "" {
+			fmt.Println(subtitleStyle.Render("$ " + result.Command))
+		}
+		if result.Output != "" {
+			fmt.Println(result.Output)
+		}
+	}
+
+	passed, failed, skipped := report.Counts()
+	fmt.Println()
+	fmt.Println(titleStyle.Render("📋 Verification summary:"),
+		fmt.Sprintf("%d passed, %d failed, %d skipped in %s", passed, failed, skipped, report.Duration.Round(time.Millisecond)))
+}
+
 // ShowSuccessMessage displays the success message and next steps
 func (pg *ProjectGenerator) ShowSuccessMessage() {
 	fmt.Println(successStyle.Render("✅ Project created successfully!"))
@@ -133,15 +209,19 @@ func (pg *ProjectGenerator) Generate() error {
 	fmt.Println()
 
 	// Execute generation steps
-	steps := []struct {
+	type step struct {
 		name string
 		fn   func() error
-	}{
+	}
+	steps := []step{
 		{"validate configuration", pg.ValidateAndPrepare},
 		{"create project structure", pg.CreateProjectStructure},
 		{"process templates", pg.ProcessTemplates},
 		{"post-process", pg.PostProcess},
 	}
+	if pg.config.Verify {
+		steps = append(steps, step{"verify project", pg.Verify})
+	}
 
 	for _, step := range steps {
 		if err := step.fn(); err != nil {
@@ -156,8 +236,7 @@ func (pg *ProjectGenerator) Generate() error {
 
 // createMarkerFile creates the .meowed marker file
 func (pg *ProjectGenerator) createMarkerFile() error {
-	markerFile := filepath.Join(pg.config.DestDir, MarkerFileName)
-	return os.WriteFile(markerFile, []byte(MarkerFileContent), 0o644)
+	return pg.out.WriteFile(MarkerFileName, []byte(MarkerFileContent))
 }
 
 // fallbackToLocalFiles handles fallback to local development files
@@ -0,0 +1,234 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlyxPink/meower/internal/generators"
+	"github.com/AlyxPink/meower/internal/proto"
+
+	"github.com/spf13/cobra"
+)
+
+// protoDir is where a Meower project keeps its .proto files
+const protoDir = "api/proto"
+
+// Flags for proto breaking command
+var breakingAgainst string
+
+// protoCmd represents the proto command
+var protoCmd = &cobra.Command{
+	Use:   "proto",
+	Short: "Check the project's protobuf definitions",
+	Long: titleStyle.Render("📜 Protobuf Definitions") + "\n\n" +
+		subtitleStyle.Render("Check the .proto files under api/proto, offline:") + "\n" +
+		subtitleStyle.Render("• Lint naming and numbering conventions") + "\n" +
+		subtitleStyle.Render("• Detect breaking changes against a git ref or directory") + "\n" +
+		subtitleStyle.Render("• Generate the JSON gateway routes of the web app") + "\n",
+}
+
+// protoLintCmd represents the proto lint command
+var protoLintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Lint the project's .proto files",
+	Long: titleStyle.Render("🔍 Lint Protobuf Definitions") + "\n\n" +
+		subtitleStyle.Render("Parse every .proto file under api/proto and report:") + "\n" +
+		subtitleStyle.Render("• Packages not matching their directory or missing a version") + "\n" +
+		subtitleStyle.Render("• Service names create handler would reject") + "\n" +
+		subtitleStyle.Render("• Misnamed rpcs, messages, fields and enum values") + "\n" +
+		subtitleStyle.Render("• Invalid, reserved or duplicate field numbers") + "\n" +
+		subtitleStyle.Render("• References to undefined types") + "\n",
+	Args: cobra.NoArgs,
+	RunE: runProtoLintCommand,
+	// Issues are reported above; usage would only bury them
+	SilenceUsage: true,
+}
+
+// protoBreakingCmd represents the proto breaking command
+var protoBreakingCmd = &cobra.Command{
+	Use:   "breaking",
+	Short: "Detect breaking changes in the project's .proto files",
+	Long: titleStyle.Render("💥 Detect Breaking Changes") + "\n\n" +
+		subtitleStyle.Render("Compare api/proto with a previous version and report:") + "\n" +
+		subtitleStyle.Render("• Deleted services, rpcs, messages, fields and enum values") + "\n" +
+		subtitleStyle.Render("• Renumbered or renamed fields") + "\n" +
+		subtitleStyle.Render("• Changed field, request and response types") + "\n\n" +
+		subtitleStyle.Render("--against takes a git ref or a directory (a project root or a proto root):") + "\n" +
+		subtitleStyle.Render("  meower proto breaking --against main") + "\n",
+	Args: cobra.NoArgs,
+	RunE: runProtoBreakingCommand,
+	// Issues are reported above; usage would only bury them
+	SilenceUsage: true,
+}
+
+// protoGatewayCmd represents the proto gateway command
+var protoGatewayCmd = &cobra.Command{
+	Use:   "gateway",
+	Short: "Generate the JSON gateway routes from the project's .proto files",
+	Long: titleStyle.Render("🌉 Generate JSON Gateway") + "\n\n" +
+		subtitleStyle.Render("Write web/gateway/routes.go, exposing every unary RPC as JSON over HTTP:") + "\n" +
+		subtitleStyle.Render("• RPCs with a google.api.http option use its method, path and body") + "\n" +
+		subtitleStyle.Render("• Others follow a REST convention: CreateMeow is POST /api/v1/meows,") + "\n" +
+		subtitleStyle.Render("  GetMeow is GET /api/v1/meows/:id, anything else is POST /api/v1/meows/<rpc>") + "\n" +
+		subtitleStyle.Render("• Streaming RPCs are skipped") + "\n\n" +
+		subtitleStyle.Render("The gateway is served by the web app when GATEWAY_ENABLED=true") + "\n",
+	Args: cobra.NoArgs,
+	RunE: runProtoGatewayCommand,
+	// Errors are reported above; usage would only bury them
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(protoCmd)
+	protoCmd.AddCommand(protoLintCmd)
+	protoCmd.AddCommand(protoBreakingCmd)
+	protoCmd.AddCommand(protoGatewayCmd)
+
+	protoBreakingCmd.Flags().StringVar(&breakingAgainst, "against", "", "git ref or directory to compare against")
+	protoBreakingCmd.MarkFlagRequired("against")
+}
+
+func runProtoLintCommand(cmd *cobra.Command, args []string) error {
+	files, err := loadProjectProtos()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(titleStyle.Render("🔍 Linting protobuf definitions"))
+	fmt.Println()
+
+	issues := proto.Lint(files)
+	return reportProtoIssues(issues, len(files), "proto lint found %d issues")
+}
+
+func runProtoBreakingCommand(cmd *cobra.Command, args []string) error {
+	files, err := loadProjectProtos()
+	if err != nil {
+		return err
+	}
+
+	previous, err := loadProtosFrom(breakingAgainst)
+	if err != nil {
+		return fmt.Errorf("failed to load protos from %s: %w", breakingAgainst, err)
+	}
+
+	fmt.Println(titleStyle.Render("💥 Checking for breaking changes"))
+	fmt.Println(subtitleStyle.Render("Against:"), breakingAgainst)
+	fmt.Println()
+
+	issues := proto.Breaking(files, previous)
+	return reportProtoIssues(issues, len(files), "proto breaking found %d breaking changes")
+}
+
+func runProtoGatewayCommand(cmd *cobra.Command, args []string) error {
+	if !isInMeowerProject() {
+		fmt.Println(errorStyle.Render("❌ Not in a Meower project"))
+		fmt.Println(subtitleStyle.Render("Run 'meower new project-name' to create a new project"))
+		return fmt.Errorf("not in a Meower project")
+	}
+
+	fmt.Println(titleStyle.Render("🌉 Generating JSON gateway"))
+	fmt.Println()
+
+	result, err := generators.NewGatewayGenerator().Generate()
+	if err != nil {
+		fmt.Println(errorStyle.Render("❌ Error generating gateway:"), err)
+		return err
+	}
+
+	for _, route := range result.Routes {
+		fmt.Println(subtitleStyle.Render(fmt.Sprintf("  %-6s %s", route.Method, route.Path)), "→", route.ServiceName+"."+route.RPC)
+	}
+	for _, skipped := range result.Skipped {
+		fmt.Println(warningStyle.Render("⚠️  Skipped " + skipped))
+	}
+
+	fmt.Println()
+	fmt.Println(successStyle.Render(fmt.Sprintf("✅ %d routes written to %s", len(result.Routes), result.Path)))
+	return nil
+}
+
+// reportProtoIssues prints issues and returns an error if there are any, so
+// the commands can gate CI
+func reportProtoIssues(issues []proto.Issue, fileCount int, errFormat string) error {
+	for _, issue := range issues {
+		fmt.Println(errorStyle.Render("❌"), issue.String())
+	}
+
+	fmt.Println()
+	if len(issues) > 0 {
+		fmt.Println(errorStyle.Render(fmt.Sprintf("❌ %d files checked, %d issues", fileCount, len(issues))))
+		return fmt.Errorf(errFormat, len(issues))
+	}
+
+	fmt.Println(successStyle.Render(fmt.Sprintf("✅ %d files checked, no issues", fileCount)))
+	return nil
+}
+
+// loadProjectProtos parses the current project's .proto files
+func loadProjectProtos() ([]*proto.File, error) {
+	if !isInMeowerProject() {
+		fmt.Println(errorStyle.Render("❌ Not in a Meower project"))
+		fmt.Println(subtitleStyle.Render("Run 'meower new project-name' to create a new project"))
+		return nil, fmt.Errorf("not in a Meower project")
+	}
+
+	files, err := proto.ParseDir(os.DirFS(protoDir), ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", protoDir, err)
+	}
+	return files, nil
+}
+
+// loadProtosFrom parses the .proto files of a previous version. against is
+// either a directory, a project root or the proto root itself, or a git ref
+// read from the current repository.
+func loadProtosFrom(against string) ([]*proto.File, error) {
+	if info, err := os.Stat(against); err == nil && info.IsDir() {
+		dir := against
+		if info, err := os.Stat(filepath.Join(against, protoDir)); err == nil && info.IsDir() {
+			dir = filepath.Join(against, protoDir)
+		}
+		return proto.ParseDir(os.DirFS(dir), ".")
+	}
+
+	sources, err := gitProtoSources(against)
+	if err != nil {
+		return nil, err
+	}
+	return proto.ParseSources(sources)
+}
+
+// gitProtoSources reads the .proto files under api/proto at a git ref, keyed
+// by their path relative to api/proto
+func gitProtoSources(ref string) (map[string][]byte, error) {
+	out, err := exec.Command("git", "ls-tree", "-r", "--name-only", ref, "--", protoDir).Output()
+	if err != nil {
+		return nil, fmt.Errorf("%q is neither a directory nor a git ref: %w", ref, gitError(err))
+	}
+
+	sources := make(map[string][]byte)
+	for _, name := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if path.Ext(name) != ".proto" {
+			continue
+		}
+		src, err := exec.Command("git", "show", ref+":./"+name).Output()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s at %s: %w", name, ref, gitError(err))
+		}
+		sources[strings.TrimPrefix(name, protoDir+"/")] = src
+	}
+	return sources, nil
+}
+
+// gitError includes git's stderr in err
+func gitError(err error) error {
+	if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("%s", strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return err
+}
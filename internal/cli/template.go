@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/AlyxPink/meower/internal/templates"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags for template lint command
+var (
+	lintProjectName string
+	lintModulePath  string
+	lintServiceName string
+)
+
+// templateCmd represents the template command
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Inspect the embedded project template",
+	Long: titleStyle.Render("📦 Project Template") + "\n\n" +
+		subtitleStyle.Render("Tools for working with the template embedded in the CLI:") + "\n" +
+		subtitleStyle.Render("• Lint placeholders and dev-mode leftovers") + "\n",
+}
+
+// templateLintCmd represents the template lint command
+var templateLintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Verify placeholder coverage in the embedded template",
+	Long: titleStyle.Render("🔍 Lint Embedded Template") + "\n\n" +
+		subtitleStyle.Render("Scan the embedded template and report:") + "\n" +
+		subtitleStyle.Render("• Unknown TEMPLATE_ placeholders") + "\n" +
+		subtitleStyle.Render("• Placeholders left unreplaced for the given variables") + "\n" +
+		subtitleStyle.Render("• Files still referencing the dev-mode module name") + "\n" +
+		subtitleStyle.Render("• Hidden files that will not be copied to projects") + "\n",
+	Args: cobra.NoArgs,
+	RunE: runTemplateLintCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(templateCmd)
+	templateCmd.AddCommand(templateLintCmd)
+
+	templateLintCmd.Flags().StringVarP(&lintProjectName, "project", "p", "my-app", "Project name used to build template variables")
+	templateLintCmd.Flags().StringVarP(&lintModulePath, "module", "m", DefaultModulePrefix+"/my-app", "Module path used to build template variables")
+	templateLintCmd.Flags().StringVarP(&lintServiceName, "service", "s", "", "Service name used to build template variables (optional)")
+}
+
+func runTemplateLintCommand(cmd *cobra.Command, args []string) error {
+	vars := templates.NewTemplateVars()
+	if err := vars.SetProject(lintProjectName, lintModulePath); err != nil {
+		return fmt.Errorf("failed to set project variables: %w", err)
+	}
+	if lintServiceName != "" {
+		if err := vars.SetService(lintServiceName); err != nil {
+			return fmt.Errorf("failed to set service variables: %w", err)
+		}
+	}
+
+	fmt.Println(titleStyle.Render("🔍 Linting embedded template"))
+	fmt.Println()
+
+	report, err := templates.NewTemplateLinter(templates.EmbeddedFiles, vars).Lint()
+	if err != nil {
+		return fmt.Errorf("failed to lint template: %w", err)
+	}
+
+	for _, issue := range report.Warnings() {
+		fmt.Println(warningStyle.Render("⚠️ "), issue.String())
+	}
+	for _, issue := range report.Errors() {
+		fmt.Println(errorStyle.Render("❌"), issue.String())
+	}
+
+	errorCount, warningCount := len(report.Errors()), len(report.Warnings())
+	fmt.Println()
+	if errorCount > 0 {
+		fmt.Println(errorStyle.Render(fmt.Sprintf("❌ %d files scanned, %d errors, %d warnings", report.FilesScanned, errorCount, warningCount)))
+		return fmt.Errorf("template lint found %d errors", errorCount)
+	}
+
+	fmt.Println(successStyle.Render(fmt.Sprintf("✅ %d files scanned, no errors, %d warnings", report.FilesScanned, warningCount)))
+	return nil
+}
@@ -0,0 +1,296 @@
+package generators
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/AlyxPink/meower/internal/proto"
+	"github.com/AlyxPink/meower/internal/templates"
+)
+
+// gatewayRoutesPath is the generated route table of the JSON gateway
+var gatewayRoutesPath = path.Join("web", "gateway", "routes.go")
+
+// httpRuleRegex matches the `method: "path"` pairs of a google.api.http option
+var httpRuleRegex = regexp.MustCompile(`(\w+)\s*:\s*"((?:[^"\\]|\\.)*)"`)
+
+// pathVariableRegex matches the {field} variables of a google.api.http path
+var pathVariableRegex = regexp.MustCompile(`\{[^}]*\}`)
+
+// GatewayRoute maps an HTTP method and path to a unary RPC
+type GatewayRoute struct {
+	Method string
+	// Path is the Fiber path of the route, such as /api/v1/meows/:id
+	Path string
+	// Body is "*", a request field name, or "" for requests without a body
+	Body string
+
+	ServiceName string
+	RPC         string
+	// Client is the variable holding the service's gRPC client
+	Client string
+
+	file *proto.File
+	rpc  *proto.RPC
+}
+
+// GatewayService is a versioned service exposed by the gateway
+type GatewayService struct {
+	Name       string
+	Client     string
+	ProtoAlias string
+	GoPackage  string
+}
+
+// GatewayGenerator generates the route table of the web app's JSON gateway
+// from the .proto files under api/proto. RPCs annotated with google.api.http
+// use the annotated method, path and body; others follow a REST naming
+// convention under /api/<version>. Streaming RPCs are skipped.
+type GatewayGenerator struct {
+	src fs.FS
+	out templates.Writer
+}
+
+// NewGatewayGenerator creates a gateway generator working in the current directory
+func NewGatewayGenerator() *GatewayGenerator {
+	return NewGatewayGeneratorWithFS(os.DirFS("."), templates.NewDiskWriter("."))
+}
+
+// NewGatewayGeneratorWithFS creates a gateway generator reading the project
+// from src and writing the route table to out
+func NewGatewayGeneratorWithFS(src fs.FS, out templates.Writer) *GatewayGenerator {
+	return &GatewayGenerator{
+		src: src,
+		out: out,
+	}
+}
+
+// GatewayResult lists the routes written by Generate and the RPCs left out
+type GatewayResult struct {
+	Path    string
+	Routes  []GatewayRoute
+	Skipped []string
+}
+
+// Generate writes web/gateway/routes.go
+func (g *GatewayGenerator) Generate() (*GatewayResult, error) {
+	files, err := proto.ParseDir(g.src, path.Join("api", "proto"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse proto files: %w", err)
+	}
+
+	services, routes, skipped, err := collectGatewayRoutes(files)
+	if err != nil {
+		return nil, err
+	}
+	result := &GatewayResult{Path: gatewayRoutesPath, Routes: routes, Skipped: skipped}
+
+	tmpl, err := template.New("gateway routes").Parse(gatewayRoutesTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse gateway routes template: %w", err)
+	}
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, struct {
+		Services []GatewayService
+		Routes   []GatewayRoute
+	}{services, result.Routes})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute gateway routes template: %w", err)
+	}
+	content, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format %s: %w", gatewayRoutesPath, err)
+	}
+
+	if err := g.out.MkdirAll(path.Dir(gatewayRoutesPath)); err != nil {
+		return nil, err
+	}
+	if err := g.out.WriteFile(gatewayRoutesPath, content); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", gatewayRoutesPath, err)
+	}
+	return result, nil
+}
+
+// collectGatewayRoutes maps every unary RPC of files to a route, in the order
+// Fiber must register them, and lists the RPCs that cannot be exposed
+func collectGatewayRoutes(files []*proto.File) (services []GatewayService, routes []GatewayRoute, skipped []string, err error) {
+	seen := make(map[string]string)
+	for _, file := range files {
+		goPackage := goPackagePath(file)
+		version := packageVersion(file.Package)
+		if goPackage == "" || version == "" {
+			for _, service := range file.Services {
+				skipped = append(skipped, fmt.Sprintf("%s: %s needs a versioned package and a go_package option", file.Path, service.Name))
+			}
+			continue
+		}
+
+		alias := protoAlias(path.Base(path.Dir(goPackage)), version)
+		for _, service := range file.Services {
+			client := strings.ToLower(service.Name[:1]) + service.Name[1:] + upperVersion(version)
+			services = append(services, GatewayService{Name: service.Name, Client: client, ProtoAlias: alias, GoPackage: goPackage})
+
+			for _, rpc := range service.RPCs {
+				if rpc.ClientStreaming || rpc.ServerStreaming {
+					skipped = append(skipped, fmt.Sprintf("%s.%s: streaming RPCs are not exposed", service.Name, rpc.Name))
+					continue
+				}
+
+				route, err := gatewayRoute(file, service, rpc, version)
+				if err != nil {
+					return nil, nil, nil, fmt.Errorf("%s.%s: %w", service.Name, rpc.Name, err)
+				}
+				route.Client = client
+
+				key := route.Method + " " + route.Path
+				if other, ok := seen[key]; ok {
+					return nil, nil, nil, fmt.Errorf("%s.%s and %s both map to %s", service.Name, rpc.Name, other, key)
+				}
+				seen[key] = service.Name + "." + rpc.Name
+				routes = append(routes, route)
+			}
+		}
+	}
+
+	// Fiber matches routes in order: /users/by-email must come before
+	// /users/:id or it would never be reached
+	sort.SliceStable(routes, func(i, j int) bool {
+		return strings.Count(routes[i].Path, ":") < strings.Count(routes[j].Path, ":")
+	})
+	return services, routes, skipped, nil
+}
+
+// gatewayRoutesTemplate renders web/gateway/routes.go
+const gatewayRoutesTemplate = `// Code generated by meower proto gateway. DO NOT EDIT.
+
+package gateway
+
+import (
+{{- range .Services}}
+	{{.ProtoAlias}} "{{.GoPackage}}"
+{{- end}}
+	"google.golang.org/grpc"
+)
+
+// routes returns the JSON route of every unary RPC
+func routes(conn grpc.ClientConnInterface) []Route {
+{{- range .Services}}
+	{{.Client}} := {{.ProtoAlias}}.New{{.Name}}Client(conn)
+{{- end}}
+
+	return []Route{
+{{- range .Routes}}
+		{Method: "{{.Method}}", Path: "{{.Path}}"{{if .Body}}, Body: "{{.Body}}"{{end}}, Call: Unary({{.Client}}.{{.RPC}})},
+{{- end}}
+	}
+}
+`
+
+// gatewayRoute returns the route of a unary rpc, from its google.api.http
+// option or from the naming convention
+func gatewayRoute(file *proto.File, service *proto.Service, rpc *proto.RPC, version string) (GatewayRoute, error) {
+	route := GatewayRoute{ServiceName: service.Name, RPC: rpc.Name, file: file, rpc: rpc}
+
+	if rule := rpc.Option("(google.api.http)"); rule != "" {
+		// Bindings after additional_bindings are alternatives; only the
+		// primary one is served
+		rule, _, _ = strings.Cut(rule, "additional_bindings")
+		for _, match := range httpRuleRegex.FindAllStringSubmatch(rule, -1) {
+			switch key, value := match[1], match[2]; key {
+			case "get", "post", "put", "patch", "delete":
+				route.Method = strings.ToUpper(key)
+				route.Path = value
+			case "body":
+				route.Body = value
+			}
+		}
+		if route.Method == "" {
+			return route, fmt.Errorf("google.api.http option has no get, post, put, patch or delete path")
+		}
+
+		var err error
+		if route.Path, err = fiberPath(route.Path); err != nil {
+			return route, err
+		}
+		return route, nil
+	}
+
+	resource := strings.TrimSuffix(service.Name, "Service")
+	collection := "/api/" + version + "/" + collectionPath(resource)
+	request := file.Message(rpc.Request)
+	hasID := request != nil && request.Field("id") != nil
+
+	switch {
+	case rpc.Name == "Create"+resource:
+		route.Method, route.Path, route.Body = "POST", collection, "*"
+	case rpc.Name == "Get"+resource && hasID:
+		route.Method, route.Path = "GET", collection+"/:id"
+	case rpc.Name == "Update"+resource && hasID:
+		route.Method, route.Path, route.Body = "PATCH", collection+"/:id", "*"
+	case rpc.Name == "Delete"+resource && hasID:
+		route.Method, route.Path = "DELETE", collection+"/:id"
+	case rpc.Name == "List"+resource, rpc.Name == "List"+resource+"s", rpc.Name == "Index"+resource:
+		route.Method, route.Path = "GET", collection
+	case strings.HasPrefix(rpc.Name, "Get"+resource+"By"):
+		route.Method, route.Path = "GET", collection+"/"+kebabCase(strings.TrimPrefix(rpc.Name, "Get"+resource))
+	case strings.HasPrefix(rpc.Name, "List"+resource+"sBy"):
+		route.Method, route.Path = "GET", collection+"/"+kebabCase(strings.TrimPrefix(rpc.Name, "List"+resource+"s"))
+	default:
+		route.Method, route.Path, route.Body = "POST", collection+"/"+kebabCase(rpc.Name), "*"
+	}
+	return route, nil
+}
+
+// fiberPath converts a google.api.http path template to a Fiber path:
+// /v1/meows/{id} becomes /v1/meows/:id
+func fiberPath(template string) (string, error) {
+	var err error
+	path := pathVariableRegex.ReplaceAllStringFunc(template, func(variable string) string {
+		name := strings.Trim(variable, "{}")
+		if strings.ContainsAny(name, "=.*") {
+			err = fmt.Errorf("path variable %s is not supported, only {field}", variable)
+		}
+		return ":" + name
+	})
+	return path, err
+}
+
+// goPackagePath returns the import path from a file's go_package option
+func goPackagePath(file *proto.File) string {
+	importPath, _, _ := strings.Cut(file.Option("go_package"), ";")
+	return importPath
+}
+
+// collectionPath returns the URL path segment of a resource, such as
+// blog-posts for BlogPost
+func collectionPath(resource string) string {
+	name := kebabCase(resource)
+	switch {
+	case strings.HasSuffix(name, "y"):
+		return name[:len(name)-1] + "ies"
+	case strings.HasSuffix(name, "s"), strings.HasSuffix(name, "sh"), strings.HasSuffix(name, "ch"):
+		return name + "es"
+	}
+	return name + "s"
+}
+
+// kebabCase converts PascalCase to kebab-case
+func kebabCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) && i > 0 {
+			b.WriteRune('-')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,229 @@
+package generators
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/AlyxPink/meower/internal/templates"
+	"github.com/AlyxPink/meower/internal/templates/templatetest"
+)
+
+// templateProtoFS returns the .proto files of the project template
+func templateProtoFS(t *testing.T) fstest.MapFS {
+	t.Helper()
+
+	fsys := fstest.MapFS{}
+	for _, path := range []string{
+		"api/proto/follow/v1/follow.proto",
+		"api/proto/meow/v1/meow.proto",
+		"api/proto/user/v1/user.proto",
+	} {
+		content, err := os.ReadFile(filepath.Join("..", "..", "cmd", "meower", "template", filepath.FromSlash(path)))
+		if err != nil {
+			t.Fatalf("failed to read template file: %v", err)
+		}
+		fsys[path] = &fstest.MapFile{Data: content}
+	}
+	return fsys
+}
+
+// TestGatewayGeneratorTemplate checks that the routes shipped with the project
+// template are the ones `meower proto gateway` generates for its protos
+func TestGatewayGeneratorTemplate(t *testing.T) {
+	out := templates.NewMemoryWriter()
+	if _, err := NewGatewayGeneratorWithFS(templateProtoFS(t), out).Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want, err := os.ReadFile(filepath.Join("..", "..", "cmd", "meower", "template", filepath.FromSlash(gatewayRoutesPath)))
+	if err != nil {
+		t.Fatalf("failed to read template file: %v", err)
+	}
+	if got := out.Files[gatewayRoutesPath]; !bytes.Equal(got, want) {
+		t.Errorf("template %s is out of date, regenerate it with `meower proto gateway`; generated:\n%s", gatewayRoutesPath, got)
+	}
+}
+
+// TestGatewayGeneratorGolden generates routes for annotated, conventional,
+// versioned and streaming RPCs and compares them against
+// testdata/golden/gateway. Run with -update to accept changes.
+func TestGatewayGeneratorGolden(t *testing.T) {
+	fsys := withFiles(templateProtoFS(t), map[string][]byte{
+		"api/proto/meow/v2/meow.proto": []byte(`syntax = "proto3";
+
+package meow.v2;
+
+import "google/api/annotations.proto";
+
+option go_package = "TEMPLATE_MODULE_PATH/api/proto/meow/v2";
+
+service MeowService {
+  rpc CreateMeow(CreateMeowRequest) returns (CreateMeowResponse) {
+    option (google.api.http) = {
+      post: "/api/v2/meows"
+      body: "meow"
+    };
+  }
+  rpc GetMeow(GetMeowRequest) returns (GetMeowResponse) {
+    option (google.api.http) = {
+      get: "/api/v2/meows/{id}"
+      additional_bindings {
+        get: "/api/v2/m/{id}"
+      }
+    };
+  }
+  rpc WatchMeows(WatchMeowsRequest) returns (stream Meow) {}
+}
+
+message Meow {
+  string id = 1;
+  string content = 2;
+}
+
+message CreateMeowRequest {
+  Meow meow = 1;
+}
+
+message CreateMeowResponse {
+  Meow meow = 1;
+}
+
+message GetMeowRequest {
+  string id = 1;
+}
+
+message GetMeowResponse {
+  Meow meow = 1;
+}
+
+message WatchMeowsRequest {}
+`),
+		"api/proto/blogpost/v1/blogpost.proto": []byte(`syntax = "proto3";
+
+package blogpost.v1;
+
+option go_package = "TEMPLATE_MODULE_PATH/api/proto/blogpost/v1";
+
+service BlogPostService {
+  rpc GetBlogPost(GetBlogPostRequest) returns (GetBlogPostResponse) {}
+  rpc UpdateBlogPost(UpdateBlogPostRequest) returns (UpdateBlogPostResponse) {}
+  rpc ListBlogPosts(ListBlogPostsRequest) returns (ListBlogPostsResponse) {}
+  rpc PublishBlogPost(PublishBlogPostRequest) returns (PublishBlogPostResponse) {}
+}
+
+message GetBlogPostRequest {
+  string id = 1;
+}
+
+message GetBlogPostResponse {}
+
+message UpdateBlogPostRequest {
+  string id = 1;
+  string title = 2;
+}
+
+message UpdateBlogPostResponse {}
+
+message ListBlogPostsRequest {}
+
+message ListBlogPostsResponse {}
+
+message PublishBlogPostRequest {
+  string id = 1;
+}
+
+message PublishBlogPostResponse {}
+`),
+	})
+
+	out := templates.NewMemoryWriter()
+	result, err := NewGatewayGeneratorWithFS(fsys, out).Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	templatetest.AssertGolden(t, filepath.Join("testdata", "golden", "gateway"), out.Files)
+
+	// WatchMeows streams in both the template's meow.v1 and meow.v2
+	if len(result.Skipped) != 2 || !strings.Contains(result.Skipped[0], "MeowService.WatchMeows") || !strings.Contains(result.Skipped[1], "MeowService.WatchMeows") {
+		t.Errorf("Skipped = %q, want MeowService.WatchMeows of v1 and v2 only", result.Skipped)
+	}
+}
+
+func TestGatewayGeneratorErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		rpcs    string
+		wantErr string
+	}{
+		{
+			name: "duplicate route",
+			rpcs: `rpc GetMeow(GetMeowRequest) returns (GetMeowResponse) {}
+  rpc FetchMeow(GetMeowRequest) returns (GetMeowResponse) {
+    option (google.api.http) = { get: "/api/v1/meows/{id}" };
+  }`,
+			wantErr: "MeowService.FetchMeow and MeowService.GetMeow both map to GET /api/v1/meows/:id",
+		},
+		{
+			name: "nested path variable",
+			rpcs: `rpc GetMeow(GetMeowRequest) returns (GetMeowResponse) {
+    option (google.api.http) = { get: "/api/v1/{name=meows/*}" };
+  }`,
+			wantErr: "path variable {name=meows/*} is not supported",
+		},
+		{
+			name: "missing method",
+			rpcs: `rpc GetMeow(GetMeowRequest) returns (GetMeowResponse) {
+    option (google.api.http) = { body: "*" };
+  }`,
+			wantErr: "google.api.http option has no get, post, put, patch or delete path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{
+				"api/proto/meow/v1/meow.proto": &fstest.MapFile{Data: []byte(`syntax = "proto3";
+
+package meow.v1;
+
+option go_package = "TEMPLATE_MODULE_PATH/api/proto/meow/v1";
+
+service MeowService {
+  ` + tt.rpcs + `
+}
+
+message GetMeowRequest {
+  string id = 1;
+}
+
+message GetMeowResponse {}
+`)},
+			}
+
+			_, err := NewGatewayGeneratorWithFS(fsys, templates.NewMemoryWriter()).Generate()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCollectionPath(t *testing.T) {
+	tests := map[string]string{
+		"Meow":     "meows",
+		"User":     "users",
+		"BlogPost": "blog-posts",
+		"Category": "categories",
+		"Address":  "addresses",
+		"Match":    "matches",
+	}
+	for resource, want := range tests {
+		if got := collectionPath(resource); got != want {
+			t.Errorf("collectionPath(%q) = %q, want %q", resource, got, want)
+		}
+	}
+}
@@ -1,8 +1,9 @@
 package generators
 
 import (
+	"bytes"
 	"fmt"
-	"os"
+	"go/format"
 	"path/filepath"
 	"strings"
 	"text/template"
@@ -14,6 +15,7 @@ import (
 // This generator creates a full gRPC service stack including:
 // - Protocol buffer service definitions (.proto files)
 // - Server-side handler implementations with TODO comments
+// - Handler tests calling every RPC over an in-memory connection
 // - Web client integration stubs
 // - Proper module path handling for generated code
 //
@@ -21,37 +23,131 @@ import (
 // code formatting and supports customizable method sets.
 type HandlerGenerator struct {
 	vars *templates.TemplateVars
+	out  templates.Writer
 }
 
-// NewHandlerGenerator creates a new handler generator
+// NewHandlerGenerator creates a new handler generator that writes to the current directory
 func NewHandlerGenerator(vars *templates.TemplateVars) *HandlerGenerator {
+	return NewHandlerGeneratorWithWriter(vars, templates.NewDiskWriter("."))
+}
+
+// NewHandlerGeneratorWithWriter creates a handler generator that writes to out
+func NewHandlerGeneratorWithWriter(vars *templates.TemplateVars, out templates.Writer) *HandlerGenerator {
 	return &HandlerGenerator{
 		vars: vars,
+		out:  out,
 	}
 }
 
-// GenerateProto generates the protocol buffer definition
-func (g *HandlerGenerator) GenerateProto(methods []string) error {
-	// Create proto directory
-	protoDir := filepath.Join("api", "proto", g.vars.ServiceNameLower, "v1")
-	if err := os.MkdirAll(protoDir, 0o755); err != nil {
-		return fmt.Errorf("failed to create proto directory: %w", err)
+// render executes a template and writes the result to path
+func (g *HandlerGenerator) render(kind, path, text string, data any) error {
+	tmpl, err := template.New(kind).Funcs(template.FuncMap{
+		"inc":        func(i int) int { return i + 1 },
+		"methodStub": newMethodStubData,
+	}).Parse(text)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s template: %w", kind, err)
+	}
+	if _, err := tmpl.Parse(methodStubTemplate); err != nil {
+		return fmt.Errorf("failed to parse method stub template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to execute %s template: %w", kind, err)
+	}
+
+	content := buf.Bytes()
+	if strings.HasSuffix(path, ".go") {
+		content, err = format.Source(content)
+		if err != nil {
+			return fmt.Errorf("failed to format %s: %w", path, err)
+		}
+	}
+
+	if err := g.out.WriteFile(path, content); err != nil {
+		return fmt.Errorf("failed to create %s file: %w", kind, err)
+	}
+	return nil
+}
+
+// handlerData is the data passed to every handler template
+type handlerData struct {
+	*templates.TemplateVars
+	Methods           []Method
+	ResourceName      string
+	ResourceNameLower string
+}
+
+// newHandlerData prepares template data for the given methods
+func (g *HandlerGenerator) newHandlerData(methods []Method) handlerData {
+	resourceName := strings.TrimSuffix(g.vars.ServiceName, "Service")
+	return handlerData{
+		TemplateVars:      g.vars,
+		Methods:           methods,
+		ResourceName:      resourceName,
+		ResourceNameLower: strings.ToLower(resourceName),
 	}
+}
+
+// ProtoAlias is the import name of the service's generated proto package,
+// such as userV1
+func (d handlerData) ProtoAlias() string {
+	return protoAlias(d.ServiceNameLower, d.APIVersion)
+}
+
+// HasUnary returns true if any method is a unary RPC
+func (d handlerData) HasUnary() bool {
+	return anyMethod(d.Methods, Method.Unary)
+}
+
+// HasStreams returns true if any method streams in either direction
+func (d handlerData) HasStreams() bool {
+	return anyMethod(d.Methods, func(m Method) bool { return !m.Unary() })
+}
+
+// HasClientStreams returns true if any method receives a stream of requests
+func (d handlerData) HasClientStreams() bool {
+	return anyMethod(d.Methods, Method.ClientStreaming)
+}
 
+// HasServerStreams returns true if any method sends a stream of responses
+func (d handlerData) HasServerStreams() bool {
+	return anyMethod(d.Methods, Method.ServerStreaming)
+}
+
+// BuildsResources returns true if any server stub returns a sample resource,
+// which needs timestamppb for its timestamps
+func (d handlerData) BuildsResources() bool {
+	return anyMethod(d.Methods, func(m Method) bool {
+		switch {
+		case m.Unary():
+			crud := m.CRUD()
+			return crud == "Create" || crud == "Get" || crud == "Update" || crud == "List"
+		case m.Stream == StreamServer:
+			return m.CRUD() == "List"
+		}
+		return false
+	})
+}
+
+// GenerateProto generates the protocol buffer definition
+func (g *HandlerGenerator) GenerateProto(methods []Method) error {
 	// Generate proto file
+	protoDir := filepath.Join("api", "proto", g.vars.ServiceNameLower, g.vars.APIVersion)
 	protoFile := filepath.Join(protoDir, g.vars.ServiceNameLower+".proto")
 
 	protoTemplate := `syntax = "proto3";
 
-package {{.ServiceNameLower}}.v1;
+package {{.ServiceNameLower}}.{{.APIVersion}};
 
 import "google/protobuf/timestamp.proto";
 
-option go_package = "{{.ModulePath}}/api/proto/{{.ServiceNameLower}}/v1";
+option go_package = "{{.ModulePath}}/api/proto/{{.ServiceNameLower}}/{{.APIVersion}}";
 
 service {{.ServiceName}} {
 {{- range .Methods}}
-  rpc {{.}}{{$.ResourceName}}({{.}}{{$.ResourceName}}Request) returns ({{.}}{{$.ResourceName}}Response) {}
+  rpc {{.RPCName $.ResourceName}}({{if .ClientStreaming}}stream {{end}}{{.RPCName $.ResourceName}}Request) returns ({{if .ServerStreaming}}stream {{end}}{{.RPCName $.ResourceName}}Response) {}
 {{- end}}
 }
 
@@ -64,26 +160,35 @@ message {{.ResourceName}} {
 
 {{- range .Methods}}
 
-message {{.}}{{$.ResourceName}}Request {
-{{- if eq . "Create"}}
+message {{.RPCName $.ResourceName}}Request {
+{{- if .Custom}}
+{{- range $i, $field := .Request}}
+  {{$field.ProtoType}} {{$field.Name}} = {{inc $i}};
+{{- end}}
+{{- else if eq .CRUD "Create"}}
   string name = 1;
-{{- else if eq . "Get"}}
+{{- else if eq .CRUD "Get"}}
   string id = 1;
-{{- else if eq . "Update"}}
+{{- else if eq .CRUD "Update"}}
   string id = 1;
   string name = 2;
-{{- else if eq . "Delete"}}
+{{- else if eq .CRUD "Delete"}}
   string id = 1;
-{{- else if eq . "List"}}
-  int32 limit = 1;
-  int32 offset = 2;
+{{- else if eq .CRUD "List"}}
+  int32 page_size = 1;
+  string page_token = 2;
 {{- end}}
 }
 
-message {{.}}{{$.ResourceName}}Response {
-{{- if eq . "List"}}
+message {{.RPCName $.ResourceName}}Response {
+{{- if .Custom}}
+{{- range $i, $field := .Response}}
+  {{$field.ProtoType}} {{$field.Name}} = {{inc $i}};
+{{- end}}
+{{- else if and (eq .CRUD "List") (not .ServerStreaming)}}
   repeated {{$.ResourceName}} {{$.ResourceNameLower}}s = 1;
-{{- else if eq . "Delete"}}
+  string next_page_token = 2;
+{{- else if eq .CRUD "Delete"}}
   bool success = 1;
 {{- else}}
   {{$.ResourceName}} {{$.ResourceNameLower}} = 1;
@@ -92,83 +197,76 @@ message {{.}}{{$.ResourceName}}Response {
 {{- end}}
 `
 
-	tmpl, err := template.New("proto").Parse(protoTemplate)
-	if err != nil {
-		return fmt.Errorf("failed to parse proto template: %w", err)
-	}
-
-	// Prepare template data
-	resourceName := strings.TrimSuffix(g.vars.ServiceName, "Service")
-	data := struct {
-		*templates.TemplateVars
-		Methods           []string
-		ResourceName      string
-		ResourceNameLower string
-	}{
-		TemplateVars:      g.vars,
-		Methods:           methods,
-		ResourceName:      resourceName,
-		ResourceNameLower: strings.ToLower(resourceName),
-	}
-
-	file, err := os.Create(protoFile)
-	if err != nil {
-		return fmt.Errorf("failed to create proto file: %w", err)
-	}
-	defer func() {
-		if err := file.Close(); err != nil {
-			fmt.Printf("Warning: failed to close file: %v\n", err)
-		}
-	}()
-
-	if err := tmpl.Execute(file, data); err != nil {
-		return fmt.Errorf("failed to execute proto template: %w", err)
-	}
-
-	return nil
+	return g.render("proto", protoFile, protoTemplate, g.newHandlerData(methods))
 }
 
 // GenerateServerHandler generates the server-side gRPC handler
-func (g *HandlerGenerator) GenerateServerHandler(methods []string) error {
-	// Create handler directory
-	handlerDir := filepath.Join("api", "server", "handlers")
-	if err := os.MkdirAll(handlerDir, 0o755); err != nil {
-		return fmt.Errorf("failed to create handler directory: %w", err)
-	}
-
+func (g *HandlerGenerator) GenerateServerHandler(methods []Method) error {
 	// Generate handler file
+	handlerDir := filepath.Join("api", "server", "handlers")
 	handlerFile := filepath.Join(handlerDir, g.vars.ServiceNameLower+".go")
 
 	handlerTemplate := `package handlers
 
 import (
+{{- if .HasUnary}}
 	"context"
-	"fmt"
+{{- end}}
+{{- if .HasClientStreams}}
+	"io"
+{{- end}}
 
 	"{{.ModulePath}}/api/db"
-	{{.ServiceNameLower}}V1 "{{.ModulePath}}/api/proto/{{.ServiceNameLower}}/v1"
-	"github.com/jackc/pgx/v5/pgxpool"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	{{.ProtoAlias}} "{{.ModulePath}}/api/proto/{{.ServiceNameLower}}/{{.APIVersion}}"
+{{- if .HasStreams}}
+	"google.golang.org/grpc"
+{{- end}}
+{{- if .BuildsResources}}
 	"google.golang.org/protobuf/types/known/timestamppb"
+{{- end}}
 )
 
 type {{.ServiceNameLower}}ServiceServer struct {
-	{{.ServiceNameLower}}V1.Unimplemented{{.ServiceName}}Server
-	db *pgxpool.Pool
+	{{.ProtoAlias}}.Unimplemented{{.ServiceName}}Server
+	db db.DBTX
 }
 
-func New{{.ServiceName}}Server(db *pgxpool.Pool) {{.ServiceNameLower}}V1.{{.ServiceName}}Server {
-	return &{{.ServiceNameLower}}ServiceServer{db: db}
+func New{{.ServiceName}}Server(dbtx db.DBTX) {{.ProtoAlias}}.{{.ServiceName}}Server {
+	return &{{.ServiceNameLower}}ServiceServer{db: dbtx}
 }
 
 {{- $resourceName := .ResourceName}}
 {{- $resourceNameLower := .ResourceNameLower}}
 {{- $serviceLower := .ServiceNameLower}}
 {{- range .Methods}}
+{{- $rpc := .RPCName $resourceName}}
+{{- $request := printf "%s.%sRequest" $.ProtoAlias $rpc}}
+{{- $response := printf "%s.%sResponse" $.ProtoAlias $rpc}}
+{{- if and (eq .Stream "server-stream") (eq .CRUD "List")}}
+
+func (s *{{$serviceLower}}ServiceServer) {{$rpc}}(req *{{$request}}, stream grpc.ServerStreamingServer[{{$response}}]) error {
+	// TODO: Implement streaming list logic, sending one response per {{$resourceNameLower}}
+	{{$resourceNameLower}}s := []*{{$.ProtoAlias}}.{{$resourceName}}{
+		{
+			Id:        "sample-1",
+			Name:      "Sample {{$resourceName}} 1",
+			CreatedAt: timestamppb.Now(),
+			UpdatedAt: timestamppb.Now(),
+		},
+	}
+
+	for _, {{$resourceNameLower}} := range {{$resourceNameLower}}s {
+		if err := stream.Send(&{{$response}}{ {{$resourceName}}: {{$resourceNameLower}} }); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+{{- else if and .Unary .CRUD}}
 
-func (s *{{$serviceLower}}ServiceServer) {{.}}{{$resourceName}}(ctx context.Context, req *{{$serviceLower}}V1.{{.}}{{$resourceName}}Request) (*{{$serviceLower}}V1.{{.}}{{$resourceName}}Response, error) {
-{{- if eq . "Create"}}
+func (s *{{$serviceLower}}ServiceServer) {{$rpc}}(ctx context.Context, req *{{$request}}) (*{{$response}}, error) {
+{{- if eq .CRUD "Create"}}
 	// TODO: Implement create logic
 	// Example:
 	// result, err := db.New(s.db).Create{{$resourceName}}(ctx, db.Create{{$resourceName}}Params{
@@ -178,15 +276,15 @@ func (s *{{$serviceLower}}ServiceServer) {{.}}{{$resourceName}}(ctx context.Cont
 	//     return nil, status.Errorf(codes.Internal, "failed to create {{$resourceNameLower}}: %v", err)
 	// }
 
-	return &{{$serviceLower}}V1.{{.}}{{$resourceName}}Response{
-		{{$resourceName}}: &{{$serviceLower}}V1.{{$resourceName}}{
+	return &{{$response}}{
+		{{$resourceName}}: &{{$.ProtoAlias}}.{{$resourceName}}{
 			Id:        "generated-id",
 			Name:      req.Name,
 			CreatedAt: timestamppb.Now(),
 			UpdatedAt: timestamppb.Now(),
 		},
 	}, nil
-{{- else if eq . "Get"}}
+{{- else if eq .CRUD "Get"}}
 	// TODO: Implement get logic
 	// Example:
 	// uuid, err := parseUUID(req.Id)
@@ -199,33 +297,52 @@ func (s *{{$serviceLower}}ServiceServer) {{.}}{{$resourceName}}(ctx context.Cont
 	//     return nil, status.Errorf(codes.NotFound, "{{$resourceNameLower}} not found: %v", err)
 	// }
 
-	return &{{$serviceLower}}V1.{{.}}{{$resourceName}}Response{
-		{{$resourceName}}: &{{$serviceLower}}V1.{{$resourceName}}{
+	return &{{$response}}{
+		{{$resourceName}}: &{{$.ProtoAlias}}.{{$resourceName}}{
 			Id:        req.Id,
 			Name:      "Sample {{$resourceName}}",
 			CreatedAt: timestamppb.Now(),
 			UpdatedAt: timestamppb.Now(),
 		},
 	}, nil
-{{- else if eq . "Update"}}
+{{- else if eq .CRUD "Update"}}
 	// TODO: Implement update logic
-	return &{{$serviceLower}}V1.{{.}}{{$resourceName}}Response{
-		{{$resourceName}}: &{{$serviceLower}}V1.{{$resourceName}}{
+	return &{{$response}}{
+		{{$resourceName}}: &{{$.ProtoAlias}}.{{$resourceName}}{
 			Id:        req.Id,
 			Name:      req.Name,
 			CreatedAt: timestamppb.Now(),
 			UpdatedAt: timestamppb.Now(),
 		},
 	}, nil
-{{- else if eq . "Delete"}}
+{{- else if eq .CRUD "Delete"}}
 	// TODO: Implement delete logic
-	return &{{$serviceLower}}V1.{{.}}{{$resourceName}}Response{
+	return &{{$response}}{
 		Success: true,
 	}, nil
-{{- else if eq . "List"}}
-	// TODO: Implement list logic
-	return &{{$serviceLower}}V1.{{.}}{{$resourceName}}Response{
-		{{$resourceName}}s: []*{{$serviceLower}}V1.{{$resourceName}}{
+{{- else if eq .CRUD "List"}}
+	// TODO: Implement list logic, one page at a time with the pagination package
+	// Example:
+	// cursor, err := pagination.Decode(req.PageToken)
+	// if err != nil {
+	//     return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	// }
+	// size := pagination.Size(req.PageSize)
+	//
+	// rows, err := db.New(s.db).List{{$resourceName}}s(ctx, db.List{{$resourceName}}sParams{
+	//     BeforeCreatedAt: cursor.CreatedAt,
+	//     BeforeID:        cursor.ID,
+	//     PageSize:        size + 1,
+	// })
+	// if err != nil {
+	//     return nil, status.Errorf(codes.Internal, "failed to list {{$resourceNameLower}}s: %v", err)
+	// }
+	// rows, next := pagination.Page(rows, size, func(row db.{{$resourceName}}) pagination.Cursor {
+	//     return pagination.Cursor{CreatedAt: row.CreatedAt, ID: row.ID}
+	// })
+
+	return &{{$response}}{
+		{{$resourceName}}s: []*{{$.ProtoAlias}}.{{$resourceName}}{
 			{
 				Id:        "sample-1",
 				Name:      "Sample {{$resourceName}} 1",
@@ -236,103 +353,327 @@ func (s *{{$serviceLower}}ServiceServer) {{.}}{{$resourceName}}(ctx context.Cont
 	}, nil
 {{- end}}
 }
+{{- else}}
+{{- template "method stub" methodStub (printf "%sServiceServer" $serviceLower) $.ProtoAlias $rpc .}}
+{{- end}}
 {{- end}}
 `
 
-	tmpl, err := template.New("handler").Parse(handlerTemplate)
-	if err != nil {
-		return fmt.Errorf("failed to parse handler template: %w", err)
-	}
+	return g.render("handler", handlerFile, handlerTemplate, g.newHandlerData(methods))
+}
 
-	// Prepare template data
-	resourceName := strings.TrimSuffix(g.vars.ServiceName, "Service")
-	data := struct {
-		*templates.TemplateVars
-		Methods           []string
-		ResourceName      string
-		ResourceNameLower string
-	}{
-		TemplateVars:      g.vars,
-		Methods:           methods,
-		ResourceName:      resourceName,
-		ResourceNameLower: strings.ToLower(resourceName),
-	}
+// GenerateServerTest generates a test that serves the handler on an in-memory
+// bufconn listener and calls every RPC through a real gRPC client. It relies
+// on the startServer and fakeDB helpers in the template's handlers_test.go.
+func (g *HandlerGenerator) GenerateServerTest(methods []Method) error {
+	testDir := filepath.Join("api", "server", "handlers")
+	testFile := filepath.Join(testDir, g.vars.ServiceNameLower+"_test.go")
 
-	file, err := os.Create(handlerFile)
-	if err != nil {
-		return fmt.Errorf("failed to create handler file: %w", err)
-	}
-	defer func() {
-		if err := file.Close(); err != nil {
-			fmt.Printf("Warning: failed to close file: %v\n", err)
+	testTemplate := `package handlers
+
+import (
+	"context"
+{{- if .HasServerStreams}}
+	"io"
+{{- end}}
+	"testing"
+
+	{{.ProtoAlias}} "{{.ModulePath}}/api/proto/{{.ServiceNameLower}}/{{.APIVersion}}"
+	"google.golang.org/grpc"
+)
+
+func Test{{.ServiceName}}(t *testing.T) {
+	conn := startServer(t, func(g *grpc.Server) {
+		{{.ProtoAlias}}.Register{{.ServiceName}}Server(g, New{{.ServiceName}}Server(&fakeDB{}))
+	})
+	client := {{.ProtoAlias}}.New{{.ServiceName}}Client(conn)
+	ctx := context.Background()
+
+{{- $resourceName := .ResourceName}}
+{{- $serviceLower := .ServiceNameLower}}
+{{- range .Methods}}
+{{- $rpc := .RPCName $resourceName}}
+{{- $request := printf "%s.%sRequest" $.ProtoAlias $rpc}}
+
+	t.Run("{{$rpc}}", func(t *testing.T) {
+{{- if eq .Stream "server-stream"}}
+		stream, err := client.{{$rpc}}(ctx, &{{$request}}{})
+		if err != nil {
+			t.Fatalf("{{$rpc}}() error = %v", err)
 		}
-	}()
 
-	if err := tmpl.Execute(file, data); err != nil {
-		return fmt.Errorf("failed to execute handler template: %w", err)
-	}
+		var received int
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("{{$rpc}}() Recv error = %v", err)
+			}
+{{- if eq .CRUD "List"}}
+			if resp.Get{{$resourceName}}() == nil {
+				t.Errorf("{{$rpc}}() streamed a response without a {{$resourceName}}")
+			}
+{{- else}}
+			_ = resp
+{{- end}}
+			received++
+		}
+		if received == 0 {
+			t.Error("{{$rpc}}() streamed no responses")
+		}
+{{- else if eq .Stream "client-stream"}}
+		stream, err := client.{{$rpc}}(ctx)
+		if err != nil {
+			t.Fatalf("{{$rpc}}() error = %v", err)
+		}
+		if err := stream.Send(&{{$request}}{}); err != nil {
+			t.Fatalf("{{$rpc}}() Send error = %v", err)
+		}
 
-	return nil
+		resp, err := stream.CloseAndRecv()
+		if err != nil {
+			t.Fatalf("{{$rpc}}() CloseAndRecv error = %v", err)
+		}
+		if resp == nil {
+			t.Error("{{$rpc}}() returned a nil response")
+		}
+{{- else if eq .Stream "bidi"}}
+		stream, err := client.{{$rpc}}(ctx)
+		if err != nil {
+			t.Fatalf("{{$rpc}}() error = %v", err)
+		}
+		if err := stream.Send(&{{$request}}{}); err != nil {
+			t.Fatalf("{{$rpc}}() Send error = %v", err)
+		}
+		if err := stream.CloseSend(); err != nil {
+			t.Fatalf("{{$rpc}}() CloseSend error = %v", err)
+		}
+
+		var received int
+		for {
+			_, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("{{$rpc}}() Recv error = %v", err)
+			}
+			received++
+		}
+		if received != 1 {
+			t.Errorf("{{$rpc}}() received %d responses, want 1", received)
+		}
+{{- else if eq .CRUD "Create"}}
+		resp, err := client.{{$rpc}}(ctx, &{{$request}}{Name: "Test {{$resourceName}}"})
+		if err != nil {
+			t.Fatalf("{{$rpc}}() error = %v", err)
+		}
+		if resp.Get{{$resourceName}}().GetName() != "Test {{$resourceName}}" {
+			t.Errorf("{{$rpc}}() name = %q, want %q", resp.Get{{$resourceName}}().GetName(), "Test {{$resourceName}}")
+		}
+		if resp.Get{{$resourceName}}().GetId() == "" {
+			t.Error("{{$rpc}}() returned an empty id")
+		}
+{{- else if eq .CRUD "Get"}}
+		resp, err := client.{{$rpc}}(ctx, &{{$request}}{Id: "test-id"})
+		if err != nil {
+			t.Fatalf("{{$rpc}}() error = %v", err)
+		}
+		if resp.Get{{$resourceName}}().GetId() != "test-id" {
+			t.Errorf("{{$rpc}}() id = %q, want %q", resp.Get{{$resourceName}}().GetId(), "test-id")
+		}
+{{- else if eq .CRUD "Update"}}
+		resp, err := client.{{$rpc}}(ctx, &{{$request}}{Id: "test-id", Name: "Updated {{$resourceName}}"})
+		if err != nil {
+			t.Fatalf("{{$rpc}}() error = %v", err)
+		}
+		if resp.Get{{$resourceName}}().GetId() != "test-id" {
+			t.Errorf("{{$rpc}}() id = %q, want %q", resp.Get{{$resourceName}}().GetId(), "test-id")
+		}
+		if resp.Get{{$resourceName}}().GetName() != "Updated {{$resourceName}}" {
+			t.Errorf("{{$rpc}}() name = %q, want %q", resp.Get{{$resourceName}}().GetName(), "Updated {{$resourceName}}")
+		}
+{{- else if eq .CRUD "Delete"}}
+		resp, err := client.{{$rpc}}(ctx, &{{$request}}{Id: "test-id"})
+		if err != nil {
+			t.Fatalf("{{$rpc}}() error = %v", err)
+		}
+		if !resp.GetSuccess() {
+			t.Error("{{$rpc}}() success = false")
+		}
+{{- else if eq .CRUD "List"}}
+		resp, err := client.{{$rpc}}(ctx, &{{$request}}{PageSize: 10})
+		if err != nil {
+			t.Fatalf("{{$rpc}}() error = %v", err)
+		}
+		if len(resp.Get{{$resourceName}}s()) == 0 {
+			t.Error("{{$rpc}}() returned no {{$resourceName}}s")
+		}
+{{- else}}
+		resp, err := client.{{$rpc}}(ctx, &{{$request}}{})
+		if err != nil {
+			t.Fatalf("{{$rpc}}() error = %v", err)
+		}
+		if resp == nil {
+			t.Error("{{$rpc}}() returned a nil response")
+		}
+{{- end}}
+	})
+{{- end}}
+}
+`
+
+	return g.render("handler test", testFile, testTemplate, g.newHandlerData(methods))
 }
 
-// GenerateWebHandler generates the web-side handler (stub for now)
-func (g *HandlerGenerator) GenerateWebHandler(methods []string) error {
-	// Create web handler file (optional, could be a simple client wrapper)
+// GenerateWebHandler generates the web-side client helpers for the service.
+// Unary RPCs are thin wrappers; streaming RPCs are exposed as callback and
+// channel based helpers so Fiber handlers don't deal with grpc stream types.
+func (g *HandlerGenerator) GenerateWebHandler(methods []Method) error {
 	handlerDir := filepath.Join("web", "handlers")
 	handlerFile := filepath.Join(handlerDir, g.vars.ServiceNameLower+".go")
 
 	webHandlerTemplate := `package handlers
 
 import (
-	"github.com/gofiber/fiber/v2"
-	{{.ServiceNameLower}}V1 "{{.ModulePath}}/api/proto/{{.ServiceNameLower}}/v1"
+	"context"
+{{- if .HasServerStreams}}
+	"errors"
+	"io"
+{{- end}}
+
+	{{.ProtoAlias}} "{{.ModulePath}}/api/proto/{{.ServiceNameLower}}/{{.APIVersion}}"
 )
 
 type {{.ServiceName}} struct {
 	*App
+	client {{.ProtoAlias}}.{{.ServiceName}}Client
 }
 
-// TODO: Implement web handlers that call the gRPC service
-// Example:
-// func (h *{{.ServiceName}}) List{{.ResourceName}}(c *fiber.Ctx) error {
-//     resp, err := h.API.{{.ServiceName}}.List{{.ResourceName}}(c.Context(), &{{.ServiceNameLower}}V1.List{{.ResourceName}}Request{})
-//     if err != nil {
-//         return err
-//     }
-//
-//     return c.JSON(resp.{{.ResourceName}}s)
-// }
-`
+func New{{.ServiceName}}(app *App) *{{.ServiceName}} {
+	return &{{.ServiceName}}{
+		App:    app,
+		client: {{.ProtoAlias}}.New{{.ServiceName}}Client(app.API.Conn()),
+	}
+}
+
+// TODO: Implement Fiber handlers that call the helpers below with
+// c.UserContext(), which forwards the request ID to the API logs, then
+// register them in routing.RegisterRoutes with handlers.New{{.ServiceName}}(app).
 
-	tmpl, err := template.New("webhandler").Parse(webHandlerTemplate)
+{{- $resourceName := .ResourceName}}
+{{- $serviceLower := .ServiceNameLower}}
+{{- range .Methods}}
+{{- $rpc := .RPCName $resourceName}}
+{{- $request := printf "%s.%sRequest" $.ProtoAlias $rpc}}
+{{- $response := printf "%s.%sResponse" $.ProtoAlias $rpc}}
+{{- if eq .Stream "server-stream"}}
+
+// {{$rpc}} calls fn for every response streamed by the API until the stream
+// ends, ctx is cancelled or fn returns an error.
+func (h *{{$.ServiceName}}) {{$rpc}}(ctx context.Context, req *{{$request}}, fn func(*{{$response}}) error) error {
+	stream, err := h.client.{{$rpc}}(ctx, req)
 	if err != nil {
-		return fmt.Errorf("failed to parse web handler template: %w", err)
+		return err
 	}
 
-	resourceName := strings.TrimSuffix(g.vars.ServiceName, "Service")
-	data := struct {
-		*templates.TemplateVars
-		ResourceName string
-	}{
-		TemplateVars: g.vars,
-		ResourceName: resourceName,
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(resp); err != nil {
+			return err
+		}
+	}
+}
+{{- else if eq .Stream "client-stream"}}
+
+// {{$rpc}} streams every request to the API and returns its single response.
+func (h *{{$.ServiceName}}) {{$rpc}}(ctx context.Context, reqs []*{{$request}}) (*{{$response}}, error) {
+	stream, err := h.client.{{$rpc}}(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, req := range reqs {
+		if err := stream.Send(req); err != nil {
+			return nil, err
+		}
 	}
 
-	file, err := os.Create(handlerFile)
+	return stream.CloseAndRecv()
+}
+{{- else if eq .Stream "bidi"}}
+
+// {{$rpc}} sends requests from reqs while calling fn for every response.
+// Close reqs to end the stream; cancel ctx to abort it. The stream is
+// cancelled when {{$rpc}} returns, so the sending goroutine never outlives it.
+func (h *{{$.ServiceName}}) {{$rpc}}(ctx context.Context, reqs <-chan *{{$request}}, fn func(*{{$response}}) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := h.client.{{$rpc}}(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to create web handler file: %w", err)
+		return err
 	}
-	defer func() {
-		if err := file.Close(); err != nil {
-			fmt.Printf("Warning: failed to close file: %v\n", err)
+
+	sendErr := make(chan error, 1)
+	go func() {
+		defer stream.CloseSend()
+		for {
+			select {
+			case <-stream.Context().Done():
+				// Recv reports why the stream ended
+				sendErr <- nil
+				return
+			case req, ok := <-reqs:
+				if !ok {
+					sendErr <- nil
+					return
+				}
+				if err := stream.Send(req); err != nil {
+					// Send returns io.EOF once the API ends the stream; Recv
+					// then reports the actual status
+					if errors.Is(err, io.EOF) {
+						err = nil
+					}
+					sendErr <- err
+					return
+				}
+			}
 		}
 	}()
 
-	if err := tmpl.Execute(file, data); err != nil {
-		return fmt.Errorf("failed to execute web handler template: %w", err)
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return <-sendErr
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(resp); err != nil {
+			return err
+		}
 	}
+}
+{{- else}}
 
-	return nil
+// {{$rpc}} calls the {{$rpc}} RPC.
+func (h *{{$.ServiceName}}) {{$rpc}}(ctx context.Context, req *{{$request}}) (*{{$response}}, error) {
+	return h.client.{{$rpc}}(ctx, req)
+}
+{{- end}}
+{{- end}}
+`
+
+	return g.render("web handler", handlerFile, webHandlerTemplate, g.newHandlerData(methods))
 }
 
 // UpdateRoutes updates the route registration (stub for now)
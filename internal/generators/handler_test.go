@@ -0,0 +1,224 @@
+package generators
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AlyxPink/meower/internal/templates"
+	"github.com/AlyxPink/meower/internal/templates/templatetest"
+)
+
+// TestHandlerGeneratorGolden renders `meower create handler` into memory and
+// compares the result against testdata/golden/handler. Run with -update to
+// accept changes.
+func TestHandlerGeneratorGolden(t *testing.T) {
+	tests := []struct {
+		name        string
+		serviceName string
+		methods     []string
+	}{
+		{
+			name:        "default methods",
+			serviceName: "PostService",
+			methods:     []string{"Create", "Get", "Update", "Delete", "List"},
+		},
+		{
+			name:        "subset of methods",
+			serviceName: "CommentService",
+			methods:     []string{"Create", "List"},
+		},
+		{
+			name:        "non-CRUD methods",
+			serviceName: "AuthService",
+			methods:     []string{"Login", "Logout"},
+		},
+		{
+			name:        "streaming methods",
+			serviceName: "TimelineService",
+			methods:     []string{"Get", "List:server-stream", "Upload:client-stream", "Chat:bidi"},
+		},
+		{
+			name:        "custom methods",
+			serviceName: "MeowService",
+			methods: []string{
+				"Create",
+				"Publish(id:string)->(meow:Meow)",
+				"Search(query:string,tags:[]string)->(meows:[]Meow,next_page_token:string)",
+				"Watch(since:timestamp)->(meow:Meow):server-stream",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vars := templates.NewTemplateVars()
+			if err := vars.SetService(tt.serviceName); err != nil {
+				t.Fatalf("failed to set service variables: %v", err)
+			}
+			vars.ModulePath = "github.com/test/test-project"
+
+			methods, err := ParseMethods(tt.methods, strings.TrimSuffix(tt.serviceName, "Service"))
+			if err != nil {
+				t.Fatalf("ParseMethods() error = %v", err)
+			}
+
+			out := templates.NewMemoryWriter()
+			generator := NewHandlerGeneratorWithWriter(vars, out)
+
+			if err := generator.GenerateProto(methods); err != nil {
+				t.Fatalf("GenerateProto() error = %v", err)
+			}
+			if err := generator.GenerateServerHandler(methods); err != nil {
+				t.Fatalf("GenerateServerHandler() error = %v", err)
+			}
+			if err := generator.GenerateServerTest(methods); err != nil {
+				t.Fatalf("GenerateServerTest() error = %v", err)
+			}
+			if err := generator.GenerateWebHandler(methods); err != nil {
+				t.Fatalf("GenerateWebHandler() error = %v", err)
+			}
+
+			templatetest.AssertGolden(t, filepath.Join("testdata", "golden", "handler", tt.serviceName), out.Files)
+		})
+	}
+}
+
+func TestParseMethods(t *testing.T) {
+	tests := []struct {
+		name     string
+		specs    []string
+		expected []Method
+		wantErr  bool
+	}{
+		{
+			name:     "unary methods",
+			specs:    []string{"Create", " Get "},
+			expected: []Method{{Name: "Create"}, {Name: "Get"}},
+		},
+		{
+			name:  "stream kinds",
+			specs: []string{"List:server-stream", "Upload:client-stream", "Chat:bidi"},
+			expected: []Method{
+				{Name: "List", Stream: StreamServer},
+				{Name: "Upload", Stream: StreamClient},
+				{Name: "Chat", Stream: StreamBidi},
+			},
+		},
+		{
+			name:  "custom method",
+			specs: []string{"Publish(id:string)->(meow:Meow)"},
+			expected: []Method{{
+				Name:     "Publish",
+				Custom:   true,
+				Request:  []Field{{Name: "id", Type: "string"}},
+				Response: []Field{{Name: "meow", Type: "Meow"}},
+			}},
+		},
+		{
+			name:  "custom method split on commas with stream kind",
+			specs: []string{"Search(query:string", "limit:int32)->(meows:[]Meow):server-stream", "Get"},
+			expected: []Method{
+				{
+					Name:     "Search",
+					Stream:   StreamServer,
+					Custom:   true,
+					Request:  []Field{{Name: "query", Type: "string"}, {Name: "limit", Type: "int32"}},
+					Response: []Field{{Name: "meows", Type: "Meow", Repeated: true}},
+				},
+				{Name: "Get"},
+			},
+		},
+		{
+			name:     "custom method without fields",
+			specs:    []string{"Ping()->()"},
+			expected: []Method{{Name: "Ping", Custom: true}},
+		},
+		{
+			name:    "custom method with unknown type",
+			specs:   []string{"Publish(id:uuid)->()"},
+			wantErr: true,
+		},
+		{
+			name:    "custom method with invalid field name",
+			specs:   []string{"Publish(ID:string)->()"},
+			wantErr: true,
+		},
+		{
+			name:    "custom method with duplicate field",
+			specs:   []string{"Publish(id:string,id:int64)->()"},
+			wantErr: true,
+		},
+		{
+			name:    "custom method without response",
+			specs:   []string{"Publish(id:string)"},
+			wantErr: true,
+		},
+		{
+			name:    "custom method named after the resource",
+			specs:   []string{"Meow()->()"},
+			wantErr: true,
+		},
+		{
+			name:    "custom method clashing with CRUD method",
+			specs:   []string{"Create", "CreateMeow()->()"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown stream kind",
+			specs:   []string{"List:stream"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid method name",
+			specs:   []string{"list"},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate method",
+			specs:   []string{"List", "List:server-stream"},
+			wantErr: true,
+		},
+		{
+			name:    "no methods",
+			specs:   nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			methods, err := ParseMethods(tt.specs, "Meow")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMethods() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(methods, tt.expected) {
+				t.Errorf("ParseMethods() = %+v, want %+v", methods, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMethod_Streaming(t *testing.T) {
+	tests := []struct {
+		stream          StreamKind
+		clientStreaming bool
+		serverStreaming bool
+	}{
+		{StreamNone, false, false},
+		{StreamServer, false, true},
+		{StreamClient, true, false},
+		{StreamBidi, true, true},
+	}
+
+	for _, tt := range tests {
+		method := Method{Name: "List", Stream: tt.stream}
+		if method.ClientStreaming() != tt.clientStreaming {
+			t.Errorf("%q: ClientStreaming() = %v, want %v", tt.stream, method.ClientStreaming(), tt.clientStreaming)
+		}
+		if method.ServerStreaming() != tt.serverStreaming {
+			t.Errorf("%q: ServerStreaming() = %v, want %v", tt.stream, method.ServerStreaming(), tt.serverStreaming)
+		}
+	}
+}
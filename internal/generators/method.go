@@ -0,0 +1,270 @@
+package generators
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/AlyxPink/meower/internal/validation"
+)
+
+// StreamKind describes whether an RPC streams requests, responses or both
+type StreamKind string
+
+const (
+	// StreamNone is a unary RPC
+	StreamNone StreamKind = ""
+	// StreamServer streams responses: rpc X(Req) returns (stream Resp)
+	StreamServer StreamKind = "server-stream"
+	// StreamClient streams requests: rpc X(stream Req) returns (Resp)
+	StreamClient StreamKind = "client-stream"
+	// StreamBidi streams both ways: rpc X(stream Req) returns (stream Resp)
+	StreamBidi StreamKind = "bidi"
+)
+
+// crudMethods are the method names with built-in request and response shapes
+var crudMethods = []string{"Create", "Get", "Update", "Delete", "List"}
+
+// customMethodRegex matches custom RPC specs such as
+// "Publish(id:string)->(meow:Meow)" with an optional ":kind" suffix
+var customMethodRegex = regexp.MustCompile(`^(\w*)\s*\((.*)\)\s*->\s*\((.*)\)\s*(?::\s*(\S*))?$`)
+
+// Field is a field of a custom RPC request or response message
+type Field struct {
+	Name     string
+	Type     string
+	Repeated bool
+}
+
+// ProtoType returns the field's type as written in a .proto file
+func (f Field) ProtoType() string {
+	typ := f.Type
+	if typ == "timestamp" {
+		typ = "google.protobuf.Timestamp"
+	}
+	if f.Repeated {
+		return "repeated " + typ
+	}
+	return typ
+}
+
+// Method is a single RPC to generate, parsed from a --methods spec such as
+// "Create", "List:server-stream" or "Publish(id:string)->(meow:Meow)".
+//
+// CRUD methods are named <Method><Resource> and get built-in message shapes.
+// Custom methods keep their name as is and use the fields they declare.
+type Method struct {
+	Name     string
+	Stream   StreamKind
+	Custom   bool
+	Request  []Field
+	Response []Field
+}
+
+// RPCName returns the name of the generated RPC for the given resource
+func (m Method) RPCName(resourceName string) string {
+	if m.Custom {
+		return m.Name
+	}
+	return m.Name + resourceName
+}
+
+// CRUD returns the method name if it has built-in CRUD message shapes, or ""
+func (m Method) CRUD() string {
+	if !m.Custom && slices.Contains(crudMethods, m.Name) {
+		return m.Name
+	}
+	return ""
+}
+
+// ClientStreaming returns true if the client sends a stream of requests
+func (m Method) ClientStreaming() bool {
+	return m.Stream == StreamClient || m.Stream == StreamBidi
+}
+
+// ServerStreaming returns true if the server sends a stream of responses
+func (m Method) ServerStreaming() bool {
+	return m.Stream == StreamServer || m.Stream == StreamBidi
+}
+
+// Unary returns true if neither side streams
+func (m Method) Unary() bool {
+	return m.Stream == StreamNone
+}
+
+// ParseMethod parses a method spec of the form Name, Name:kind or
+// Name(field:type,...)->(field:type,...), optionally followed by :kind, where
+// kind is server-stream, client-stream or bidi. Field types are protobuf
+// scalars, timestamp or the service's resource message, prefixed with [] for
+// repeated fields.
+func ParseMethod(spec, resourceName string) (Method, error) {
+	validator := validation.NewValidator()
+	spec = strings.TrimSpace(spec)
+
+	var method Method
+	if strings.Contains(spec, "(") {
+		match := customMethodRegex.FindStringSubmatch(spec)
+		if match == nil {
+			return Method{}, validation.ValidationError{
+				Field:   "method",
+				Value:   spec,
+				Rule:    "format",
+				Message: "custom methods must look like Name(field:type,...)->(field:type,...)",
+			}
+		}
+
+		method = Method{Name: match[1], Stream: StreamKind(match[4]), Custom: true}
+
+		var err error
+		if method.Request, err = ParseFields(match[2], resourceName); err != nil {
+			return Method{}, fmt.Errorf("%s request: %w", method.Name, err)
+		}
+		if method.Response, err = ParseFields(match[3], resourceName); err != nil {
+			return Method{}, fmt.Errorf("%s response: %w", method.Name, err)
+		}
+	} else {
+		name, kind, _ := strings.Cut(spec, ":")
+		method = Method{
+			Name:   strings.TrimSpace(name),
+			Stream: StreamKind(strings.TrimSpace(kind)),
+		}
+	}
+
+	if err := validator.Service.ValidateMethodName(method.Name); err != nil {
+		return Method{}, err
+	}
+	if err := validator.Service.ValidateStreamKind(string(method.Stream)); err != nil {
+		return Method{}, err
+	}
+	if method.Custom && method.Name == resourceName {
+		return Method{}, validation.ValidationError{
+			Field:   "method name",
+			Value:   method.Name,
+			Rule:    "reserved",
+			Message: fmt.Sprintf("'%s' is the resource message name, please choose another name", method.Name),
+		}
+	}
+
+	return method, nil
+}
+
+// ParseFields parses a comma-separated list of name:type fields. Types may be
+// protobuf scalars, timestamp or one of messageTypes.
+func ParseFields(list string, messageTypes ...string) ([]Field, error) {
+	validator := validation.NewValidator()
+
+	var fields []Field
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(list, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, typ, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, validation.ValidationError{
+				Field:   "field",
+				Value:   part,
+				Rule:    "format",
+				Message: "fields must be written as name:type",
+			}
+		}
+
+		field := Field{Name: strings.TrimSpace(name), Type: strings.TrimSpace(typ)}
+		if rest, repeated := strings.CutPrefix(field.Type, "[]"); repeated {
+			field.Type = rest
+			field.Repeated = true
+		}
+
+		if err := validator.Service.ValidateFieldName(field.Name); err != nil {
+			return nil, err
+		}
+		if err := validator.Service.ValidateFieldType(field.Type, messageTypes...); err != nil {
+			return nil, err
+		}
+		if seen[field.Name] {
+			return nil, fmt.Errorf("field %s is declared more than once", field.Name)
+		}
+		seen[field.Name] = true
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// ParseMethods parses every method spec, rejecting duplicate RPC names.
+// Specs may arrive split on the commas inside a custom method's field list
+// (cobra splits --methods on commas), so those pieces are joined back first.
+func ParseMethods(specs []string, resourceName string) ([]Method, error) {
+	specs = joinSplitSpecs(specs)
+	if len(specs) == 0 {
+		return nil, validation.ValidationError{
+			Field:   "methods",
+			Rule:    "required",
+			Message: "at least one method must be specified",
+		}
+	}
+
+	var methods []Method
+	var errs validation.MultiError
+	seen := make(map[string]bool)
+
+	for _, spec := range specs {
+		method, err := ParseMethod(spec, resourceName)
+		if err != nil {
+			errs.Errors = append(errs.Errors, err)
+			continue
+		}
+		rpc := method.RPCName(resourceName)
+		if seen[rpc] {
+			errs.Errors = append(errs.Errors, fmt.Errorf("method %s is specified more than once", rpc))
+			continue
+		}
+		seen[rpc] = true
+		methods = append(methods, method)
+	}
+
+	if errs.HasErrors() {
+		return nil, errs
+	}
+	return methods, nil
+}
+
+// joinSplitSpecs rejoins specs whose parentheses were split apart on commas
+func joinSplitSpecs(specs []string) []string {
+	var joined []string
+	var current string
+	depth := 0
+
+	for _, spec := range specs {
+		if depth > 0 {
+			current += "," + spec
+		} else {
+			current = spec
+		}
+		depth += strings.Count(spec, "(") - strings.Count(spec, ")")
+		if depth <= 0 {
+			joined = append(joined, current)
+			depth = 0
+		}
+	}
+	if depth > 0 {
+		joined = append(joined, current)
+	}
+
+	return joined
+}
+
+// anyMethod reports whether any method matches the predicate
+func anyMethod(methods []Method, match func(Method) bool) bool {
+	for _, method := range methods {
+		if match(method) {
+			return true
+		}
+	}
+	return false
+}
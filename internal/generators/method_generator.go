@@ -0,0 +1,644 @@
+package generators
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/AlyxPink/meower/internal/proto"
+	"github.com/AlyxPink/meower/internal/templates"
+	"github.com/AlyxPink/meower/internal/validation"
+)
+
+// methodStubTemplate renders a server method with no built-in logic. It is
+// shared by `create handler` and `create method` so both emit the same stubs.
+const methodStubTemplate = `{{define "method stub"}}
+{{- $request := printf "%s.%sRequest" .Alias .RPC}}
+{{- $response := printf "%s.%sResponse" .Alias .RPC}}
+{{- if eq .Stream "server-stream"}}
+
+func (s *{{.Receiver}}) {{.RPC}}(req *{{$request}}, stream grpc.ServerStreamingServer[{{$response}}]) error {
+	// TODO: Implement streaming logic, calling stream.Send for every response.
+	// Long-lived streams should return when stream.Context() is done.
+	return stream.Send(&{{$response}}{})
+}
+{{- else if eq .Stream "client-stream"}}
+
+func (s *{{.Receiver}}) {{.RPC}}(stream grpc.ClientStreamingServer[{{$request}}, {{$response}}]) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			// TODO: Build the response from the received requests
+			return stream.SendAndClose(&{{$response}}{})
+		}
+		if err != nil {
+			return err
+		}
+
+		// TODO: Handle each request
+		_ = req
+	}
+}
+{{- else if eq .Stream "bidi"}}
+
+func (s *{{.Receiver}}) {{.RPC}}(stream grpc.BidiStreamingServer[{{$request}}, {{$response}}]) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// TODO: Handle each request and send responses
+		_ = req
+		if err := stream.Send(&{{$response}}{}); err != nil {
+			return err
+		}
+	}
+}
+{{- else}}
+
+func (s *{{.Receiver}}) {{.RPC}}(ctx context.Context, req *{{$request}}) (*{{$response}}, error) {
+	// TODO: Implement {{.Name}} logic
+	return &{{$response}}{}, nil
+}
+{{- end}}
+{{- end}}`
+
+// methodStubData is the data passed to the method stub template
+type methodStubData struct {
+	Receiver string
+	Alias    string
+	RPC      string
+	Name     string
+	Stream   StreamKind
+}
+
+func newMethodStubData(receiver, alias, rpc string, method Method) methodStubData {
+	return methodStubData{
+		Receiver: receiver,
+		Alias:    alias,
+		RPC:      rpc,
+		Name:     method.Name,
+		Stream:   method.Stream,
+	}
+}
+
+// ServiceFiles locates the proto definition and server implementation of an
+// existing gRPC service in a Meower project
+type ServiceFiles struct {
+	ServiceName string
+	// ProtoPath is the slash-separated path of the .proto file declaring the service
+	ProtoPath string
+	Proto     *proto.File
+	// HandlerPath is the slash-separated path of the Go file declaring the server struct
+	HandlerPath string
+	// Receiver is the server struct embedding Unimplemented<Service>Server
+	Receiver string
+	// ProtoAlias is the import name of the generated proto package in the handler file
+	ProtoAlias string
+	// Version is the API version ending the proto package, such as v1
+	Version string
+}
+
+// GoPackage returns the import path of the service's generated Go package,
+// from the go_package option of its .proto file
+func (f *ServiceFiles) GoPackage() string {
+	importPath, _, _ := strings.Cut(f.Proto.Option("go_package"), ";")
+	return importPath
+}
+
+// MessageTypes returns the message names a new RPC's fields may refer to
+func (f *ServiceFiles) MessageTypes() []string {
+	var types []string
+	for name := range f.Proto.AllMessages() {
+		types = append(types, name)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// NewMethod builds a custom method from comma-separated request and response
+// field lists. Field types may be protobuf scalars, timestamp or any message
+// declared in the service's .proto file.
+func (f *ServiceFiles) NewMethod(name, request, response string, stream StreamKind) (Method, error) {
+	validator := validation.NewValidator()
+	if err := validator.Service.ValidateMethodName(name); err != nil {
+		return Method{}, err
+	}
+	if err := validator.Service.ValidateStreamKind(string(stream)); err != nil {
+		return Method{}, err
+	}
+
+	method := Method{Name: name, Stream: stream, Custom: true}
+
+	var err error
+	if method.Request, err = ParseFields(request, f.MessageTypes()...); err != nil {
+		return Method{}, fmt.Errorf("%s request: %w", name, err)
+	}
+	if method.Response, err = ParseFields(response, f.MessageTypes()...); err != nil {
+		return Method{}, fmt.Errorf("%s response: %w", name, err)
+	}
+	return method, nil
+}
+
+// MethodGenerator adds RPCs to services that already exist. Unlike
+// HandlerGenerator it edits files in place: the rpc and its messages are
+// appended to the .proto file and a stub is appended to the server handler,
+// leaving existing code byte-for-byte untouched.
+type MethodGenerator struct {
+	src fs.FS
+	out templates.Writer
+}
+
+// NewMethodGenerator creates a method generator working in the current directory
+func NewMethodGenerator() *MethodGenerator {
+	return NewMethodGeneratorWithFS(os.DirFS("."), templates.NewDiskWriter("."))
+}
+
+// NewMethodGeneratorWithFS creates a method generator reading the project from
+// src and writing edited files to out
+func NewMethodGeneratorWithFS(src fs.FS, out templates.Writer) *MethodGenerator {
+	return &MethodGenerator{
+		src: src,
+		out: out,
+	}
+}
+
+// FindService locates the .proto file under api/proto and the handler under
+// api/server/handlers for serviceName. A service with several API versions
+// resolves to its oldest one.
+func (g *MethodGenerator) FindService(serviceName string) (*ServiceFiles, error) {
+	versions, err := findServiceVersions(g.src, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	service := versions[0]
+	if service.HandlerPath == "" {
+		return nil, fmt.Errorf("no struct in %s embeds Unimplemented%sServer", path.Join("api", "server", "handlers"), serviceName)
+	}
+	return service, nil
+}
+
+// findServiceVersions locates every version of serviceName: each .proto file
+// under api/proto declaring it, oldest version first, together with the
+// handler implementing that version. HandlerPath is empty for versions
+// without a handler.
+func findServiceVersions(src fs.FS, serviceName string) ([]*ServiceFiles, error) {
+	files, err := proto.ParseDir(src, path.Join("api", "proto"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse proto files: %w", err)
+	}
+
+	var versions []*ServiceFiles
+	for _, file := range files {
+		if file.Service(serviceName) != nil {
+			versions = append(versions, &ServiceFiles{
+				ServiceName: serviceName,
+				ProtoPath:   path.Join("api", "proto", file.Path),
+				Proto:       file,
+				Version:     packageVersion(file.Package),
+			})
+		}
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("service %s is not declared in any .proto file under api/proto", serviceName)
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versionNumber(versions[i].Version) < versionNumber(versions[j].Version)
+	})
+
+	handlersDir := path.Join("api", "server", "handlers")
+	entries, err := fs.ReadDir(src, handlersDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", handlersDir, err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+
+		handlerPath := path.Join(handlersDir, entry.Name())
+		content, err := fs.ReadFile(src, handlerPath)
+		if err != nil {
+			return nil, err
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), handlerPath, content, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", handlerPath, err)
+		}
+		receiver, alias := findServerStruct(file, serviceName)
+		if receiver == "" {
+			continue
+		}
+
+		// Match the handler to the version whose Go package it implements
+		importPath := importPathOf(file, alias)
+		for _, service := range versions {
+			if service.HandlerPath == "" && (service.GoPackage() == "" || service.GoPackage() == importPath) {
+				service.HandlerPath = handlerPath
+				service.Receiver = receiver
+				service.ProtoAlias = alias
+				break
+			}
+		}
+	}
+
+	return versions, nil
+}
+
+// findServerStruct returns the struct embedding <alias>.Unimplemented<Service>Server
+func findServerStruct(file *ast.File, serviceName string) (receiver, alias string) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			for _, field := range structType.Fields.List {
+				sel, ok := field.Type.(*ast.SelectorExpr)
+				if !ok || len(field.Names) != 0 || sel.Sel.Name != "Unimplemented"+serviceName+"Server" {
+					continue
+				}
+				if pkg, ok := sel.X.(*ast.Ident); ok {
+					return typeSpec.Name.Name, pkg.Name
+				}
+			}
+		}
+	}
+	return "", ""
+}
+
+// importPathOf returns the path of the import named name, or of the import
+// whose last path element is name when it has no explicit name
+func importPathOf(file *ast.File, name string) string {
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		if spec.Name != nil && spec.Name.Name == name || spec.Name == nil && path.Base(importPath) == name {
+			return importPath
+		}
+	}
+	return ""
+}
+
+// AddMethod appends method to the service's .proto file and server handler.
+// Custom methods keep their name; its messages are <Name>Request and <Name>Response.
+func (g *MethodGenerator) AddMethod(service *ServiceFiles, method Method) error {
+	rpc := method.RPCName(strings.TrimSuffix(service.ServiceName, "Service"))
+
+	if service.Proto.Service(service.ServiceName).RPC(rpc) != nil {
+		return fmt.Errorf("%s already has an rpc named %s", service.ServiceName, rpc)
+	}
+	for _, message := range []string{rpc + "Request", rpc + "Response"} {
+		if service.Proto.Message(message) != nil {
+			return fmt.Errorf("message %s already exists in %s", message, service.ProtoPath)
+		}
+	}
+
+	protoSrc, err := fs.ReadFile(g.src, service.ProtoPath)
+	if err != nil {
+		return err
+	}
+	handlerSrc, err := fs.ReadFile(g.src, service.HandlerPath)
+	if err != nil {
+		return err
+	}
+
+	newProto := appendRPC(protoSrc, service.Proto, service.ServiceName, rpc, method)
+	newHandler, err := appendMethodStub(handlerSrc, service, rpc, method)
+	if err != nil {
+		return err
+	}
+
+	if err := g.out.WriteFile(service.ProtoPath, newProto); err != nil {
+		return fmt.Errorf("failed to update %s: %w", service.ProtoPath, err)
+	}
+	if err := g.out.WriteFile(service.HandlerPath, newHandler); err != nil {
+		return fmt.Errorf("failed to update %s: %w", service.HandlerPath, err)
+	}
+	return nil
+}
+
+// appendRPC inserts the rpc before the service's closing brace and appends
+// its request and response messages to the end of the file
+func appendRPC(src []byte, file *proto.File, serviceName, rpc string, method Method) []byte {
+	service := file.Service(serviceName)
+
+	indent := "  "
+	if len(service.RPCs) > 0 {
+		last := service.RPCs[len(service.RPCs)-1].Pos.Offset
+		indent = string(src[lineStart(src, last):last])
+	}
+
+	var line strings.Builder
+	fmt.Fprintf(&line, "%srpc %s(", indent, rpc)
+	if method.ClientStreaming() {
+		line.WriteString("stream ")
+	}
+	fmt.Fprintf(&line, "%sRequest) returns (", rpc)
+	if method.ServerStreaming() {
+		line.WriteString("stream ")
+	}
+	fmt.Fprintf(&line, "%sResponse) {}\n", rpc)
+
+	var edits []edit
+	closeBrace := service.Close.Offset
+	if start := lineStart(src, closeBrace); strings.TrimSpace(string(src[start:closeBrace])) == "" {
+		edits = append(edits, edit{offset: start, text: line.String()})
+	} else {
+		// The closing brace shares a line with other declarations, e.g. `service S {}`
+		edits = append(edits, edit{offset: closeBrace, text: "\n" + line.String()})
+	}
+
+	if needsTimestamp(method) && !file.HasImport("google/protobuf/timestamp.proto") {
+		statement := "import \"google/protobuf/timestamp.proto\";\n"
+		if n := len(file.Imports); n > 0 {
+			edits = append(edits, edit{offset: lineEnd(src, file.Imports[n-1].End), text: statement})
+		} else {
+			edits = append(edits, edit{offset: lineEnd(src, file.PackagePos.Offset), text: "\n" + statement})
+		}
+	}
+
+	var messages strings.Builder
+	if len(src) > 0 && src[len(src)-1] != '\n' {
+		messages.WriteString("\n")
+	}
+	writeMessage(&messages, rpc+"Request", method.Request)
+	writeMessage(&messages, rpc+"Response", method.Response)
+	edits = append(edits, edit{offset: len(src), text: messages.String()})
+
+	return applyEdits(src, edits)
+}
+
+// writeMessage writes a message declaration preceded by a blank line
+func writeMessage(b *strings.Builder, name string, fields []Field) {
+	if len(fields) == 0 {
+		fmt.Fprintf(b, "\nmessage %s {}\n", name)
+		return
+	}
+	fmt.Fprintf(b, "\nmessage %s {\n", name)
+	for i, field := range fields {
+		fmt.Fprintf(b, "  %s %s = %d;\n", field.ProtoType(), field.Name, i+1)
+	}
+	b.WriteString("}\n")
+}
+
+func needsTimestamp(method Method) bool {
+	for _, field := range append(append([]Field(nil), method.Request...), method.Response...) {
+		if field.Type == "timestamp" {
+			return true
+		}
+	}
+	return false
+}
+
+// appendMethodStub appends a server stub for rpc to the handler source and
+// adds any imports it needs, without reformatting existing code
+func appendMethodStub(src []byte, service *ServiceFiles, rpc string, method Method) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, service.HandlerPath, src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", service.HandlerPath, err)
+	}
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if ok && fn.Name.Name == rpc && receiverName(fn) == service.Receiver {
+			return nil, fmt.Errorf("%s already implements %s in %s", service.Receiver, rpc, service.HandlerPath)
+		}
+	}
+
+	tmpl, err := template.New("method stub").Parse(methodStubTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse method stub template: %w", err)
+	}
+	var buf bytes.Buffer
+	buf.WriteString("package handlers\n")
+	if err := tmpl.Execute(&buf, newMethodStubData(service.Receiver, service.ProtoAlias, rpc, method)); err != nil {
+		return nil, fmt.Errorf("failed to execute method stub template: %w", err)
+	}
+	stub, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format method stub: %w", err)
+	}
+	stub = bytes.TrimPrefix(stub, []byte("package handlers\n"))
+
+	var imports []string
+	if method.Unary() {
+		imports = append(imports, "context")
+	} else {
+		imports = append(imports, "google.golang.org/grpc")
+	}
+	if method.ClientStreaming() {
+		imports = append(imports, "io")
+	}
+
+	edits := importEdits(fset, file, src, imports)
+	if len(src) > 0 && src[len(src)-1] != '\n' {
+		stub = append([]byte("\n"), stub...)
+	}
+	edits = append(edits, edit{offset: len(src), text: string(stub)})
+
+	return applyEdits(src, edits), nil
+}
+
+// receiverName returns the type name of a method's receiver, or ""
+func receiverName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	typ := fn.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	if ident, ok := typ.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// importEdits returns the edits adding each missing import path. Standard
+// library paths go into the group of standard imports and others into the
+// group of third-party imports, each in sorted position.
+func importEdits(fset *token.FileSet, file *ast.File, src []byte, paths []string) []edit {
+	imports := make([]namedImport, len(paths))
+	for i, p := range paths {
+		imports[i] = namedImport{path: p}
+	}
+	return namedImportEdits(fset, file, src, imports)
+}
+
+// namedImport is an import path with an optional name
+type namedImport struct {
+	name string
+	path string
+}
+
+func (i namedImport) String() string {
+	if i.name == "" {
+		return strconv.Quote(i.path)
+	}
+	return i.name + " " + strconv.Quote(i.path)
+}
+
+// namedImportEdits is importEdits for imports that may be named
+func namedImportEdits(fset *token.FileSet, file *ast.File, src []byte, imports []namedImport) []edit {
+	var specs []*ast.ImportSpec
+	var decl *ast.GenDecl
+	for _, d := range file.Decls {
+		if gen, ok := d.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			decl = gen
+			for _, spec := range gen.Specs {
+				specs = append(specs, spec.(*ast.ImportSpec))
+			}
+		}
+	}
+
+	imported := make(map[string]bool)
+	for _, spec := range specs {
+		p, _ := strconv.Unquote(spec.Path.Value)
+		imported[p] = true
+	}
+
+	var missing []namedImport
+	for _, i := range imports {
+		if !imported[i.path] {
+			missing = append(missing, i)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	offset := func(pos token.Pos) int { return fset.Position(pos).Offset }
+	line := func(pos token.Pos) int { return fset.Position(pos).Line }
+
+	if decl == nil || !decl.Lparen.IsValid() {
+		// No parenthesized import block: add a new one after the package clause
+		// (or after the single import)
+		at := file.Name.End()
+		if decl != nil {
+			at = decl.End()
+		}
+		var b strings.Builder
+		b.WriteString("\n\nimport (\n")
+		for _, i := range missing {
+			fmt.Fprintf(&b, "\t%s\n", i)
+		}
+		b.WriteString(")")
+		return []edit{{offset: offset(at), text: b.String()}}
+	}
+
+	// Split the import block into groups separated by blank lines
+	var groups [][]*ast.ImportSpec
+	for i, spec := range specs {
+		if i == 0 || line(spec.Pos()) > line(specs[i-1].End())+1 {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], spec)
+	}
+
+	// The standard library group is the first one holding only standard
+	// imports; third-party imports go into the last group holding any other
+	var stdGroup, otherGroup []*ast.ImportSpec
+	for _, g := range groups {
+		std := true
+		for _, spec := range g {
+			if sp, _ := strconv.Unquote(spec.Path.Value); !isStdImport(sp) {
+				std = false
+			}
+		}
+		if std && stdGroup == nil {
+			stdGroup = g
+		}
+		if !std {
+			otherGroup = g
+		}
+	}
+
+	var edits []edit
+	for _, i := range missing {
+		std := isStdImport(i.path)
+		group := otherGroup
+		if std {
+			group = stdGroup
+		}
+
+		statement := fmt.Sprintf("\t%s\n", i)
+		switch {
+		case group == nil && std:
+			edits = append(edits, edit{offset: lineEnd(src, offset(decl.Lparen)), text: statement + "\n"})
+		case group == nil:
+			edits = append(edits, edit{offset: lineStart(src, offset(decl.Rparen)), text: "\n" + statement})
+		default:
+			at := lineEnd(src, offset(group[len(group)-1].End()))
+			for _, spec := range group {
+				if sp, _ := strconv.Unquote(spec.Path.Value); sp > i.path {
+					at = lineStart(src, offset(spec.Pos()))
+					break
+				}
+			}
+			edits = append(edits, edit{offset: at, text: statement})
+		}
+	}
+	return edits
+}
+
+// isStdImport reports whether an import path belongs to the standard library,
+// whose first path element never contains a dot
+func isStdImport(p string) bool {
+	first, _, _ := strings.Cut(p, "/")
+	return !strings.Contains(first, ".")
+}
+
+// edit inserts text at a byte offset
+type edit struct {
+	offset int
+	text   string
+}
+
+// applyEdits applies insertions to src. Edits at the same offset keep their order.
+func applyEdits(src []byte, edits []edit) []byte {
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].offset < edits[j].offset })
+
+	var out bytes.Buffer
+	last := 0
+	for _, e := range edits {
+		out.Write(src[last:e.offset])
+		out.WriteString(e.text)
+		last = e.offset
+	}
+	out.Write(src[last:])
+	return out.Bytes()
+}
+
+// lineStart returns the offset of the start of the line containing offset
+func lineStart(src []byte, offset int) int {
+	return bytes.LastIndexByte(src[:offset], '\n') + 1
+}
+
+// lineEnd returns the offset just past the newline ending the line containing offset
+func lineEnd(src []byte, offset int) int {
+	if i := bytes.IndexByte(src[offset:], '\n'); i >= 0 {
+		return offset + i + 1
+	}
+	return len(src)
+}
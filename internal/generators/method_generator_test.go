@@ -0,0 +1,231 @@
+package generators
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/AlyxPink/meower/internal/templates"
+	"github.com/AlyxPink/meower/internal/templates/templatetest"
+)
+
+// templateServiceFS returns the MeowService files of the project template
+func templateServiceFS(t *testing.T) fstest.MapFS {
+	t.Helper()
+
+	fsys := fstest.MapFS{}
+	for _, path := range []string{
+		"api/proto/meow/v1/meow.proto",
+		"api/server/handlers/meow.go",
+	} {
+		content, err := os.ReadFile(filepath.Join("..", "..", "cmd", "meower", "template", filepath.FromSlash(path)))
+		if err != nil {
+			t.Fatalf("failed to read template file: %v", err)
+		}
+		fsys[path] = &fstest.MapFile{Data: content}
+	}
+	return fsys
+}
+
+// TestMethodGeneratorGolden appends RPCs to the template's MeowService and
+// compares the edited files against testdata/golden/method. Run with -update
+// to accept changes.
+func TestMethodGeneratorGolden(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		request  string
+		response string
+		stream   StreamKind
+	}{
+		{name: "Like", method: "Like", request: "meow_id:string"},
+		{name: "WatchReplies", method: "WatchReplies", request: "since:timestamp", response: "meow:Meow", stream: StreamServer},
+		{name: "ImportMeows", method: "ImportMeows", request: "content:string", response: "meows:[]Meow", stream: StreamClient},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := templates.NewMemoryWriter()
+			generator := NewMethodGeneratorWithFS(templateServiceFS(t), out)
+
+			service, err := generator.FindService("MeowService")
+			if err != nil {
+				t.Fatalf("FindService() error = %v", err)
+			}
+
+			method, err := service.NewMethod(tt.method, tt.request, tt.response, tt.stream)
+			if err != nil {
+				t.Fatalf("NewMethod() error = %v", err)
+			}
+
+			if err := generator.AddMethod(service, method); err != nil {
+				t.Fatalf("AddMethod() error = %v", err)
+			}
+
+			templatetest.AssertGolden(t, filepath.Join("testdata", "golden", "method", tt.name), out.Files)
+		})
+	}
+}
+
+// TestMethodGeneratorKeepsExistingCode checks that appending a method only
+// inserts text: every line of the original files survives in order.
+func TestMethodGeneratorKeepsExistingCode(t *testing.T) {
+	fsys := templateServiceFS(t)
+	out := templates.NewMemoryWriter()
+	generator := NewMethodGeneratorWithFS(fsys, out)
+
+	service, err := generator.FindService("MeowService")
+	if err != nil {
+		t.Fatalf("FindService() error = %v", err)
+	}
+	if service.Receiver != "meowServiceServer" || service.ProtoAlias != "meowV1" {
+		t.Errorf("FindService() = %s/%s, want meowServiceServer/meowV1", service.Receiver, service.ProtoAlias)
+	}
+
+	if err := generator.AddMethod(service, Method{Name: "Chat", Stream: StreamBidi, Custom: true}); err != nil {
+		t.Fatalf("AddMethod() error = %v", err)
+	}
+
+	for path, file := range fsys {
+		got := strings.Split(string(out.Files[path]), "\n")
+		i := 0
+		for _, line := range strings.Split(string(file.Data), "\n") {
+			for i < len(got) && got[i] != line {
+				i++
+			}
+			if i == len(got) {
+				t.Errorf("%s: original line %q missing or reordered", path, line)
+				break
+			}
+			i++
+		}
+	}
+}
+
+func TestMethodGeneratorErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		serviceName string
+		method      Method
+		wantErr     string
+	}{
+		{
+			name:        "unknown service",
+			serviceName: "PurrService",
+			wantErr:     "service PurrService is not declared",
+		},
+		{
+			name:        "existing rpc",
+			serviceName: "MeowService",
+			method:      Method{Name: "CreateMeow", Custom: true},
+			wantErr:     "already has an rpc named CreateMeow",
+		},
+		{
+			name:        "existing message",
+			serviceName: "MeowService",
+			method:      Method{Name: "Meow", Custom: true},
+			wantErr:     "message MeowRequest",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := templateServiceFS(t)
+			fsys["api/proto/meow/v1/meow.proto"].Data = append(fsys["api/proto/meow/v1/meow.proto"].Data, "\nmessage MeowRequest {}\n"...)
+			generator := NewMethodGeneratorWithFS(fsys, templates.NewMemoryWriter())
+
+			service, err := generator.FindService(tt.serviceName)
+			if err == nil {
+				err = generator.AddMethod(service, tt.method)
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServiceFilesNewMethod(t *testing.T) {
+	generator := NewMethodGeneratorWithFS(templateServiceFS(t), templates.NewMemoryWriter())
+	service, err := generator.FindService("MeowService")
+	if err != nil {
+		t.Fatalf("FindService() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		request  string
+		response string
+		stream   StreamKind
+		wantErr  bool
+	}{
+		{name: "message from proto", method: "Like", request: "meow_id:string", response: "meow:Meow"},
+		{name: "repeated request message", method: "Import", request: "meows:[]CreateMeowRequest"},
+		{name: "unknown message", method: "Like", request: "meow:Purr", wantErr: true},
+		{name: "lowercase name", method: "like", wantErr: true},
+		{name: "bad stream kind", method: "Like", stream: "both", wantErr: true},
+		{name: "malformed field", method: "Like", request: "meow_id", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.NewMethod(tt.method, tt.request, tt.response, tt.stream)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewMethod() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestImportEdits(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		paths    []string
+		expected string
+	}{
+		{
+			name:     "sorted into existing groups",
+			src:      "package handlers\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/a/b\"\n\t\"google.golang.org/protobuf\"\n)\n",
+			paths:    []string{"context", "google.golang.org/grpc"},
+			expected: "package handlers\n\nimport (\n\t\"context\"\n\t\"fmt\"\n\n\t\"github.com/a/b\"\n\t\"google.golang.org/grpc\"\n\t\"google.golang.org/protobuf\"\n)\n",
+		},
+		{
+			name:     "new groups",
+			src:      "package handlers\n\nimport (\n\t\"github.com/a/b\"\n)\n",
+			paths:    []string{"io"},
+			expected: "package handlers\n\nimport (\n\t\"io\"\n\n\t\"github.com/a/b\"\n)\n",
+		},
+		{
+			name:     "already imported",
+			src:      "package handlers\n\nimport \"context\"\n",
+			paths:    []string{"context"},
+			expected: "package handlers\n\nimport \"context\"\n",
+		},
+		{
+			name:     "no imports",
+			src:      "package handlers\n\nvar x = 1\n",
+			paths:    []string{"context"},
+			expected: "package handlers\n\nimport (\n\t\"context\"\n)\n\nvar x = 1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "x.go", tt.src, parser.ImportsOnly)
+			if err != nil {
+				t.Fatalf("ParseFile() error = %v", err)
+			}
+			got := string(applyEdits([]byte(tt.src), importEdits(fset, file, []byte(tt.src), tt.paths)))
+			if got != tt.expected {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.expected)
+			}
+		})
+	}
+}
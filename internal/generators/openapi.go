@@ -0,0 +1,482 @@
+package generators
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/AlyxPink/meower/internal/proto"
+	"github.com/AlyxPink/meower/internal/templates"
+)
+
+// OpenAPIPath is where the web app embeds the OpenAPI document it serves
+var OpenAPIPath = path.Join("web", "docs", "openapi.json")
+
+// fiberParamRegex matches the :params of a Fiber path
+var fiberParamRegex = regexp.MustCompile(`:(\w+)`)
+
+// statusSchemaName is the schema of the gRPC status returned on errors
+const statusSchemaName = "google.rpc.Status"
+
+// openAPIDocument is the subset of an OpenAPI 3 document Meower generates
+type openAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       openAPIInfo                             `json:"info"`
+	Tags       []openAPITag                            `json:"tags,omitempty"`
+	Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+	Components openAPIComponents                       `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPITag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type openAPIComponents struct {
+	Schemas map[string]*openAPISchema `json:"schemas"`
+}
+
+type openAPIOperation struct {
+	OperationID string                      `json:"operationId"`
+	Description string                      `json:"description,omitempty"`
+	Tags        []string                    `json:"tags"`
+	Parameters  []*openAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Schema      *openAPISchema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	AllOf                []*openAPISchema          `json:"allOf,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Description          string                    `json:"description,omitempty"`
+	Nullable             bool                      `json:"nullable,omitempty"`
+	Enum                 []string                  `json:"enum,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+}
+
+// scalarSchemas are the JSON representations of protobuf scalars, following
+// protojson: 64-bit integers are strings and bytes are base64
+var scalarSchemas = map[string]openAPISchema{
+	"double":   {Type: "number", Format: "double"},
+	"float":    {Type: "number", Format: "float"},
+	"int32":    {Type: "integer", Format: "int32"},
+	"sint32":   {Type: "integer", Format: "int32"},
+	"sfixed32": {Type: "integer", Format: "int32"},
+	"uint32":   {Type: "integer", Format: "int64"},
+	"fixed32":  {Type: "integer", Format: "int64"},
+	"int64":    {Type: "string", Format: "int64"},
+	"sint64":   {Type: "string", Format: "int64"},
+	"sfixed64": {Type: "string", Format: "int64"},
+	"uint64":   {Type: "string", Format: "uint64"},
+	"fixed64":  {Type: "string", Format: "uint64"},
+	"bool":     {Type: "boolean"},
+	"string":   {Type: "string"},
+	"bytes":    {Type: "string", Format: "byte"},
+}
+
+// wellKnownSchemas are the JSON representations of google.protobuf types
+var wellKnownSchemas = map[string]openAPISchema{
+	"google.protobuf.Timestamp":   {Type: "string", Format: "date-time"},
+	"google.protobuf.Duration":    {Type: "string", Description: "Seconds with up to nine fractional digits, suffixed with s, such as 1.5s"},
+	"google.protobuf.FieldMask":   {Type: "string", Description: "Comma-separated field paths in lowerCamelCase"},
+	"google.protobuf.Empty":       {Type: "object"},
+	"google.protobuf.Struct":      {Type: "object"},
+	"google.protobuf.Any":         {Type: "object"},
+	"google.protobuf.Value":       {},
+	"google.protobuf.ListValue":   {Type: "array", Items: &openAPISchema{}},
+	"google.protobuf.DoubleValue": {Type: "number", Format: "double", Nullable: true},
+	"google.protobuf.FloatValue":  {Type: "number", Format: "float", Nullable: true},
+	"google.protobuf.Int64Value":  {Type: "string", Format: "int64", Nullable: true},
+	"google.protobuf.UInt64Value": {Type: "string", Format: "uint64", Nullable: true},
+	"google.protobuf.Int32Value":  {Type: "integer", Format: "int32", Nullable: true},
+	"google.protobuf.UInt32Value": {Type: "integer", Format: "int64", Nullable: true},
+	"google.protobuf.BoolValue":   {Type: "boolean", Nullable: true},
+	"google.protobuf.StringValue": {Type: "string", Nullable: true},
+	"google.protobuf.BytesValue":  {Type: "string", Format: "byte", Nullable: true},
+}
+
+// OpenAPIGenerator converts the .proto files under api/proto to an OpenAPI 3
+// document describing the JSON gateway: one operation per gateway route and
+// one schema per message and enum
+type OpenAPIGenerator struct {
+	src fs.FS
+	out templates.Writer
+}
+
+// NewOpenAPIGenerator creates an OpenAPI generator working in the current directory
+func NewOpenAPIGenerator() *OpenAPIGenerator {
+	return NewOpenAPIGeneratorWithFS(os.DirFS("."), templates.NewDiskWriter("."))
+}
+
+// NewOpenAPIGeneratorWithFS creates an OpenAPI generator reading the project
+// from src and writing the document to out
+func NewOpenAPIGeneratorWithFS(src fs.FS, out templates.Writer) *OpenAPIGenerator {
+	return &OpenAPIGenerator{
+		src: src,
+		out: out,
+	}
+}
+
+// OpenAPIResult summarizes a generated document
+type OpenAPIResult struct {
+	Path       string
+	Operations int
+	Schemas    int
+	Skipped    []string
+}
+
+// Generate writes the OpenAPI document of the project's API to output
+func (g *OpenAPIGenerator) Generate(title, output string) (*OpenAPIResult, error) {
+	files, err := proto.ParseDir(g.src, path.Join("api", "proto"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse proto files: %w", err)
+	}
+
+	_, routes, skipped, err := collectGatewayRoutes(files)
+	if err != nil {
+		return nil, err
+	}
+
+	b := newOpenAPIBuilder(files)
+	doc := &openAPIDocument{
+		OpenAPI:    "3.0.3",
+		Info:       openAPIInfo{Title: title, Version: latestVersion(files)},
+		Paths:      make(map[string]map[string]*openAPIOperation),
+		Components: openAPIComponents{Schemas: b.schemas()},
+	}
+
+	tagged := make(map[string]bool)
+	for _, file := range files {
+		for _, service := range file.Services {
+			if !tagged[service.Name] {
+				tagged[service.Name] = true
+				doc.Tags = append(doc.Tags, openAPITag{Name: service.Name, Description: service.Comment})
+			}
+		}
+	}
+
+	for _, route := range routes {
+		operation, err := b.operation(route)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", route.ServiceName, route.RPC, err)
+		}
+		p := fiberParamRegex.ReplaceAllString(route.Path, "{$1}")
+		if doc.Paths[p] == nil {
+			doc.Paths[p] = make(map[string]*openAPIOperation)
+		}
+		doc.Paths[p][strings.ToLower(route.Method)] = operation
+	}
+
+	content, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+	content = append(content, '\n')
+
+	if err := g.out.MkdirAll(path.Dir(output)); err != nil {
+		return nil, err
+	}
+	if err := g.out.WriteFile(output, content); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", output, err)
+	}
+
+	return &OpenAPIResult{
+		Path:       output,
+		Operations: len(routes),
+		Schemas:    len(doc.Components.Schemas),
+		Skipped:    skipped,
+	}, nil
+}
+
+// latestVersion returns the highest API version declared by files, used as
+// the document version
+func latestVersion(files []*proto.File) string {
+	latest := "v1"
+	for _, file := range files {
+		if version := packageVersion(file.Package); versionNumber(version) > versionNumber(latest) {
+			latest = version
+		}
+	}
+	return latest
+}
+
+// openAPIBuilder converts proto definitions to OpenAPI schemas, keyed by
+// fully-qualified name such as meow.v1.Meow
+type openAPIBuilder struct {
+	resolver *proto.Resolver
+	messages map[string]scopedMessage
+	enums    map[string]*proto.Enum
+}
+
+// scopedMessage is a message with what is needed to resolve its field types
+type scopedMessage struct {
+	pkg     string
+	scope   string
+	message *proto.Message
+}
+
+func newOpenAPIBuilder(files []*proto.File) *openAPIBuilder {
+	b := &openAPIBuilder{
+		resolver: proto.NewResolver(files),
+		messages: make(map[string]scopedMessage),
+		enums:    make(map[string]*proto.Enum),
+	}
+	for _, file := range files {
+		for name, message := range file.AllMessages() {
+			b.messages[qualifiedName(file.Package, name)] = scopedMessage{pkg: file.Package, scope: name, message: message}
+		}
+		for name, enum := range file.AllEnums() {
+			b.enums[qualifiedName(file.Package, name)] = enum
+		}
+	}
+	return b
+}
+
+// schemas returns the component schemas of every message and enum, along
+// with the gRPC status returned on errors
+func (b *openAPIBuilder) schemas() map[string]*openAPISchema {
+	schemas := map[string]*openAPISchema{
+		statusSchemaName: {
+			Type:        "object",
+			Description: "The gRPC status of a failed call",
+			Properties: map[string]*openAPISchema{
+				"code":    {Type: "integer", Format: "int32", Description: "gRPC status code, such as 5 for NOT_FOUND"},
+				"message": {Type: "string"},
+				"details": {Type: "array", Items: &openAPISchema{Type: "object"}},
+			},
+		},
+	}
+
+	for name, enum := range b.enums {
+		schema := &openAPISchema{Type: "string", Description: enum.Comment}
+		for _, value := range enum.Values {
+			schema.Enum = append(schema.Enum, value.Name)
+		}
+		schemas[name] = schema
+	}
+
+	for name, scoped := range b.messages {
+		schema := &openAPISchema{Type: "object", Description: scoped.message.Comment, Properties: make(map[string]*openAPISchema)}
+		for _, field := range scoped.message.Fields {
+			property := b.fieldSchema(scoped, field)
+			if field.Comment != "" {
+				property = withDescription(property, field.Comment)
+			}
+			schema.Properties[jsonName(field)] = property
+		}
+		schemas[name] = schema
+	}
+	return schemas
+}
+
+// fieldSchema returns the schema of a field's JSON value
+func (b *openAPIBuilder) fieldSchema(scoped scopedMessage, field *proto.Field) *openAPISchema {
+	value := b.typeSchema(scoped, field.Type)
+	switch {
+	case field.IsMap():
+		return &openAPISchema{Type: "object", AdditionalProperties: value}
+	case field.Repeated():
+		return &openAPISchema{Type: "array", Items: value}
+	}
+	return value
+}
+
+// typeSchema returns the schema of a scalar, a reference to a message or
+// enum schema, or the JSON mapping of a well-known type
+func (b *openAPIBuilder) typeSchema(scoped scopedMessage, typ string) *openAPISchema {
+	if schema, ok := scalarSchemas[typ]; ok {
+		return &schema
+	}
+
+	name := b.resolver.Resolve(scoped.pkg, scoped.scope, typ)
+	if schema, ok := wellKnownSchemas[name]; ok {
+		return &schema
+	}
+	if name == "" || strings.HasPrefix(name, "google.protobuf.") {
+		// Types from files outside api/proto can't be described
+		return &openAPISchema{Type: "object"}
+	}
+	return &openAPISchema{Ref: schemaRef(name)}
+}
+
+// operation describes a gateway route
+func (b *openAPIBuilder) operation(route GatewayRoute) (*openAPIOperation, error) {
+	pkg := route.file.Package
+	requestName := b.resolver.Resolve(pkg, "", route.rpc.Request)
+	request, ok := b.messages[requestName]
+	if !ok {
+		return nil, fmt.Errorf("request type %s is not declared", route.rpc.Request)
+	}
+	responseName := b.resolver.Resolve(pkg, "", route.rpc.Response)
+	if responseName == "" {
+		return nil, fmt.Errorf("response type %s is not declared", route.rpc.Response)
+	}
+
+	operation := &openAPIOperation{
+		OperationID: route.ServiceName + "_" + route.RPC,
+		Description: route.rpc.Comment,
+		Tags:        []string{route.ServiceName},
+		Responses: map[string]*openAPIResponse{
+			"200": {
+				Description: "A successful response",
+				Content:     jsonContent(b.typeSchema(request, "."+responseName)),
+			},
+			"default": {
+				Description: "An error, with the HTTP status matching its gRPC status code",
+				Content:     jsonContent(&openAPISchema{Ref: schemaRef(statusSchemaName)}),
+			},
+		},
+	}
+
+	inPath := make(map[string]bool)
+	for _, match := range fiberParamRegex.FindAllStringSubmatch(route.Path, -1) {
+		name := match[1]
+		inPath[name] = true
+
+		schema := &openAPISchema{Type: "string"}
+		var description string
+		if field := request.message.Field(name); field != nil {
+			schema = b.typeSchema(request, field.Type)
+			description = field.Comment
+		}
+		operation.Parameters = append(operation.Parameters, &openAPIParameter{
+			Name: name, In: "path", Required: true, Description: description, Schema: schema,
+		})
+	}
+
+	switch route.Body {
+	case "*":
+		operation.RequestBody = &openAPIRequestBody{
+			Required: true,
+			Content:  jsonContent(&openAPISchema{Ref: schemaRef(requestName)}),
+		}
+		return operation, nil
+	case "":
+	default:
+		field := request.message.Field(route.Body)
+		if field == nil {
+			return nil, fmt.Errorf("body field %s is not declared in %s", route.Body, route.rpc.Request)
+		}
+		operation.RequestBody = &openAPIRequestBody{
+			Required: true,
+			Content:  jsonContent(b.fieldSchema(request, field)),
+		}
+	}
+
+	// Remaining scalar fields can be set from the query string
+	for _, field := range request.message.Fields {
+		if inPath[field.Name] || field.Name == route.Body || field.IsMap() {
+			continue
+		}
+		schema := b.fieldSchema(request, field)
+		if !b.isQueryValue(schema) && (schema.Items == nil || !b.isQueryValue(schema.Items)) {
+			continue
+		}
+		operation.Parameters = append(operation.Parameters, &openAPIParameter{
+			Name: jsonName(field), In: "query", Description: field.Comment, Schema: schema,
+		})
+	}
+	return operation, nil
+}
+
+// isQueryValue reports whether a value can be given as a query parameter:
+// scalars, enums and wrapper types, but not messages
+func (b *openAPIBuilder) isQueryValue(schema *openAPISchema) bool {
+	if schema.Ref != "" {
+		_, isEnum := b.enums[strings.TrimPrefix(schema.Ref, schemaRef(""))]
+		return isEnum
+	}
+	return schema.Type != "" && schema.Type != "object" && schema.Type != "array"
+}
+
+// qualifiedName joins a package and a name relative to it
+func qualifiedName(pkg, name string) string {
+	if pkg == "" {
+		return name
+	}
+	return pkg + "." + name
+}
+
+// schemaRef returns the reference to a component schema
+func schemaRef(name string) string {
+	return "#/components/schemas/" + name
+}
+
+// withDescription describes a schema. Siblings of $ref are ignored in
+// OpenAPI 3.0, so references are wrapped in allOf.
+func withDescription(schema *openAPISchema, description string) *openAPISchema {
+	if schema.Ref != "" {
+		return &openAPISchema{AllOf: []*openAPISchema{schema}, Description: description}
+	}
+	described := *schema
+	if described.Description != "" {
+		description += "\n\n" + described.Description
+	}
+	described.Description = description
+	return &described
+}
+
+// jsonContent returns an application/json body of the given schema
+func jsonContent(schema *openAPISchema) map[string]openAPIMediaType {
+	return map[string]openAPIMediaType{"application/json": {Schema: schema}}
+}
+
+// jsonName returns the name of a field in protojson output: its json_name
+// option, or its name in lowerCamelCase
+func jsonName(field *proto.Field) string {
+	for _, option := range field.Options {
+		if option.Name == "json_name" {
+			return option.Value
+		}
+	}
+
+	var b strings.Builder
+	upper := false
+	for _, r := range field.Name {
+		switch {
+		case r == '_':
+			upper = true
+		case upper:
+			b.WriteString(strings.ToUpper(string(r)))
+			upper = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,147 @@
+package generators
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/AlyxPink/meower/internal/proto"
+	"github.com/AlyxPink/meower/internal/templates"
+	"github.com/AlyxPink/meower/internal/templates/templatetest"
+)
+
+// TestOpenAPIGeneratorTemplate checks that the document shipped with the
+// project template is the one `meower docs openapi` generates for its protos
+func TestOpenAPIGeneratorTemplate(t *testing.T) {
+	out := templates.NewMemoryWriter()
+	if _, err := NewOpenAPIGeneratorWithFS(templateProtoFS(t), out).Generate("TEMPLATE_PROJECT_NAME API", OpenAPIPath); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want, err := os.ReadFile(filepath.Join("..", "..", "cmd", "meower", "template", filepath.FromSlash(OpenAPIPath)))
+	if err != nil {
+		t.Fatalf("failed to read template file: %v", err)
+	}
+	if got := out.Files[OpenAPIPath]; !bytes.Equal(got, want) {
+		t.Errorf("template %s is out of date, regenerate it with `meower docs openapi --title \"TEMPLATE_PROJECT_NAME API\"`", OpenAPIPath)
+	}
+}
+
+// TestOpenAPIGeneratorGolden converts a proto using comments, enums, nested
+// messages, maps, well-known types and google.api.http annotations, and
+// compares the document against testdata/golden/openapi. Run with -update to
+// accept changes.
+func TestOpenAPIGeneratorGolden(t *testing.T) {
+	fsys := fstest.MapFS{
+		"api/proto/meow/v2/meow.proto": &fstest.MapFile{Data: []byte(`syntax = "proto3";
+
+package meow.v2;
+
+import "google/api/annotations.proto";
+import "google/protobuf/timestamp.proto";
+import "google/protobuf/wrappers.proto";
+
+option go_package = "TEMPLATE_MODULE_PATH/api/proto/meow/v2";
+
+// MeowService publishes short messages
+service MeowService {
+  // CreateMeow publishes a meow
+  rpc CreateMeow(CreateMeowRequest) returns (CreateMeowResponse) {
+    option (google.api.http) = {
+      post: "/api/v2/meows"
+      body: "meow"
+    };
+  }
+  // GetMeow returns a single meow
+  rpc GetMeow(GetMeowRequest) returns (Meow) {}
+  rpc ListMeows(ListMeowsRequest) returns (ListMeowsResponse) {}
+}
+
+// Visibility controls who can read a meow
+enum Visibility {
+  VISIBILITY_UNSPECIFIED = 0;
+  VISIBILITY_PUBLIC = 1;
+  VISIBILITY_FOLLOWERS = 2;
+}
+
+// Meow is a short message
+message Meow {
+  // Attachment is a file attached to a meow
+  message Attachment {
+    string url = 1;
+    bytes thumbnail = 2;
+  }
+
+  string id = 1;
+  // Content is the text of the meow, up to 280 characters
+  string content = 2;
+  google.protobuf.Timestamp created_at = 3;
+  Visibility visibility = 4;
+  repeated Attachment attachments = 5;
+  map<string, int64> reactions = 6;
+  google.protobuf.StringValue reply_to = 7;
+  int64 view_count = 8 [json_name = "views"];
+}
+
+message CreateMeowRequest {
+  Meow meow = 1;
+}
+
+message CreateMeowResponse {
+  Meow meow = 1;
+}
+
+message GetMeowRequest {
+  // ID of the meow
+  string id = 1;
+}
+
+message ListMeowsRequest {
+  int32 page_size = 1;
+  repeated Visibility visibility = 2;
+  Meow.Attachment with_attachment = 3;
+}
+
+message ListMeowsResponse {
+  repeated Meow meows = 1;
+  string next_page_token = 2;
+}
+`)},
+	}
+
+	out := templates.NewMemoryWriter()
+	result, err := NewOpenAPIGeneratorWithFS(fsys, out).Generate("Meower API", OpenAPIPath)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	templatetest.AssertGolden(t, filepath.Join("testdata", "golden", "openapi"), out.Files)
+
+	if result.Operations != 3 {
+		t.Errorf("Operations = %d, want 3", result.Operations)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(out.Files[OpenAPIPath], &doc); err != nil {
+		t.Fatalf("document is not valid JSON: %v", err)
+	}
+	if doc["openapi"] != "3.0.3" {
+		t.Errorf("openapi = %v, want 3.0.3", doc["openapi"])
+	}
+}
+
+func TestJSONName(t *testing.T) {
+	tests := map[string]string{
+		"id":              "id",
+		"created_at":      "createdAt",
+		"next_page_token": "nextPageToken",
+		"user_id2":        "userId2",
+	}
+	for name, want := range tests {
+		if got := jsonName(&proto.Field{Name: name}); got != want {
+			t.Errorf("jsonName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
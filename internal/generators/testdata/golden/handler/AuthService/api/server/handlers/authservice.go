@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/test/test-project/api/db"
+	authserviceV1 "github.com/test/test-project/api/proto/authservice/v1"
+)
+
+type authserviceServiceServer struct {
+	authserviceV1.UnimplementedAuthServiceServer
+	db db.DBTX
+}
+
+func NewAuthServiceServer(dbtx db.DBTX) authserviceV1.AuthServiceServer {
+	return &authserviceServiceServer{db: dbtx}
+}
+
+func (s *authserviceServiceServer) LoginAuth(ctx context.Context, req *authserviceV1.LoginAuthRequest) (*authserviceV1.LoginAuthResponse, error) {
+	// TODO: Implement Login logic
+	return &authserviceV1.LoginAuthResponse{}, nil
+}
+
+func (s *authserviceServiceServer) LogoutAuth(ctx context.Context, req *authserviceV1.LogoutAuthRequest) (*authserviceV1.LogoutAuthResponse, error) {
+	// TODO: Implement Logout logic
+	return &authserviceV1.LogoutAuthResponse{}, nil
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	authserviceV1 "github.com/test/test-project/api/proto/authservice/v1"
+	"google.golang.org/grpc"
+)
+
+func TestAuthService(t *testing.T) {
+	conn := startServer(t, func(g *grpc.Server) {
+		authserviceV1.RegisterAuthServiceServer(g, NewAuthServiceServer(&fakeDB{}))
+	})
+	client := authserviceV1.NewAuthServiceClient(conn)
+	ctx := context.Background()
+
+	t.Run("LoginAuth", func(t *testing.T) {
+		resp, err := client.LoginAuth(ctx, &authserviceV1.LoginAuthRequest{})
+		if err != nil {
+			t.Fatalf("LoginAuth() error = %v", err)
+		}
+		if resp == nil {
+			t.Error("LoginAuth() returned a nil response")
+		}
+	})
+
+	t.Run("LogoutAuth", func(t *testing.T) {
+		resp, err := client.LogoutAuth(ctx, &authserviceV1.LogoutAuthRequest{})
+		if err != nil {
+			t.Fatalf("LogoutAuth() error = %v", err)
+		}
+		if resp == nil {
+			t.Error("LogoutAuth() returned a nil response")
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"context"
+
+	authserviceV1 "github.com/test/test-project/api/proto/authservice/v1"
+)
+
+type AuthService struct {
+	*App
+	client authserviceV1.AuthServiceClient
+}
+
+func NewAuthService(app *App) *AuthService {
+	return &AuthService{
+		App:    app,
+		client: authserviceV1.NewAuthServiceClient(app.API.Conn()),
+	}
+}
+
+// TODO: Implement Fiber handlers that call the helpers below with
+// c.UserContext(), which forwards the request ID to the API logs, then
+// register them in routing.RegisterRoutes with handlers.NewAuthService(app).
+
+// LoginAuth calls the LoginAuth RPC.
+func (h *AuthService) LoginAuth(ctx context.Context, req *authserviceV1.LoginAuthRequest) (*authserviceV1.LoginAuthResponse, error) {
+	return h.client.LoginAuth(ctx, req)
+}
+
+// LogoutAuth calls the LogoutAuth RPC.
+func (h *AuthService) LogoutAuth(ctx context.Context, req *authserviceV1.LogoutAuthRequest) (*authserviceV1.LogoutAuthResponse, error) {
+	return h.client.LogoutAuth(ctx, req)
+}
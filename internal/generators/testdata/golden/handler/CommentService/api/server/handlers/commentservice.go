@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/test/test-project/api/db"
+	commentserviceV1 "github.com/test/test-project/api/proto/commentservice/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type commentserviceServiceServer struct {
+	commentserviceV1.UnimplementedCommentServiceServer
+	db db.DBTX
+}
+
+func NewCommentServiceServer(dbtx db.DBTX) commentserviceV1.CommentServiceServer {
+	return &commentserviceServiceServer{db: dbtx}
+}
+
+func (s *commentserviceServiceServer) CreateComment(ctx context.Context, req *commentserviceV1.CreateCommentRequest) (*commentserviceV1.CreateCommentResponse, error) {
+	// TODO: Implement create logic
+	// Example:
+	// result, err := db.New(s.db).CreateComment(ctx, db.CreateCommentParams{
+	//     Name: req.Name,
+	// })
+	// if err != nil {
+	//     return nil, status.Errorf(codes.Internal, "failed to create comment: %v", err)
+	// }
+
+	return &commentserviceV1.CreateCommentResponse{
+		Comment: &commentserviceV1.Comment{
+			Id:        "generated-id",
+			Name:      req.Name,
+			CreatedAt: timestamppb.Now(),
+			UpdatedAt: timestamppb.Now(),
+		},
+	}, nil
+}
+
+func (s *commentserviceServiceServer) ListComment(ctx context.Context, req *commentserviceV1.ListCommentRequest) (*commentserviceV1.ListCommentResponse, error) {
+	// TODO: Implement list logic, one page at a time with the pagination package
+	// Example:
+	// cursor, err := pagination.Decode(req.PageToken)
+	// if err != nil {
+	//     return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	// }
+	// size := pagination.Size(req.PageSize)
+	//
+	// rows, err := db.New(s.db).ListComments(ctx, db.ListCommentsParams{
+	//     BeforeCreatedAt: cursor.CreatedAt,
+	//     BeforeID:        cursor.ID,
+	//     PageSize:        size + 1,
+	// })
+	// if err != nil {
+	//     return nil, status.Errorf(codes.Internal, "failed to list comments: %v", err)
+	// }
+	// rows, next := pagination.Page(rows, size, func(row db.Comment) pagination.Cursor {
+	//     return pagination.Cursor{CreatedAt: row.CreatedAt, ID: row.ID}
+	// })
+
+	return &commentserviceV1.ListCommentResponse{
+		Comments: []*commentserviceV1.Comment{
+			{
+				Id:        "sample-1",
+				Name:      "Sample Comment 1",
+				CreatedAt: timestamppb.Now(),
+				UpdatedAt: timestamppb.Now(),
+			},
+		},
+	}, nil
+}
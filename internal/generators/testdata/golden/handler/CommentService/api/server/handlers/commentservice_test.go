@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	commentserviceV1 "github.com/test/test-project/api/proto/commentservice/v1"
+	"google.golang.org/grpc"
+)
+
+func TestCommentService(t *testing.T) {
+	conn := startServer(t, func(g *grpc.Server) {
+		commentserviceV1.RegisterCommentServiceServer(g, NewCommentServiceServer(&fakeDB{}))
+	})
+	client := commentserviceV1.NewCommentServiceClient(conn)
+	ctx := context.Background()
+
+	t.Run("CreateComment", func(t *testing.T) {
+		resp, err := client.CreateComment(ctx, &commentserviceV1.CreateCommentRequest{Name: "Test Comment"})
+		if err != nil {
+			t.Fatalf("CreateComment() error = %v", err)
+		}
+		if resp.GetComment().GetName() != "Test Comment" {
+			t.Errorf("CreateComment() name = %q, want %q", resp.GetComment().GetName(), "Test Comment")
+		}
+		if resp.GetComment().GetId() == "" {
+			t.Error("CreateComment() returned an empty id")
+		}
+	})
+
+	t.Run("ListComment", func(t *testing.T) {
+		resp, err := client.ListComment(ctx, &commentserviceV1.ListCommentRequest{PageSize: 10})
+		if err != nil {
+			t.Fatalf("ListComment() error = %v", err)
+		}
+		if len(resp.GetComments()) == 0 {
+			t.Error("ListComment() returned no Comments")
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"context"
+
+	commentserviceV1 "github.com/test/test-project/api/proto/commentservice/v1"
+)
+
+type CommentService struct {
+	*App
+	client commentserviceV1.CommentServiceClient
+}
+
+func NewCommentService(app *App) *CommentService {
+	return &CommentService{
+		App:    app,
+		client: commentserviceV1.NewCommentServiceClient(app.API.Conn()),
+	}
+}
+
+// TODO: Implement Fiber handlers that call the helpers below with
+// c.UserContext(), which forwards the request ID to the API logs, then
+// register them in routing.RegisterRoutes with handlers.NewCommentService(app).
+
+// CreateComment calls the CreateComment RPC.
+func (h *CommentService) CreateComment(ctx context.Context, req *commentserviceV1.CreateCommentRequest) (*commentserviceV1.CreateCommentResponse, error) {
+	return h.client.CreateComment(ctx, req)
+}
+
+// ListComment calls the ListComment RPC.
+func (h *CommentService) ListComment(ctx context.Context, req *commentserviceV1.ListCommentRequest) (*commentserviceV1.ListCommentResponse, error) {
+	return h.client.ListComment(ctx, req)
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/test/test-project/api/db"
+	meowserviceV1 "github.com/test/test-project/api/proto/meowservice/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type meowserviceServiceServer struct {
+	meowserviceV1.UnimplementedMeowServiceServer
+	db db.DBTX
+}
+
+func NewMeowServiceServer(dbtx db.DBTX) meowserviceV1.MeowServiceServer {
+	return &meowserviceServiceServer{db: dbtx}
+}
+
+func (s *meowserviceServiceServer) CreateMeow(ctx context.Context, req *meowserviceV1.CreateMeowRequest) (*meowserviceV1.CreateMeowResponse, error) {
+	// TODO: Implement create logic
+	// Example:
+	// result, err := db.New(s.db).CreateMeow(ctx, db.CreateMeowParams{
+	//     Name: req.Name,
+	// })
+	// if err != nil {
+	//     return nil, status.Errorf(codes.Internal, "failed to create meow: %v", err)
+	// }
+
+	return &meowserviceV1.CreateMeowResponse{
+		Meow: &meowserviceV1.Meow{
+			Id:        "generated-id",
+			Name:      req.Name,
+			CreatedAt: timestamppb.Now(),
+			UpdatedAt: timestamppb.Now(),
+		},
+	}, nil
+}
+
+func (s *meowserviceServiceServer) Publish(ctx context.Context, req *meowserviceV1.PublishRequest) (*meowserviceV1.PublishResponse, error) {
+	// TODO: Implement Publish logic
+	return &meowserviceV1.PublishResponse{}, nil
+}
+
+func (s *meowserviceServiceServer) Search(ctx context.Context, req *meowserviceV1.SearchRequest) (*meowserviceV1.SearchResponse, error) {
+	// TODO: Implement Search logic
+	return &meowserviceV1.SearchResponse{}, nil
+}
+
+func (s *meowserviceServiceServer) Watch(req *meowserviceV1.WatchRequest, stream grpc.ServerStreamingServer[meowserviceV1.WatchResponse]) error {
+	// TODO: Implement streaming logic, calling stream.Send for every response.
+	// Long-lived streams should return when stream.Context() is done.
+	return stream.Send(&meowserviceV1.WatchResponse{})
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	meowserviceV1 "github.com/test/test-project/api/proto/meowservice/v1"
+	"google.golang.org/grpc"
+)
+
+func TestMeowService(t *testing.T) {
+	conn := startServer(t, func(g *grpc.Server) {
+		meowserviceV1.RegisterMeowServiceServer(g, NewMeowServiceServer(&fakeDB{}))
+	})
+	client := meowserviceV1.NewMeowServiceClient(conn)
+	ctx := context.Background()
+
+	t.Run("CreateMeow", func(t *testing.T) {
+		resp, err := client.CreateMeow(ctx, &meowserviceV1.CreateMeowRequest{Name: "Test Meow"})
+		if err != nil {
+			t.Fatalf("CreateMeow() error = %v", err)
+		}
+		if resp.GetMeow().GetName() != "Test Meow" {
+			t.Errorf("CreateMeow() name = %q, want %q", resp.GetMeow().GetName(), "Test Meow")
+		}
+		if resp.GetMeow().GetId() == "" {
+			t.Error("CreateMeow() returned an empty id")
+		}
+	})
+
+	t.Run("Publish", func(t *testing.T) {
+		resp, err := client.Publish(ctx, &meowserviceV1.PublishRequest{})
+		if err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+		if resp == nil {
+			t.Error("Publish() returned a nil response")
+		}
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		resp, err := client.Search(ctx, &meowserviceV1.SearchRequest{})
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+		if resp == nil {
+			t.Error("Search() returned a nil response")
+		}
+	})
+
+	t.Run("Watch", func(t *testing.T) {
+		stream, err := client.Watch(ctx, &meowserviceV1.WatchRequest{})
+		if err != nil {
+			t.Fatalf("Watch() error = %v", err)
+		}
+
+		var received int
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("Watch() Recv error = %v", err)
+			}
+			_ = resp
+			received++
+		}
+		if received == 0 {
+			t.Error("Watch() streamed no responses")
+		}
+	})
+}
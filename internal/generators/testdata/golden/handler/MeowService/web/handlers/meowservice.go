@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	meowserviceV1 "github.com/test/test-project/api/proto/meowservice/v1"
+)
+
+type MeowService struct {
+	*App
+	client meowserviceV1.MeowServiceClient
+}
+
+func NewMeowService(app *App) *MeowService {
+	return &MeowService{
+		App:    app,
+		client: meowserviceV1.NewMeowServiceClient(app.API.Conn()),
+	}
+}
+
+// TODO: Implement Fiber handlers that call the helpers below with
+// c.UserContext(), which forwards the request ID to the API logs, then
+// register them in routing.RegisterRoutes with handlers.NewMeowService(app).
+
+// CreateMeow calls the CreateMeow RPC.
+func (h *MeowService) CreateMeow(ctx context.Context, req *meowserviceV1.CreateMeowRequest) (*meowserviceV1.CreateMeowResponse, error) {
+	return h.client.CreateMeow(ctx, req)
+}
+
+// Publish calls the Publish RPC.
+func (h *MeowService) Publish(ctx context.Context, req *meowserviceV1.PublishRequest) (*meowserviceV1.PublishResponse, error) {
+	return h.client.Publish(ctx, req)
+}
+
+// Search calls the Search RPC.
+func (h *MeowService) Search(ctx context.Context, req *meowserviceV1.SearchRequest) (*meowserviceV1.SearchResponse, error) {
+	return h.client.Search(ctx, req)
+}
+
+// Watch calls fn for every response streamed by the API until the stream
+// ends, ctx is cancelled or fn returns an error.
+func (h *MeowService) Watch(ctx context.Context, req *meowserviceV1.WatchRequest, fn func(*meowserviceV1.WatchResponse) error) error {
+	stream, err := h.client.Watch(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(resp); err != nil {
+			return err
+		}
+	}
+}
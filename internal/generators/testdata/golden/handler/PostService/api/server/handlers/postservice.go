@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/test/test-project/api/db"
+	postserviceV1 "github.com/test/test-project/api/proto/postservice/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type postserviceServiceServer struct {
+	postserviceV1.UnimplementedPostServiceServer
+	db db.DBTX
+}
+
+func NewPostServiceServer(dbtx db.DBTX) postserviceV1.PostServiceServer {
+	return &postserviceServiceServer{db: dbtx}
+}
+
+func (s *postserviceServiceServer) CreatePost(ctx context.Context, req *postserviceV1.CreatePostRequest) (*postserviceV1.CreatePostResponse, error) {
+	// TODO: Implement create logic
+	// Example:
+	// result, err := db.New(s.db).CreatePost(ctx, db.CreatePostParams{
+	//     Name: req.Name,
+	// })
+	// if err != nil {
+	//     return nil, status.Errorf(codes.Internal, "failed to create post: %v", err)
+	// }
+
+	return &postserviceV1.CreatePostResponse{
+		Post: &postserviceV1.Post{
+			Id:        "generated-id",
+			Name:      req.Name,
+			CreatedAt: timestamppb.Now(),
+			UpdatedAt: timestamppb.Now(),
+		},
+	}, nil
+}
+
+func (s *postserviceServiceServer) GetPost(ctx context.Context, req *postserviceV1.GetPostRequest) (*postserviceV1.GetPostResponse, error) {
+	// TODO: Implement get logic
+	// Example:
+	// uuid, err := parseUUID(req.Id)
+	// if err != nil {
+	//     return nil, status.Errorf(codes.InvalidArgument, "invalid ID: %v", err)
+	// }
+	//
+	// result, err := db.New(s.db).GetPostById(ctx, uuid)
+	// if err != nil {
+	//     return nil, status.Errorf(codes.NotFound, "post not found: %v", err)
+	// }
+
+	return &postserviceV1.GetPostResponse{
+		Post: &postserviceV1.Post{
+			Id:        req.Id,
+			Name:      "Sample Post",
+			CreatedAt: timestamppb.Now(),
+			UpdatedAt: timestamppb.Now(),
+		},
+	}, nil
+}
+
+func (s *postserviceServiceServer) UpdatePost(ctx context.Context, req *postserviceV1.UpdatePostRequest) (*postserviceV1.UpdatePostResponse, error) {
+	// TODO: Implement update logic
+	return &postserviceV1.UpdatePostResponse{
+		Post: &postserviceV1.Post{
+			Id:        req.Id,
+			Name:      req.Name,
+			CreatedAt: timestamppb.Now(),
+			UpdatedAt: timestamppb.Now(),
+		},
+	}, nil
+}
+
+func (s *postserviceServiceServer) DeletePost(ctx context.Context, req *postserviceV1.DeletePostRequest) (*postserviceV1.DeletePostResponse, error) {
+	// TODO: Implement delete logic
+	return &postserviceV1.DeletePostResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *postserviceServiceServer) ListPost(ctx context.Context, req *postserviceV1.ListPostRequest) (*postserviceV1.ListPostResponse, error) {
+	// TODO: Implement list logic, one page at a time with the pagination package
+	// Example:
+	// cursor, err := pagination.Decode(req.PageToken)
+	// if err != nil {
+	//     return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	// }
+	// size := pagination.Size(req.PageSize)
+	//
+	// rows, err := db.New(s.db).ListPosts(ctx, db.ListPostsParams{
+	//     BeforeCreatedAt: cursor.CreatedAt,
+	//     BeforeID:        cursor.ID,
+	//     PageSize:        size + 1,
+	// })
+	// if err != nil {
+	//     return nil, status.Errorf(codes.Internal, "failed to list posts: %v", err)
+	// }
+	// rows, next := pagination.Page(rows, size, func(row db.Post) pagination.Cursor {
+	//     return pagination.Cursor{CreatedAt: row.CreatedAt, ID: row.ID}
+	// })
+
+	return &postserviceV1.ListPostResponse{
+		Posts: []*postserviceV1.Post{
+			{
+				Id:        "sample-1",
+				Name:      "Sample Post 1",
+				CreatedAt: timestamppb.Now(),
+				UpdatedAt: timestamppb.Now(),
+			},
+		},
+	}, nil
+}
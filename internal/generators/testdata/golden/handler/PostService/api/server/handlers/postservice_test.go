@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	postserviceV1 "github.com/test/test-project/api/proto/postservice/v1"
+	"google.golang.org/grpc"
+)
+
+func TestPostService(t *testing.T) {
+	conn := startServer(t, func(g *grpc.Server) {
+		postserviceV1.RegisterPostServiceServer(g, NewPostServiceServer(&fakeDB{}))
+	})
+	client := postserviceV1.NewPostServiceClient(conn)
+	ctx := context.Background()
+
+	t.Run("CreatePost", func(t *testing.T) {
+		resp, err := client.CreatePost(ctx, &postserviceV1.CreatePostRequest{Name: "Test Post"})
+		if err != nil {
+			t.Fatalf("CreatePost() error = %v", err)
+		}
+		if resp.GetPost().GetName() != "Test Post" {
+			t.Errorf("CreatePost() name = %q, want %q", resp.GetPost().GetName(), "Test Post")
+		}
+		if resp.GetPost().GetId() == "" {
+			t.Error("CreatePost() returned an empty id")
+		}
+	})
+
+	t.Run("GetPost", func(t *testing.T) {
+		resp, err := client.GetPost(ctx, &postserviceV1.GetPostRequest{Id: "test-id"})
+		if err != nil {
+			t.Fatalf("GetPost() error = %v", err)
+		}
+		if resp.GetPost().GetId() != "test-id" {
+			t.Errorf("GetPost() id = %q, want %q", resp.GetPost().GetId(), "test-id")
+		}
+	})
+
+	t.Run("UpdatePost", func(t *testing.T) {
+		resp, err := client.UpdatePost(ctx, &postserviceV1.UpdatePostRequest{Id: "test-id", Name: "Updated Post"})
+		if err != nil {
+			t.Fatalf("UpdatePost() error = %v", err)
+		}
+		if resp.GetPost().GetId() != "test-id" {
+			t.Errorf("UpdatePost() id = %q, want %q", resp.GetPost().GetId(), "test-id")
+		}
+		if resp.GetPost().GetName() != "Updated Post" {
+			t.Errorf("UpdatePost() name = %q, want %q", resp.GetPost().GetName(), "Updated Post")
+		}
+	})
+
+	t.Run("DeletePost", func(t *testing.T) {
+		resp, err := client.DeletePost(ctx, &postserviceV1.DeletePostRequest{Id: "test-id"})
+		if err != nil {
+			t.Fatalf("DeletePost() error = %v", err)
+		}
+		if !resp.GetSuccess() {
+			t.Error("DeletePost() success = false")
+		}
+	})
+
+	t.Run("ListPost", func(t *testing.T) {
+		resp, err := client.ListPost(ctx, &postserviceV1.ListPostRequest{PageSize: 10})
+		if err != nil {
+			t.Fatalf("ListPost() error = %v", err)
+		}
+		if len(resp.GetPosts()) == 0 {
+			t.Error("ListPost() returned no Posts")
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"context"
+
+	postserviceV1 "github.com/test/test-project/api/proto/postservice/v1"
+)
+
+type PostService struct {
+	*App
+	client postserviceV1.PostServiceClient
+}
+
+func NewPostService(app *App) *PostService {
+	return &PostService{
+		App:    app,
+		client: postserviceV1.NewPostServiceClient(app.API.Conn()),
+	}
+}
+
+// TODO: Implement Fiber handlers that call the helpers below with
+// c.UserContext(), which forwards the request ID to the API logs, then
+// register them in routing.RegisterRoutes with handlers.NewPostService(app).
+
+// CreatePost calls the CreatePost RPC.
+func (h *PostService) CreatePost(ctx context.Context, req *postserviceV1.CreatePostRequest) (*postserviceV1.CreatePostResponse, error) {
+	return h.client.CreatePost(ctx, req)
+}
+
+// GetPost calls the GetPost RPC.
+func (h *PostService) GetPost(ctx context.Context, req *postserviceV1.GetPostRequest) (*postserviceV1.GetPostResponse, error) {
+	return h.client.GetPost(ctx, req)
+}
+
+// UpdatePost calls the UpdatePost RPC.
+func (h *PostService) UpdatePost(ctx context.Context, req *postserviceV1.UpdatePostRequest) (*postserviceV1.UpdatePostResponse, error) {
+	return h.client.UpdatePost(ctx, req)
+}
+
+// DeletePost calls the DeletePost RPC.
+func (h *PostService) DeletePost(ctx context.Context, req *postserviceV1.DeletePostRequest) (*postserviceV1.DeletePostResponse, error) {
+	return h.client.DeletePost(ctx, req)
+}
+
+// ListPost calls the ListPost RPC.
+func (h *PostService) ListPost(ctx context.Context, req *postserviceV1.ListPostRequest) (*postserviceV1.ListPostResponse, error) {
+	return h.client.ListPost(ctx, req)
+}
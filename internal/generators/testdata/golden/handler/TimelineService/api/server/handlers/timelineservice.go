@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"io"
+
+	"github.com/test/test-project/api/db"
+	timelineserviceV1 "github.com/test/test-project/api/proto/timelineservice/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type timelineserviceServiceServer struct {
+	timelineserviceV1.UnimplementedTimelineServiceServer
+	db db.DBTX
+}
+
+func NewTimelineServiceServer(dbtx db.DBTX) timelineserviceV1.TimelineServiceServer {
+	return &timelineserviceServiceServer{db: dbtx}
+}
+
+func (s *timelineserviceServiceServer) GetTimeline(ctx context.Context, req *timelineserviceV1.GetTimelineRequest) (*timelineserviceV1.GetTimelineResponse, error) {
+	// TODO: Implement get logic
+	// Example:
+	// uuid, err := parseUUID(req.Id)
+	// if err != nil {
+	//     return nil, status.Errorf(codes.InvalidArgument, "invalid ID: %v", err)
+	// }
+	//
+	// result, err := db.New(s.db).GetTimelineById(ctx, uuid)
+	// if err != nil {
+	//     return nil, status.Errorf(codes.NotFound, "timeline not found: %v", err)
+	// }
+
+	return &timelineserviceV1.GetTimelineResponse{
+		Timeline: &timelineserviceV1.Timeline{
+			Id:        req.Id,
+			Name:      "Sample Timeline",
+			CreatedAt: timestamppb.Now(),
+			UpdatedAt: timestamppb.Now(),
+		},
+	}, nil
+}
+
+func (s *timelineserviceServiceServer) ListTimeline(req *timelineserviceV1.ListTimelineRequest, stream grpc.ServerStreamingServer[timelineserviceV1.ListTimelineResponse]) error {
+	// TODO: Implement streaming list logic, sending one response per timeline
+	timelines := []*timelineserviceV1.Timeline{
+		{
+			Id:        "sample-1",
+			Name:      "Sample Timeline 1",
+			CreatedAt: timestamppb.Now(),
+			UpdatedAt: timestamppb.Now(),
+		},
+	}
+
+	for _, timeline := range timelines {
+		if err := stream.Send(&timelineserviceV1.ListTimelineResponse{Timeline: timeline}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *timelineserviceServiceServer) UploadTimeline(stream grpc.ClientStreamingServer[timelineserviceV1.UploadTimelineRequest, timelineserviceV1.UploadTimelineResponse]) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			// TODO: Build the response from the received requests
+			return stream.SendAndClose(&timelineserviceV1.UploadTimelineResponse{})
+		}
+		if err != nil {
+			return err
+		}
+
+		// TODO: Handle each request
+		_ = req
+	}
+}
+
+func (s *timelineserviceServiceServer) ChatTimeline(stream grpc.BidiStreamingServer[timelineserviceV1.ChatTimelineRequest, timelineserviceV1.ChatTimelineResponse]) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// TODO: Handle each request and send responses
+		_ = req
+		if err := stream.Send(&timelineserviceV1.ChatTimelineResponse{}); err != nil {
+			return err
+		}
+	}
+}
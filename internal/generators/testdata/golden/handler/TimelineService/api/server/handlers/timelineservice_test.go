@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	timelineserviceV1 "github.com/test/test-project/api/proto/timelineservice/v1"
+	"google.golang.org/grpc"
+)
+
+func TestTimelineService(t *testing.T) {
+	conn := startServer(t, func(g *grpc.Server) {
+		timelineserviceV1.RegisterTimelineServiceServer(g, NewTimelineServiceServer(&fakeDB{}))
+	})
+	client := timelineserviceV1.NewTimelineServiceClient(conn)
+	ctx := context.Background()
+
+	t.Run("GetTimeline", func(t *testing.T) {
+		resp, err := client.GetTimeline(ctx, &timelineserviceV1.GetTimelineRequest{Id: "test-id"})
+		if err != nil {
+			t.Fatalf("GetTimeline() error = %v", err)
+		}
+		if resp.GetTimeline().GetId() != "test-id" {
+			t.Errorf("GetTimeline() id = %q, want %q", resp.GetTimeline().GetId(), "test-id")
+		}
+	})
+
+	t.Run("ListTimeline", func(t *testing.T) {
+		stream, err := client.ListTimeline(ctx, &timelineserviceV1.ListTimelineRequest{})
+		if err != nil {
+			t.Fatalf("ListTimeline() error = %v", err)
+		}
+
+		var received int
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("ListTimeline() Recv error = %v", err)
+			}
+			if resp.GetTimeline() == nil {
+				t.Errorf("ListTimeline() streamed a response without a Timeline")
+			}
+			received++
+		}
+		if received == 0 {
+			t.Error("ListTimeline() streamed no responses")
+		}
+	})
+
+	t.Run("UploadTimeline", func(t *testing.T) {
+		stream, err := client.UploadTimeline(ctx)
+		if err != nil {
+			t.Fatalf("UploadTimeline() error = %v", err)
+		}
+		if err := stream.Send(&timelineserviceV1.UploadTimelineRequest{}); err != nil {
+			t.Fatalf("UploadTimeline() Send error = %v", err)
+		}
+
+		resp, err := stream.CloseAndRecv()
+		if err != nil {
+			t.Fatalf("UploadTimeline() CloseAndRecv error = %v", err)
+		}
+		if resp == nil {
+			t.Error("UploadTimeline() returned a nil response")
+		}
+	})
+
+	t.Run("ChatTimeline", func(t *testing.T) {
+		stream, err := client.ChatTimeline(ctx)
+		if err != nil {
+			t.Fatalf("ChatTimeline() error = %v", err)
+		}
+		if err := stream.Send(&timelineserviceV1.ChatTimelineRequest{}); err != nil {
+			t.Fatalf("ChatTimeline() Send error = %v", err)
+		}
+		if err := stream.CloseSend(); err != nil {
+			t.Fatalf("ChatTimeline() CloseSend error = %v", err)
+		}
+
+		var received int
+		for {
+			_, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("ChatTimeline() Recv error = %v", err)
+			}
+			received++
+		}
+		if received != 1 {
+			t.Errorf("ChatTimeline() received %d responses, want 1", received)
+		}
+	})
+}
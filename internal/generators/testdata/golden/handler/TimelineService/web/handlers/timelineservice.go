@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	timelineserviceV1 "github.com/test/test-project/api/proto/timelineservice/v1"
+)
+
+type TimelineService struct {
+	*App
+	client timelineserviceV1.TimelineServiceClient
+}
+
+func NewTimelineService(app *App) *TimelineService {
+	return &TimelineService{
+		App:    app,
+		client: timelineserviceV1.NewTimelineServiceClient(app.API.Conn()),
+	}
+}
+
+// TODO: Implement Fiber handlers that call the helpers below with
+// c.UserContext(), which forwards the request ID to the API logs, then
+// register them in routing.RegisterRoutes with handlers.NewTimelineService(app).
+
+// GetTimeline calls the GetTimeline RPC.
+func (h *TimelineService) GetTimeline(ctx context.Context, req *timelineserviceV1.GetTimelineRequest) (*timelineserviceV1.GetTimelineResponse, error) {
+	return h.client.GetTimeline(ctx, req)
+}
+
+// ListTimeline calls fn for every response streamed by the API until the stream
+// ends, ctx is cancelled or fn returns an error.
+func (h *TimelineService) ListTimeline(ctx context.Context, req *timelineserviceV1.ListTimelineRequest, fn func(*timelineserviceV1.ListTimelineResponse) error) error {
+	stream, err := h.client.ListTimeline(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// UploadTimeline streams every request to the API and returns its single response.
+func (h *TimelineService) UploadTimeline(ctx context.Context, reqs []*timelineserviceV1.UploadTimelineRequest) (*timelineserviceV1.UploadTimelineResponse, error) {
+	stream, err := h.client.UploadTimeline(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, req := range reqs {
+		if err := stream.Send(req); err != nil {
+			return nil, err
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
+
+// ChatTimeline sends requests from reqs while calling fn for every response.
+// Close reqs to end the stream; cancel ctx to abort it. The stream is
+// cancelled when ChatTimeline returns, so the sending goroutine never outlives it.
+func (h *TimelineService) ChatTimeline(ctx context.Context, reqs <-chan *timelineserviceV1.ChatTimelineRequest, fn func(*timelineserviceV1.ChatTimelineResponse) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := h.client.ChatTimeline(ctx)
+	if err != nil {
+		return err
+	}
+
+	sendErr := make(chan error, 1)
+	go func() {
+		defer stream.CloseSend()
+		for {
+			select {
+			case <-stream.Context().Done():
+				// Recv reports why the stream ended
+				sendErr <- nil
+				return
+			case req, ok := <-reqs:
+				if !ok {
+					sendErr <- nil
+					return
+				}
+				if err := stream.Send(req); err != nil {
+					// Send returns io.EOF once the API ends the stream; Recv
+					// then reports the actual status
+					if errors.Is(err, io.EOF) {
+						err = nil
+					}
+					sendErr <- err
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return <-sendErr
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(resp); err != nil {
+			return err
+		}
+	}
+}
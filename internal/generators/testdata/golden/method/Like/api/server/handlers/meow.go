@@ -0,0 +1,657 @@
+package handlers
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"TEMPLATE_MODULE_PATH/api/content"
+	"TEMPLATE_MODULE_PATH/api/db"
+	meowV1 "TEMPLATE_MODULE_PATH/api/proto/meow/v1"
+	"TEMPLATE_MODULE_PATH/api/server/auth"
+	"TEMPLATE_MODULE_PATH/api/server/pagination"
+	"TEMPLATE_MODULE_PATH/api/server/pubsub"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TxDB is a db.DBTX that can also begin transactions, such as *pgxpool.Pool
+type TxDB interface {
+	db.DBTX
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// MeowEvent is a meow created, published to WatchMeows streams along with
+// the name of its author, so that they don't query the database for it
+type MeowEvent struct {
+	Meow        db.Meow
+	Username    string
+	DisplayName string
+}
+
+type meowServiceServer struct {
+	meowV1.UnimplementedMeowServiceServer
+	db TxDB
+	// meows publishes the meows created, to WatchMeows streams
+	meows *pubsub.Broker[MeowEvent]
+}
+
+func NewMeowerServer(dbtx TxDB, meows *pubsub.Broker[MeowEvent]) meowV1.MeowServiceServer {
+	return &meowServiceServer{db: dbtx, meows: meows}
+}
+
+// meowWithCounts holds the columns selected by the queries listing meows: the
+// meow, its author, its counts and whether the viewer liked or reposted it.
+// Their rows convert to it, as in meowWithCounts(row).
+type meowWithCounts struct {
+	Meow         db.Meow
+	Username     string
+	DisplayName  string
+	LikeCount    int64
+	ReplyCount   int64
+	RepostCount  int64
+	LikedByMe    bool
+	RepostedByMe bool
+}
+
+// Helper function to convert a DB meow and the name of its author to a proto meow
+func dbMeowToProto(meow db.Meow, username, displayName string) *meowV1.Meow {
+	protoMeow := &meowV1.Meow{
+		Id:                hex.EncodeToString(meow.ID.Bytes[:]),
+		Content:           meow.Content,
+		CreatedAt:         timestamppb.New(meow.CreatedAt.Time),
+		AuthorId:          hex.EncodeToString(meow.UserID.Bytes[:]),
+		AuthorUsername:    username,
+		AuthorDisplayName: displayName,
+	}
+
+	if meow.ParentID.Valid {
+		protoMeow.ParentId = hex.EncodeToString(meow.ParentID.Bytes[:])
+	}
+
+	return protoMeow
+}
+
+// Helper function to convert a listed meow to a proto meow
+func dbMeowWithCountsToProto(row meowWithCounts) *meowV1.Meow {
+	meow := dbMeowToProto(row.Meow, row.Username, row.DisplayName)
+	meow.LikeCount = row.LikeCount
+	meow.ReplyCount = row.ReplyCount
+	meow.RepostCount = row.RepostCount
+	meow.LikedByMe = row.LikedByMe
+	meow.RepostedByMe = row.RepostedByMe
+	return meow
+}
+
+// Helper function to get a meow as seen by the caller
+func (s *meowServiceServer) showMeow(ctx context.Context, id pgtype.UUID) (db.ShowMeowRow, error) {
+	viewerID, _ := auth.UserID(ctx)
+	row, err := db.New(s.db).ShowMeow(ctx, db.ShowMeowParams{ID: id, ViewerID: viewerID})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.ShowMeowRow{}, status.Errorf(codes.NotFound, "meow not found")
+	}
+	if err != nil {
+		return db.ShowMeowRow{}, status.Errorf(codes.Internal, "failed to get meow: %v", err)
+	}
+	return row, nil
+}
+
+// Helper function to get a meow the caller may change, as its author
+func (s *meowServiceServer) getOwnMeow(ctx context.Context, id string) (db.ShowMeowRow, error) {
+	uuid, err := parseUUID(id)
+	if err != nil {
+		return db.ShowMeowRow{}, status.Errorf(codes.InvalidArgument, "invalid meow ID: %v", err)
+	}
+
+	row, err := s.showMeow(ctx, uuid)
+	if err != nil {
+		return db.ShowMeowRow{}, err
+	}
+
+	if callerID, ok := auth.UserID(ctx); !ok || callerID != row.Meow.UserID {
+		return db.ShowMeowRow{}, status.Errorf(codes.PermissionDenied, "you can only change your own meows")
+	}
+	return row, nil
+}
+
+// Helper function to store the #tags and @mentions of the content of a meow,
+// replacing the previous ones. Mentions of unknown users are ignored. Run it
+// in the transaction writing the meow.
+func setMeowLinks(ctx context.Context, queries *db.Queries, meowID pgtype.UUID, text string) error {
+	if err := queries.DeleteMeowTags(ctx, meowID); err != nil {
+		return err
+	}
+	if tags := content.Tags(text); tags != nil {
+		if err := queries.AddMeowTags(ctx, db.AddMeowTagsParams{MeowID: meowID, Tags: tags}); err != nil {
+			return err
+		}
+	}
+
+	if err := queries.DeleteMeowMentions(ctx, meowID); err != nil {
+		return err
+	}
+	if mentions := content.Mentions(text); mentions != nil {
+		if err := queries.AddMeowMentions(ctx, db.AddMeowMentionsParams{MeowID: meowID, Usernames: mentions}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Helper function to run change, recording or removing a like or repost of
+// the caller, and return the meow with its new counts
+func (s *meowServiceServer) interact(ctx context.Context, id string, change func(q *db.Queries, userID, meowID pgtype.UUID) error) (*meowV1.Meow, error) {
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "log in to like or repost meows")
+	}
+
+	meowID, err := parseUUID(id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid meow ID: %v", err)
+	}
+
+	if err := change(db.New(s.db), userID, meowID); err != nil {
+		if strings.Contains(err.Error(), "foreign key") {
+			return nil, status.Errorf(codes.NotFound, "meow not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update meow: %v", err)
+	}
+
+	row, err := s.showMeow(ctx, meowID)
+	if err != nil {
+		return nil, err
+	}
+	return dbMeowWithCountsToProto(meowWithCounts(row)), nil
+}
+
+func (s *meowServiceServer) CreateMeow(ctx context.Context, req *meowV1.CreateMeowRequest) (*meowV1.CreateMeowResponse, error) {
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "log in to meow")
+	}
+
+	var parentID pgtype.UUID
+	if req.ParentId != "" {
+		var err error
+		if parentID, err = parseUUID(req.ParentId); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid parent meow ID: %v", err)
+		}
+	}
+
+	queries := db.New(s.db)
+	author, err := queries.GetUserById(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get author: %v", err)
+	}
+
+	var meow db.Meow
+	err = pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		q := queries.WithTx(tx)
+		var err error
+		meow, err = q.CreateMeow(ctx, db.CreateMeowParams{
+			UserID:   userID,
+			Content:  req.Content,
+			ParentID: parentID,
+		})
+		if err != nil {
+			return err
+		}
+		return setMeowLinks(ctx, q, meow.ID, meow.Content)
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "foreign key") {
+			return nil, status.Errorf(codes.NotFound, "parent meow not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create meow: %v", err)
+	}
+
+	// Only once committed, so watchers never see a meow that was rolled back
+	s.meows.Publish(MeowEvent{Meow: meow, Username: author.Username, DisplayName: author.DisplayName})
+
+	return &meowV1.CreateMeowResponse{
+		Meow: dbMeowToProto(meow, author.Username, author.DisplayName),
+	}, nil
+}
+
+// GetMeow gets a meow by ID
+func (s *meowServiceServer) GetMeow(ctx context.Context, req *meowV1.GetMeowRequest) (*meowV1.GetMeowResponse, error) {
+	uuid, err := parseUUID(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid meow ID: %v", err)
+	}
+
+	row, err := s.showMeow(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &meowV1.GetMeowResponse{
+		Meow: dbMeowWithCountsToProto(meowWithCounts(row)),
+	}, nil
+}
+
+func (s *meowServiceServer) IndexMeow(ctx context.Context, req *meowV1.IndexMeowRequest) (*meowV1.IndexMeowResponse, error) {
+	cursor, err := pagination.Decode(req.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	size := pagination.Size(req.PageSize)
+
+	viewerID, _ := auth.UserID(ctx)
+	meows, err := db.New(s.db).IndexMeows(ctx, db.IndexMeowsParams{
+		ViewerID:        viewerID,
+		BeforeCreatedAt: cursor.CreatedAt,
+		BeforeID:        cursor.ID,
+		PageSize:        size + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	meows, next := pagination.Page(meows, size, func(row db.IndexMeowsRow) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: row.Meow.CreatedAt, ID: row.Meow.ID}
+	})
+
+	var resp []*meowV1.Meow
+	for _, row := range meows {
+		resp = append(resp, dbMeowWithCountsToProto(meowWithCounts(row)))
+	}
+
+	return &meowV1.IndexMeowResponse{Meows: resp, NextPageToken: next}, nil
+}
+
+// ListMeowsByUser lists the meows of a user, newest first
+func (s *meowServiceServer) ListMeowsByUser(ctx context.Context, req *meowV1.ListMeowsByUserRequest) (*meowV1.ListMeowsByUserResponse, error) {
+	cursor, err := pagination.Decode(req.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	size := pagination.Size(req.PageSize)
+
+	queries := db.New(s.db)
+	user, err := queries.GetUserByUsername(ctx, req.Username)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+
+	viewerID, _ := auth.UserID(ctx)
+	meows, err := queries.ListMeowsByUser(ctx, db.ListMeowsByUserParams{
+		ViewerID:        viewerID,
+		UserID:          user.ID,
+		BeforeCreatedAt: cursor.CreatedAt,
+		BeforeID:        cursor.ID,
+		PageSize:        size + 1,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list meows: %v", err)
+	}
+	meows, next := pagination.Page(meows, size, func(row db.ListMeowsByUserRow) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: row.Meow.CreatedAt, ID: row.Meow.ID}
+	})
+
+	var resp []*meowV1.Meow
+	for _, row := range meows {
+		resp = append(resp, dbMeowWithCountsToProto(meowWithCounts(row)))
+	}
+
+	return &meowV1.ListMeowsByUserResponse{Meows: resp, NextPageToken: next}, nil
+}
+
+// ListMeowsByParent lists the replies to a meow, newest first
+func (s *meowServiceServer) ListMeowsByParent(ctx context.Context, req *meowV1.ListMeowsByParentRequest) (*meowV1.ListMeowsByParentResponse, error) {
+	parentID, err := parseUUID(req.ParentId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid parent meow ID: %v", err)
+	}
+
+	cursor, err := pagination.Decode(req.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	size := pagination.Size(req.PageSize)
+
+	viewerID, _ := auth.UserID(ctx)
+	meows, err := db.New(s.db).ListMeowsByParent(ctx, db.ListMeowsByParentParams{
+		ViewerID:        viewerID,
+		ParentID:        parentID,
+		BeforeCreatedAt: cursor.CreatedAt,
+		BeforeID:        cursor.ID,
+		PageSize:        size + 1,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list replies: %v", err)
+	}
+	meows, next := pagination.Page(meows, size, func(row db.ListMeowsByParentRow) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: row.Meow.CreatedAt, ID: row.Meow.ID}
+	})
+
+	var resp []*meowV1.Meow
+	for _, row := range meows {
+		resp = append(resp, dbMeowWithCountsToProto(meowWithCounts(row)))
+	}
+
+	return &meowV1.ListMeowsByParentResponse{Meows: resp, NextPageToken: next}, nil
+}
+
+// HomeTimeline lists the meows of the caller and of the users they follow,
+// newest first
+func (s *meowServiceServer) HomeTimeline(ctx context.Context, req *meowV1.HomeTimelineRequest) (*meowV1.HomeTimelineResponse, error) {
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "log in to see your timeline")
+	}
+
+	cursor, err := pagination.Decode(req.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	size := pagination.Size(req.PageSize)
+
+	meows, err := db.New(s.db).HomeTimeline(ctx, db.HomeTimelineParams{
+		UserID:          userID,
+		BeforeCreatedAt: cursor.CreatedAt,
+		BeforeID:        cursor.ID,
+		PageSize:        size + 1,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list meows: %v", err)
+	}
+	meows, next := pagination.Page(meows, size, func(row db.HomeTimelineRow) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: row.Meow.CreatedAt, ID: row.Meow.ID}
+	})
+
+	var resp []*meowV1.Meow
+	for _, row := range meows {
+		resp = append(resp, dbMeowWithCountsToProto(meowWithCounts(row)))
+	}
+
+	return &meowV1.HomeTimelineResponse{Meows: resp, NextPageToken: next}, nil
+}
+
+// ListMeowsByTag lists the meows tagged with a #tag, newest first
+func (s *meowServiceServer) ListMeowsByTag(ctx context.Context, req *meowV1.ListMeowsByTagRequest) (*meowV1.ListMeowsByTagResponse, error) {
+	tag := strings.ToLower(strings.TrimPrefix(req.Tag, "#"))
+	if tag == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "tag is required")
+	}
+
+	cursor, err := pagination.Decode(req.PageToken)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	size := pagination.Size(req.PageSize)
+
+	viewerID, _ := auth.UserID(ctx)
+	meows, err := db.New(s.db).ListMeowsByTag(ctx, db.ListMeowsByTagParams{
+		ViewerID:        viewerID,
+		Tag:             tag,
+		BeforeCreatedAt: cursor.CreatedAt,
+		BeforeID:        cursor.ID,
+		PageSize:        size + 1,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list meows: %v", err)
+	}
+	meows, next := pagination.Page(meows, size, func(row db.ListMeowsByTagRow) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: row.Meow.CreatedAt, ID: row.Meow.ID}
+	})
+
+	var resp []*meowV1.Meow
+	for _, row := range meows {
+		resp = append(resp, dbMeowWithCountsToProto(meowWithCounts(row)))
+	}
+
+	return &meowV1.ListMeowsByTagResponse{Meows: resp, NextPageToken: next}, nil
+}
+
+// SearchMeows lists the meows matching a full-text query, best matches first
+func (s *meowServiceServer) SearchMeows(ctx context.Context, req *meowV1.SearchMeowsRequest) (*meowV1.SearchMeowsResponse, error) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "query is required")
+	}
+
+	// Page tokens are only valid for the query they were returned for
+	cursor, err := pagination.DecodeRanked(req.PageToken, query)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	size := pagination.Size(req.PageSize)
+
+	viewerID, _ := auth.UserID(ctx)
+	meows, err := db.New(s.db).SearchMeows(ctx, db.SearchMeowsParams{
+		ViewerID:        viewerID,
+		Query:           query,
+		BeforeRank:      cursor.Rank,
+		BeforeCreatedAt: cursor.CreatedAt,
+		BeforeID:        cursor.ID,
+		PageSize:        size + 1,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to search meows: %v", err)
+	}
+	meows, next := pagination.Page(meows, size, func(row db.SearchMeowsRow) pagination.Cursor {
+		return pagination.Cursor{
+			Rank:      pgtype.Float4{Float32: row.Rank, Valid: true},
+			Query:     query,
+			CreatedAt: row.Meow.CreatedAt,
+			ID:        row.Meow.ID,
+		}
+	})
+
+	var resp []*meowV1.Meow
+	for _, row := range meows {
+		resp = append(resp, dbMeowWithCountsToProto(meowWithCounts{
+			Meow:         row.Meow,
+			Username:     row.Username,
+			DisplayName:  row.DisplayName,
+			LikeCount:    row.LikeCount,
+			ReplyCount:   row.ReplyCount,
+			RepostCount:  row.RepostCount,
+			LikedByMe:    row.LikedByMe,
+			RepostedByMe: row.RepostedByMe,
+		}))
+	}
+
+	return &meowV1.SearchMeowsResponse{Meows: resp, NextPageToken: next}, nil
+}
+
+// WatchMeows streams the meows created from now on, oldest first. Streams
+// resuming from the event_id of a previous one first replay the meows created
+// since. Home streams only carry the meows of the caller and of the users they
+// follow, like HomeTimeline; follows made meanwhile apply once resumed. Live
+// meows are sent as published, new and without likes, reposts or replies.
+func (s *meowServiceServer) WatchMeows(req *meowV1.WatchMeowsRequest, stream grpc.ServerStreamingServer[meowV1.WatchMeowsResponse]) error {
+	ctx := stream.Context()
+	viewerID, ok := auth.UserID(ctx)
+	if req.Home && !ok {
+		return status.Errorf(codes.Unauthenticated, "log in to watch your timeline")
+	}
+
+	// Event IDs are the cursors of the meows in (created_at, id) order
+	after, err := pagination.Decode(req.AfterEventId)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid event ID")
+	}
+
+	// The authors of home streams, loaded once rather than for every meow
+	queries := db.New(s.db)
+	var authors map[pgtype.UUID]bool
+	if req.Home {
+		followed, err := queries.ListFollowedIDs(ctx, viewerID)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to list follows: %v", err)
+		}
+		authors = map[pgtype.UUID]bool{viewerID: true}
+		for _, id := range followed {
+			authors[id] = true
+		}
+	}
+
+	// Subscribe before replaying, not to miss the meows created meanwhile
+	events, unsubscribe := s.meows.Subscribe()
+	defer unsubscribe()
+
+	send := func(row meowWithCounts) error {
+		return stream.Send(&meowV1.WatchMeowsResponse{
+			Meow:    dbMeowWithCountsToProto(row),
+			EventId: pagination.Cursor{CreatedAt: row.Meow.CreatedAt, ID: row.Meow.ID}.Token(),
+		})
+	}
+
+	// Meows are published once committed, not in created_at order, so live
+	// meows are only checked against the replayed ones, which they may repeat
+	replayed := make(map[pgtype.UUID]bool)
+
+	for after.ID.Valid {
+		meows, err := queries.ListMeowsSince(ctx, db.ListMeowsSinceParams{
+			ViewerID:       viewerID,
+			AfterCreatedAt: after.CreatedAt,
+			AfterID:        after.ID,
+			Home:           req.Home,
+			PageSize:       pagination.MaxSize,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to list meows: %v", err)
+		}
+		for _, row := range meows {
+			replayed[row.Meow.ID] = true
+			if err := send(meowWithCounts(row)); err != nil {
+				return err
+			}
+		}
+		if len(meows) < pagination.MaxSize {
+			break
+		}
+		last := meows[len(meows)-1].Meow
+		after = pagination.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return status.Errorf(codes.Unavailable, "stream fell behind, resume it from the last event ID")
+			}
+			// Skip the meows already replayed, each is published once
+			if replayed[event.Meow.ID] {
+				delete(replayed, event.Meow.ID)
+				continue
+			}
+			if req.Home && !authors[event.Meow.UserID] {
+				continue
+			}
+
+			if err := send(meowWithCounts{Meow: event.Meow, Username: event.Username, DisplayName: event.DisplayName}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// UpdateMeow changes the content of a meow of the caller
+func (s *meowServiceServer) UpdateMeow(ctx context.Context, req *meowV1.UpdateMeowRequest) (*meowV1.UpdateMeowResponse, error) {
+	row, err := s.getOwnMeow(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	var meow db.Meow
+	err = pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		q := db.New(s.db).WithTx(tx)
+		var err error
+		meow, err = q.UpdateMeow(ctx, db.UpdateMeowParams{
+			ID:      row.Meow.ID,
+			Content: req.Content,
+		})
+		if err != nil {
+			return err
+		}
+		return setMeowLinks(ctx, q, meow.ID, meow.Content)
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update meow: %v", err)
+	}
+
+	row.Meow = meow
+	return &meowV1.UpdateMeowResponse{
+		Meow: dbMeowWithCountsToProto(meowWithCounts(row)),
+	}, nil
+}
+
+// DeleteMeow deletes a meow of the caller
+func (s *meowServiceServer) DeleteMeow(ctx context.Context, req *meowV1.DeleteMeowRequest) (*meowV1.DeleteMeowResponse, error) {
+	row, err := s.getOwnMeow(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.New(s.db).DeleteMeow(ctx, row.Meow.ID); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete meow: %v", err)
+	}
+
+	return &meowV1.DeleteMeowResponse{}, nil
+}
+
+// LikeMeow likes a meow as the caller, doing nothing if they already do
+func (s *meowServiceServer) LikeMeow(ctx context.Context, req *meowV1.LikeMeowRequest) (*meowV1.LikeMeowResponse, error) {
+	meow, err := s.interact(ctx, req.Id, func(q *db.Queries, userID, meowID pgtype.UUID) error {
+		return q.LikeMeow(ctx, db.LikeMeowParams{UserID: userID, MeowID: meowID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &meowV1.LikeMeowResponse{Meow: meow}, nil
+}
+
+// UnlikeMeow removes the like of the caller from a meow
+func (s *meowServiceServer) UnlikeMeow(ctx context.Context, req *meowV1.UnlikeMeowRequest) (*meowV1.UnlikeMeowResponse, error) {
+	meow, err := s.interact(ctx, req.Id, func(q *db.Queries, userID, meowID pgtype.UUID) error {
+		return q.UnlikeMeow(ctx, db.UnlikeMeowParams{UserID: userID, MeowID: meowID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &meowV1.UnlikeMeowResponse{Meow: meow}, nil
+}
+
+// RepostMeow reposts a meow as the caller, doing nothing if they already did
+func (s *meowServiceServer) RepostMeow(ctx context.Context, req *meowV1.RepostMeowRequest) (*meowV1.RepostMeowResponse, error) {
+	meow, err := s.interact(ctx, req.Id, func(q *db.Queries, userID, meowID pgtype.UUID) error {
+		return q.RepostMeow(ctx, db.RepostMeowParams{UserID: userID, MeowID: meowID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &meowV1.RepostMeowResponse{Meow: meow}, nil
+}
+
+// UnrepostMeow removes the repost of the caller of a meow
+func (s *meowServiceServer) UnrepostMeow(ctx context.Context, req *meowV1.UnrepostMeowRequest) (*meowV1.UnrepostMeowResponse, error) {
+	meow, err := s.interact(ctx, req.Id, func(q *db.Queries, userID, meowID pgtype.UUID) error {
+		return q.UnrepostMeow(ctx, db.UnrepostMeowParams{UserID: userID, MeowID: meowID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &meowV1.UnrepostMeowResponse{Meow: meow}, nil
+}
+
+func (s *meowServiceServer) Like(ctx context.Context, req *meowV1.LikeRequest) (*meowV1.LikeResponse, error) {
+	// TODO: Implement Like logic
+	return &meowV1.LikeResponse{}, nil
+}
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"context"
+
+	meowV1 "TEMPLATE_MODULE_PATH/api/proto/meow/v1"
+	meowV2 "TEMPLATE_MODULE_PATH/api/proto/meow/v2"
+	"google.golang.org/grpc"
+)
+
+// meowServiceV2Server serves v2 of MeowService. Every RPC delegates to the
+// v1 implementation, converting messages between versions: replace a
+// method's body to change its behaviour in v2 only.
+type meowServiceV2Server struct {
+	meowV2.UnimplementedMeowServiceServer
+	v1 meowV1.MeowServiceServer
+}
+
+func NewMeowServiceV2Server(v1 meowV1.MeowServiceServer) meowV2.MeowServiceServer {
+	return &meowServiceV2Server{v1: v1}
+}
+
+func (s *meowServiceV2Server) CreateMeow(ctx context.Context, req *meowV2.CreateMeowRequest) (*meowV2.CreateMeowResponse, error) {
+	v1Req := &meowV1.CreateMeowRequest{}
+	if err := convertMessage(req, v1Req); err != nil {
+		return nil, err
+	}
+	v1Resp, err := s.v1.CreateMeow(ctx, v1Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV2.CreateMeowResponse{}
+	return resp, convertMessage(v1Resp, resp)
+}
+
+func (s *meowServiceV2Server) GetMeow(ctx context.Context, req *meowV2.GetMeowRequest) (*meowV2.GetMeowResponse, error) {
+	v1Req := &meowV1.GetMeowRequest{}
+	if err := convertMessage(req, v1Req); err != nil {
+		return nil, err
+	}
+	v1Resp, err := s.v1.GetMeow(ctx, v1Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV2.GetMeowResponse{}
+	return resp, convertMessage(v1Resp, resp)
+}
+
+func (s *meowServiceV2Server) IndexMeow(ctx context.Context, req *meowV2.IndexMeowRequest) (*meowV2.IndexMeowResponse, error) {
+	v1Req := &meowV1.IndexMeowRequest{}
+	if err := convertMessage(req, v1Req); err != nil {
+		return nil, err
+	}
+	v1Resp, err := s.v1.IndexMeow(ctx, v1Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV2.IndexMeowResponse{}
+	return resp, convertMessage(v1Resp, resp)
+}
+
+func (s *meowServiceV2Server) ListMeowsByUser(ctx context.Context, req *meowV2.ListMeowsByUserRequest) (*meowV2.ListMeowsByUserResponse, error) {
+	v1Req := &meowV1.ListMeowsByUserRequest{}
+	if err := convertMessage(req, v1Req); err != nil {
+		return nil, err
+	}
+	v1Resp, err := s.v1.ListMeowsByUser(ctx, v1Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV2.ListMeowsByUserResponse{}
+	return resp, convertMessage(v1Resp, resp)
+}
+
+func (s *meowServiceV2Server) ListMeowsByParent(ctx context.Context, req *meowV2.ListMeowsByParentRequest) (*meowV2.ListMeowsByParentResponse, error) {
+	v1Req := &meowV1.ListMeowsByParentRequest{}
+	if err := convertMessage(req, v1Req); err != nil {
+		return nil, err
+	}
+	v1Resp, err := s.v1.ListMeowsByParent(ctx, v1Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV2.ListMeowsByParentResponse{}
+	return resp, convertMessage(v1Resp, resp)
+}
+
+func (s *meowServiceV2Server) ListMeowsByTag(ctx context.Context, req *meowV2.ListMeowsByTagRequest) (*meowV2.ListMeowsByTagResponse, error) {
+	v1Req := &meowV1.ListMeowsByTagRequest{}
+	if err := convertMessage(req, v1Req); err != nil {
+		return nil, err
+	}
+	v1Resp, err := s.v1.ListMeowsByTag(ctx, v1Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV2.ListMeowsByTagResponse{}
+	return resp, convertMessage(v1Resp, resp)
+}
+
+func (s *meowServiceV2Server) SearchMeows(ctx context.Context, req *meowV2.SearchMeowsRequest) (*meowV2.SearchMeowsResponse, error) {
+	v1Req := &meowV1.SearchMeowsRequest{}
+	if err := convertMessage(req, v1Req); err != nil {
+		return nil, err
+	}
+	v1Resp, err := s.v1.SearchMeows(ctx, v1Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV2.SearchMeowsResponse{}
+	return resp, convertMessage(v1Resp, resp)
+}
+
+func (s *meowServiceV2Server) HomeTimeline(ctx context.Context, req *meowV2.HomeTimelineRequest) (*meowV2.HomeTimelineResponse, error) {
+	v1Req := &meowV1.HomeTimelineRequest{}
+	if err := convertMessage(req, v1Req); err != nil {
+		return nil, err
+	}
+	v1Resp, err := s.v1.HomeTimeline(ctx, v1Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV2.HomeTimelineResponse{}
+	return resp, convertMessage(v1Resp, resp)
+}
+
+func (s *meowServiceV2Server) WatchMeows(req *meowV2.WatchMeowsRequest, stream grpc.ServerStreamingServer[meowV2.WatchMeowsResponse]) error {
+	v1Req := &meowV1.WatchMeowsRequest{}
+	if err := convertMessage(req, v1Req); err != nil {
+		return err
+	}
+	return s.v1.WatchMeows(v1Req, &versionStream[meowV1.WatchMeowsRequest, meowV1.WatchMeowsResponse]{
+		ServerStream: stream,
+		send: func(v1Resp *meowV1.WatchMeowsResponse) error {
+			resp := &meowV2.WatchMeowsResponse{}
+			if err := convertMessage(v1Resp, resp); err != nil {
+				return err
+			}
+			return stream.Send(resp)
+		},
+	})
+}
+
+func (s *meowServiceV2Server) UpdateMeow(ctx context.Context, req *meowV2.UpdateMeowRequest) (*meowV2.UpdateMeowResponse, error) {
+	v1Req := &meowV1.UpdateMeowRequest{}
+	if err := convertMessage(req, v1Req); err != nil {
+		return nil, err
+	}
+	v1Resp, err := s.v1.UpdateMeow(ctx, v1Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV2.UpdateMeowResponse{}
+	return resp, convertMessage(v1Resp, resp)
+}
+
+func (s *meowServiceV2Server) DeleteMeow(ctx context.Context, req *meowV2.DeleteMeowRequest) (*meowV2.DeleteMeowResponse, error) {
+	v1Req := &meowV1.DeleteMeowRequest{}
+	if err := convertMessage(req, v1Req); err != nil {
+		return nil, err
+	}
+	v1Resp, err := s.v1.DeleteMeow(ctx, v1Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV2.DeleteMeowResponse{}
+	return resp, convertMessage(v1Resp, resp)
+}
+
+func (s *meowServiceV2Server) LikeMeow(ctx context.Context, req *meowV2.LikeMeowRequest) (*meowV2.LikeMeowResponse, error) {
+	v1Req := &meowV1.LikeMeowRequest{}
+	if err := convertMessage(req, v1Req); err != nil {
+		return nil, err
+	}
+	v1Resp, err := s.v1.LikeMeow(ctx, v1Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV2.LikeMeowResponse{}
+	return resp, convertMessage(v1Resp, resp)
+}
+
+func (s *meowServiceV2Server) UnlikeMeow(ctx context.Context, req *meowV2.UnlikeMeowRequest) (*meowV2.UnlikeMeowResponse, error) {
+	v1Req := &meowV1.UnlikeMeowRequest{}
+	if err := convertMessage(req, v1Req); err != nil {
+		return nil, err
+	}
+	v1Resp, err := s.v1.UnlikeMeow(ctx, v1Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV2.UnlikeMeowResponse{}
+	return resp, convertMessage(v1Resp, resp)
+}
+
+func (s *meowServiceV2Server) RepostMeow(ctx context.Context, req *meowV2.RepostMeowRequest) (*meowV2.RepostMeowResponse, error) {
+	v1Req := &meowV1.RepostMeowRequest{}
+	if err := convertMessage(req, v1Req); err != nil {
+		return nil, err
+	}
+	v1Resp, err := s.v1.RepostMeow(ctx, v1Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV2.RepostMeowResponse{}
+	return resp, convertMessage(v1Resp, resp)
+}
+
+func (s *meowServiceV2Server) UnrepostMeow(ctx context.Context, req *meowV2.UnrepostMeowRequest) (*meowV2.UnrepostMeowResponse, error) {
+	v1Req := &meowV1.UnrepostMeowRequest{}
+	if err := convertMessage(req, v1Req); err != nil {
+		return nil, err
+	}
+	v1Resp, err := s.v1.UnrepostMeow(ctx, v1Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV2.UnrepostMeowResponse{}
+	return resp, convertMessage(v1Resp, resp)
+}
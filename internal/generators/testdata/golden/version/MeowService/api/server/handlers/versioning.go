@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// convertMessage copies from into to through the protobuf wire format, so a
+// message of one API version can be handled by another version's server.
+// Fields keep their values as long as they keep their numbers and types,
+// which `meower proto breaking` checks.
+func convertMessage(from, to proto.Message) error {
+	b, err := proto.Marshal(from)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to convert %T: %v", from, err)
+	}
+	if err := proto.Unmarshal(b, to); err != nil {
+		return status.Errorf(codes.Internal, "failed to convert %T to %T: %v", from, to, err)
+	}
+	return nil
+}
+
+// versionStream presents a stream of one API version to the server of
+// another. It implements grpc.ServerStreamingServer, ClientStreamingServer
+// and BidiStreamingServer; recv and send convert each message.
+type versionStream[Req, Res any] struct {
+	grpc.ServerStream
+	recv func() (*Req, error)
+	send func(*Res) error
+}
+
+func (s *versionStream[Req, Res]) Recv() (*Req, error)       { return s.recv() }
+func (s *versionStream[Req, Res]) Send(m *Res) error         { return s.send(m) }
+func (s *versionStream[Req, Res]) SendAndClose(m *Res) error { return s.send(m) }
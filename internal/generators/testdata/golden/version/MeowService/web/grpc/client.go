@@ -0,0 +1,100 @@
+package grpc
+
+import (
+	meowV2 "TEMPLATE_MODULE_PATH/api/proto/meow/v2"
+	"context"
+
+	followV1 "TEMPLATE_MODULE_PATH/api/proto/follow/v1"
+	meowV1 "TEMPLATE_MODULE_PATH/api/proto/meow/v1"
+	userV1 "TEMPLATE_MODULE_PATH/api/proto/user/v1"
+	"github.com/charmbracelet/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// requestIDKey is the metadata key the API reads request IDs from
+	requestIDKey = "x-request-id"
+	// authorizationKey is the metadata key the API reads session tokens from
+	authorizationKey = "authorization"
+)
+
+type Client struct {
+	MeowService   meowV1.MeowServiceClient
+	UserService   userV1.UserServiceClient
+	FollowService followV1.FollowServiceClient
+	MeowServiceV2 meowV2.MeowServiceClient
+	conn          *grpc.ClientConn
+}
+
+// NewClient initializes and returns a new gRPC client for our services API
+// listening at endpoint.
+func NewClient(endpoint string) *Client {
+	conn, err := grpc.NewClient(endpoint,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(unaryOutgoing),
+		grpc.WithChainStreamInterceptor(streamOutgoing),
+	)
+	if err != nil {
+		log.Fatalf("could not connect: %v", err)
+	}
+
+	client := &Client{
+		MeowService:   meowV1.NewMeowServiceClient(conn),
+		UserService:   userV1.NewUserServiceClient(conn),
+		FollowService: followV1.NewFollowServiceClient(conn),
+		MeowServiceV2: meowV2.NewMeowServiceClient(conn),
+		conn:          conn,
+	}
+
+	return client
+}
+
+// Conn returns the underlying connection, used by service clients generated
+// with `meower create handler`.
+func (c *Client) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
+type (
+	requestIDContextKey struct{}
+	tokenContextKey     struct{}
+)
+
+// WithRequestID returns a copy of ctx whose calls to the API carry id, so the
+// API logs can be matched with the web request. Fiber handlers get one from
+// c.UserContext().
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+// WithToken returns a copy of ctx whose calls to the API are authenticated
+// with the session token returned by Login. Fiber handlers get the token of
+// the logged in user from c.UserContext().
+func WithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenContextKey{}, token)
+}
+
+// outgoing adds the request ID and session token of ctx, if any, to the
+// metadata sent to the API. An authorization already set, such as the one
+// forwarded by the JSON gateway, is kept.
+func outgoing(ctx context.Context) context.Context {
+	if id, ok := ctx.Value(requestIDContextKey{}).(string); ok && id != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, requestIDKey, id)
+	}
+	if token, ok := ctx.Value(tokenContextKey{}).(string); ok && token != "" {
+		if md, _ := metadata.FromOutgoingContext(ctx); len(md.Get(authorizationKey)) == 0 {
+			ctx = metadata.AppendToOutgoingContext(ctx, authorizationKey, "Bearer "+token)
+		}
+	}
+	return ctx
+}
+
+func unaryOutgoing(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(outgoing(ctx), method, req, reply, cc, opts...)
+}
+
+func streamOutgoing(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(outgoing(ctx), desc, cc, method, opts...)
+}
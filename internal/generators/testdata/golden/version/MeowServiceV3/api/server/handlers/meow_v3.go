@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"context"
+
+	meowV2 "TEMPLATE_MODULE_PATH/api/proto/meow/v2"
+	meowV3 "TEMPLATE_MODULE_PATH/api/proto/meow/v3"
+	"google.golang.org/grpc"
+)
+
+// meowServiceV3Server serves v3 of MeowService. Every RPC delegates to the
+// v2 implementation, converting messages between versions: replace a
+// method's body to change its behaviour in v3 only.
+type meowServiceV3Server struct {
+	meowV3.UnimplementedMeowServiceServer
+	v2 meowV2.MeowServiceServer
+}
+
+func NewMeowServiceV3Server(v2 meowV2.MeowServiceServer) meowV3.MeowServiceServer {
+	return &meowServiceV3Server{v2: v2}
+}
+
+func (s *meowServiceV3Server) CreateMeow(ctx context.Context, req *meowV3.CreateMeowRequest) (*meowV3.CreateMeowResponse, error) {
+	v2Req := &meowV2.CreateMeowRequest{}
+	if err := convertMessage(req, v2Req); err != nil {
+		return nil, err
+	}
+	v2Resp, err := s.v2.CreateMeow(ctx, v2Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV3.CreateMeowResponse{}
+	return resp, convertMessage(v2Resp, resp)
+}
+
+func (s *meowServiceV3Server) GetMeow(ctx context.Context, req *meowV3.GetMeowRequest) (*meowV3.GetMeowResponse, error) {
+	v2Req := &meowV2.GetMeowRequest{}
+	if err := convertMessage(req, v2Req); err != nil {
+		return nil, err
+	}
+	v2Resp, err := s.v2.GetMeow(ctx, v2Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV3.GetMeowResponse{}
+	return resp, convertMessage(v2Resp, resp)
+}
+
+func (s *meowServiceV3Server) IndexMeow(ctx context.Context, req *meowV3.IndexMeowRequest) (*meowV3.IndexMeowResponse, error) {
+	v2Req := &meowV2.IndexMeowRequest{}
+	if err := convertMessage(req, v2Req); err != nil {
+		return nil, err
+	}
+	v2Resp, err := s.v2.IndexMeow(ctx, v2Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV3.IndexMeowResponse{}
+	return resp, convertMessage(v2Resp, resp)
+}
+
+func (s *meowServiceV3Server) ListMeowsByUser(ctx context.Context, req *meowV3.ListMeowsByUserRequest) (*meowV3.ListMeowsByUserResponse, error) {
+	v2Req := &meowV2.ListMeowsByUserRequest{}
+	if err := convertMessage(req, v2Req); err != nil {
+		return nil, err
+	}
+	v2Resp, err := s.v2.ListMeowsByUser(ctx, v2Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV3.ListMeowsByUserResponse{}
+	return resp, convertMessage(v2Resp, resp)
+}
+
+func (s *meowServiceV3Server) ListMeowsByParent(ctx context.Context, req *meowV3.ListMeowsByParentRequest) (*meowV3.ListMeowsByParentResponse, error) {
+	v2Req := &meowV2.ListMeowsByParentRequest{}
+	if err := convertMessage(req, v2Req); err != nil {
+		return nil, err
+	}
+	v2Resp, err := s.v2.ListMeowsByParent(ctx, v2Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV3.ListMeowsByParentResponse{}
+	return resp, convertMessage(v2Resp, resp)
+}
+
+func (s *meowServiceV3Server) ListMeowsByTag(ctx context.Context, req *meowV3.ListMeowsByTagRequest) (*meowV3.ListMeowsByTagResponse, error) {
+	v2Req := &meowV2.ListMeowsByTagRequest{}
+	if err := convertMessage(req, v2Req); err != nil {
+		return nil, err
+	}
+	v2Resp, err := s.v2.ListMeowsByTag(ctx, v2Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV3.ListMeowsByTagResponse{}
+	return resp, convertMessage(v2Resp, resp)
+}
+
+func (s *meowServiceV3Server) SearchMeows(ctx context.Context, req *meowV3.SearchMeowsRequest) (*meowV3.SearchMeowsResponse, error) {
+	v2Req := &meowV2.SearchMeowsRequest{}
+	if err := convertMessage(req, v2Req); err != nil {
+		return nil, err
+	}
+	v2Resp, err := s.v2.SearchMeows(ctx, v2Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV3.SearchMeowsResponse{}
+	return resp, convertMessage(v2Resp, resp)
+}
+
+func (s *meowServiceV3Server) HomeTimeline(ctx context.Context, req *meowV3.HomeTimelineRequest) (*meowV3.HomeTimelineResponse, error) {
+	v2Req := &meowV2.HomeTimelineRequest{}
+	if err := convertMessage(req, v2Req); err != nil {
+		return nil, err
+	}
+	v2Resp, err := s.v2.HomeTimeline(ctx, v2Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV3.HomeTimelineResponse{}
+	return resp, convertMessage(v2Resp, resp)
+}
+
+func (s *meowServiceV3Server) WatchMeows(req *meowV3.WatchMeowsRequest, stream grpc.ServerStreamingServer[meowV3.WatchMeowsResponse]) error {
+	v2Req := &meowV2.WatchMeowsRequest{}
+	if err := convertMessage(req, v2Req); err != nil {
+		return err
+	}
+	return s.v2.WatchMeows(v2Req, &versionStream[meowV2.WatchMeowsRequest, meowV2.WatchMeowsResponse]{
+		ServerStream: stream,
+		send: func(v2Resp *meowV2.WatchMeowsResponse) error {
+			resp := &meowV3.WatchMeowsResponse{}
+			if err := convertMessage(v2Resp, resp); err != nil {
+				return err
+			}
+			return stream.Send(resp)
+		},
+	})
+}
+
+func (s *meowServiceV3Server) UpdateMeow(ctx context.Context, req *meowV3.UpdateMeowRequest) (*meowV3.UpdateMeowResponse, error) {
+	v2Req := &meowV2.UpdateMeowRequest{}
+	if err := convertMessage(req, v2Req); err != nil {
+		return nil, err
+	}
+	v2Resp, err := s.v2.UpdateMeow(ctx, v2Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV3.UpdateMeowResponse{}
+	return resp, convertMessage(v2Resp, resp)
+}
+
+func (s *meowServiceV3Server) DeleteMeow(ctx context.Context, req *meowV3.DeleteMeowRequest) (*meowV3.DeleteMeowResponse, error) {
+	v2Req := &meowV2.DeleteMeowRequest{}
+	if err := convertMessage(req, v2Req); err != nil {
+		return nil, err
+	}
+	v2Resp, err := s.v2.DeleteMeow(ctx, v2Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV3.DeleteMeowResponse{}
+	return resp, convertMessage(v2Resp, resp)
+}
+
+func (s *meowServiceV3Server) LikeMeow(ctx context.Context, req *meowV3.LikeMeowRequest) (*meowV3.LikeMeowResponse, error) {
+	v2Req := &meowV2.LikeMeowRequest{}
+	if err := convertMessage(req, v2Req); err != nil {
+		return nil, err
+	}
+	v2Resp, err := s.v2.LikeMeow(ctx, v2Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV3.LikeMeowResponse{}
+	return resp, convertMessage(v2Resp, resp)
+}
+
+func (s *meowServiceV3Server) UnlikeMeow(ctx context.Context, req *meowV3.UnlikeMeowRequest) (*meowV3.UnlikeMeowResponse, error) {
+	v2Req := &meowV2.UnlikeMeowRequest{}
+	if err := convertMessage(req, v2Req); err != nil {
+		return nil, err
+	}
+	v2Resp, err := s.v2.UnlikeMeow(ctx, v2Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV3.UnlikeMeowResponse{}
+	return resp, convertMessage(v2Resp, resp)
+}
+
+func (s *meowServiceV3Server) RepostMeow(ctx context.Context, req *meowV3.RepostMeowRequest) (*meowV3.RepostMeowResponse, error) {
+	v2Req := &meowV2.RepostMeowRequest{}
+	if err := convertMessage(req, v2Req); err != nil {
+		return nil, err
+	}
+	v2Resp, err := s.v2.RepostMeow(ctx, v2Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV3.RepostMeowResponse{}
+	return resp, convertMessage(v2Resp, resp)
+}
+
+func (s *meowServiceV3Server) UnrepostMeow(ctx context.Context, req *meowV3.UnrepostMeowRequest) (*meowV3.UnrepostMeowResponse, error) {
+	v2Req := &meowV2.UnrepostMeowRequest{}
+	if err := convertMessage(req, v2Req); err != nil {
+		return nil, err
+	}
+	v2Resp, err := s.v2.UnrepostMeow(ctx, v2Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &meowV3.UnrepostMeowResponse{}
+	return resp, convertMessage(v2Resp, resp)
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+
+	timelineserviceV1 "TEMPLATE_MODULE_PATH/api/proto/timelineservice/v1"
+	timelineserviceV2 "TEMPLATE_MODULE_PATH/api/proto/timelineservice/v2"
+	"google.golang.org/grpc"
+)
+
+// timelineServiceV2Server serves v2 of TimelineService. Every RPC delegates to the
+// v1 implementation, converting messages between versions: replace a
+// method's body to change its behaviour in v2 only.
+type timelineServiceV2Server struct {
+	timelineserviceV2.UnimplementedTimelineServiceServer
+	v1 timelineserviceV1.TimelineServiceServer
+}
+
+func NewTimelineServiceV2Server(v1 timelineserviceV1.TimelineServiceServer) timelineserviceV2.TimelineServiceServer {
+	return &timelineServiceV2Server{v1: v1}
+}
+
+func (s *timelineServiceV2Server) GetTimeline(ctx context.Context, req *timelineserviceV2.GetTimelineRequest) (*timelineserviceV2.GetTimelineResponse, error) {
+	v1Req := &timelineserviceV1.GetTimelineRequest{}
+	if err := convertMessage(req, v1Req); err != nil {
+		return nil, err
+	}
+	v1Resp, err := s.v1.GetTimeline(ctx, v1Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &timelineserviceV2.GetTimelineResponse{}
+	return resp, convertMessage(v1Resp, resp)
+}
+
+func (s *timelineServiceV2Server) ListTimeline(req *timelineserviceV2.ListTimelineRequest, stream grpc.ServerStreamingServer[timelineserviceV2.ListTimelineResponse]) error {
+	v1Req := &timelineserviceV1.ListTimelineRequest{}
+	if err := convertMessage(req, v1Req); err != nil {
+		return err
+	}
+	return s.v1.ListTimeline(v1Req, &versionStream[timelineserviceV1.ListTimelineRequest, timelineserviceV1.ListTimelineResponse]{
+		ServerStream: stream,
+		send: func(v1Resp *timelineserviceV1.ListTimelineResponse) error {
+			resp := &timelineserviceV2.ListTimelineResponse{}
+			if err := convertMessage(v1Resp, resp); err != nil {
+				return err
+			}
+			return stream.Send(resp)
+		},
+	})
+}
+
+func (s *timelineServiceV2Server) UploadTimeline(stream grpc.ClientStreamingServer[timelineserviceV2.UploadTimelineRequest, timelineserviceV2.UploadTimelineResponse]) error {
+	return s.v1.UploadTimeline(&versionStream[timelineserviceV1.UploadTimelineRequest, timelineserviceV1.UploadTimelineResponse]{
+		ServerStream: stream,
+		recv: func() (*timelineserviceV1.UploadTimelineRequest, error) {
+			req, err := stream.Recv()
+			if err != nil {
+				return nil, err
+			}
+			v1Req := &timelineserviceV1.UploadTimelineRequest{}
+			return v1Req, convertMessage(req, v1Req)
+		},
+		send: func(v1Resp *timelineserviceV1.UploadTimelineResponse) error {
+			resp := &timelineserviceV2.UploadTimelineResponse{}
+			if err := convertMessage(v1Resp, resp); err != nil {
+				return err
+			}
+			return stream.SendAndClose(resp)
+		},
+	})
+}
+
+func (s *timelineServiceV2Server) ChatTimeline(stream grpc.BidiStreamingServer[timelineserviceV2.ChatTimelineRequest, timelineserviceV2.ChatTimelineResponse]) error {
+	return s.v1.ChatTimeline(&versionStream[timelineserviceV1.ChatTimelineRequest, timelineserviceV1.ChatTimelineResponse]{
+		ServerStream: stream,
+		recv: func() (*timelineserviceV1.ChatTimelineRequest, error) {
+			req, err := stream.Recv()
+			if err != nil {
+				return nil, err
+			}
+			v1Req := &timelineserviceV1.ChatTimelineRequest{}
+			return v1Req, convertMessage(req, v1Req)
+		},
+		send: func(v1Resp *timelineserviceV1.ChatTimelineResponse) error {
+			resp := &timelineserviceV2.ChatTimelineResponse{}
+			if err := convertMessage(v1Resp, resp); err != nil {
+				return err
+			}
+			return stream.Send(resp)
+		},
+	})
+}
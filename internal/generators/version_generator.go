@@ -0,0 +1,725 @@
+package generators
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/AlyxPink/meower/internal/proto"
+	"github.com/AlyxPink/meower/internal/templates"
+	"github.com/AlyxPink/meower/internal/validation"
+)
+
+// Files edited when a new API version is registered
+var (
+	serverPath     = path.Join("api", "server", "server.go")
+	clientPath     = path.Join("web", "grpc", "client.go")
+	versioningPath = path.Join("api", "server", "handlers", "versioning.go")
+)
+
+// VersionGenerator adds a new major version of an existing service so it can
+// evolve without breaking older clients. The previous version's .proto file
+// is copied with its package bumped, a server handler delegating every RPC to
+// the previous version is scaffolded, and both versions are registered in the
+// API server and the web gRPC client.
+type VersionGenerator struct {
+	src fs.FS
+	out templates.Writer
+}
+
+// NewVersionGenerator creates a version generator working in the current directory
+func NewVersionGenerator() *VersionGenerator {
+	return NewVersionGeneratorWithFS(os.DirFS("."), templates.NewDiskWriter("."))
+}
+
+// NewVersionGeneratorWithFS creates a version generator reading the project
+// from src and writing new and edited files to out
+func NewVersionGeneratorWithFS(src fs.FS, out templates.Writer) *VersionGenerator {
+	return &VersionGenerator{
+		src: src,
+		out: out,
+	}
+}
+
+// AddVersion creates version of serviceName from the latest older version,
+// and returns the files of the new version
+func (g *VersionGenerator) AddVersion(serviceName, version string) (*ServiceFiles, error) {
+	if err := validation.NewValidator().Service.ValidateAPIVersion(version); err != nil {
+		return nil, err
+	}
+
+	versions, err := findServiceVersions(g.src, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	var prev *ServiceFiles
+	for _, service := range versions {
+		if service.Version == version {
+			return nil, fmt.Errorf("%s %s already exists in %s", serviceName, version, service.ProtoPath)
+		}
+		if service.Version != "" && versionNumber(service.Version) < versionNumber(version) {
+			prev = service
+		}
+	}
+	if prev == nil {
+		return nil, fmt.Errorf("%s has no version older than %s to copy", serviceName, version)
+	}
+	if prev.HandlerPath == "" {
+		return nil, fmt.Errorf("no struct in %s implements %s of %s", path.Join("api", "server", "handlers"), prev.Version, serviceName)
+	}
+
+	next, protoSrc, err := nextVersionProto(g.src, prev, version)
+	if err != nil {
+		return nil, err
+	}
+
+	handlerSrc, err := delegatingHandler(prev, next)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fs.Stat(g.src, next.HandlerPath); err == nil {
+		return nil, fmt.Errorf("%s already exists", next.HandlerPath)
+	}
+
+	serverSrc, err := fs.ReadFile(g.src, serverPath)
+	if err != nil {
+		return nil, err
+	}
+	serverSrc, err = registerServerVersion(g.src, serverSrc, prev, next)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update %s: %w", serverPath, err)
+	}
+
+	clientSrc, err := fs.ReadFile(g.src, clientPath)
+	if err != nil {
+		return nil, err
+	}
+	clientSrc, err = registerClientVersion(clientSrc, prev, next)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update %s: %w", clientPath, err)
+	}
+
+	files := map[string][]byte{
+		next.ProtoPath:   protoSrc,
+		next.HandlerPath: handlerSrc,
+		serverPath:       serverSrc,
+		clientPath:       clientSrc,
+	}
+	if _, err := fs.Stat(g.src, versioningPath); err != nil {
+		files[versioningPath] = []byte(versioningSource)
+	}
+
+	for filePath, content := range files {
+		if err := g.out.MkdirAll(path.Dir(filePath)); err != nil {
+			return nil, err
+		}
+		if err := g.out.WriteFile(filePath, content); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", filePath, err)
+		}
+	}
+	return next, nil
+}
+
+// nextVersionProto copies the .proto file of prev into the directory of
+// version, rewriting its package and go_package
+func nextVersionProto(src fs.FS, prev *ServiceFiles, version string) (*ServiceFiles, []byte, error) {
+	versionDir := path.Dir(prev.ProtoPath)
+	if path.Base(versionDir) != prev.Version {
+		return nil, nil, fmt.Errorf("%s is not in a %s directory", prev.ProtoPath, prev.Version)
+	}
+	goPackage := prev.Proto.Option("go_package")
+	importPath, name, hasName := strings.Cut(goPackage, ";")
+	if !strings.HasSuffix(importPath, "/"+prev.Version) {
+		return nil, nil, fmt.Errorf("go_package %q of %s does not end with /%s", goPackage, prev.ProtoPath, prev.Version)
+	}
+
+	newPackage := strings.TrimSuffix(prev.Proto.Package, prev.Version) + version
+	newGoPackage := strings.TrimSuffix(importPath, prev.Version) + version
+	if hasName {
+		newGoPackage += ";" + strings.TrimSuffix(name, prev.Version)
+		if strings.HasSuffix(name, prev.Version) {
+			newGoPackage += version
+		}
+	}
+
+	content, err := fs.ReadFile(src, prev.ProtoPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Replace the package statement and the go_package value, last one first
+	// so offsets stay valid
+	type replacement struct {
+		start, end int
+		text       string
+	}
+	var replacements []replacement
+
+	start := prev.Proto.PackagePos.Offset
+	end := bytes.IndexByte(content[start:], ';')
+	if end < 0 {
+		return nil, nil, fmt.Errorf("unterminated package statement in %s", prev.ProtoPath)
+	}
+	replacements = append(replacements, replacement{start, start + end + 1, "package " + newPackage + ";"})
+
+	for _, option := range prev.Proto.Options {
+		if option.Name != "go_package" {
+			continue
+		}
+		quoted := strconv.Quote(goPackage)
+		i := bytes.Index(content[option.Pos.Offset:], []byte(quoted))
+		if i < 0 {
+			return nil, nil, fmt.Errorf("go_package of %s must be a double-quoted string", prev.ProtoPath)
+		}
+		at := option.Pos.Offset + i
+		replacements = append(replacements, replacement{at, at + len(quoted), strconv.Quote(newGoPackage)})
+	}
+	sort.Slice(replacements, func(i, j int) bool { return replacements[i].start > replacements[j].start })
+
+	newContent := append([]byte(nil), content...)
+	for _, r := range replacements {
+		newContent = append(newContent[:r.start:r.start], append([]byte(r.text), newContent[r.end:]...)...)
+	}
+
+	protoPath := path.Join(path.Dir(versionDir), version, path.Base(prev.ProtoPath))
+	file, err := proto.Parse(strings.TrimPrefix(protoPath, path.Join("api", "proto")+"/"), newContent)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	handlerPath := strings.TrimSuffix(strings.TrimSuffix(prev.HandlerPath, ".go"), "_"+prev.Version) + "_" + version + ".go"
+	next := &ServiceFiles{
+		ServiceName: prev.ServiceName,
+		ProtoPath:   protoPath,
+		Proto:       file,
+		HandlerPath: handlerPath,
+		Receiver:    strings.ToLower(prev.ServiceName[:1]) + prev.ServiceName[1:] + upperVersion(version) + "Server",
+		ProtoAlias:  protoAlias(prev.packageName(), version),
+		Version:     version,
+	}
+	return next, newContent, nil
+}
+
+// delegatingHandlerTemplate renders the server of a new API version whose
+// RPCs call the previous version's server
+const delegatingHandlerTemplate = `package handlers
+
+import (
+{{- if .HasUnary}}
+	"context"
+{{- end}}
+
+	{{.Prev.ProtoAlias}} "{{.Prev.GoPackage}}"
+	{{.Next.ProtoAlias}} "{{.Next.GoPackage}}"
+{{- if .HasStreams}}
+	"google.golang.org/grpc"
+{{- end}}
+)
+
+{{- $prev := .Prev.Version}}
+{{- $prevAlias := .Prev.ProtoAlias}}
+{{- $alias := .Next.ProtoAlias}}
+
+// {{.Next.Receiver}} serves {{.Next.Version}} of {{.Next.ServiceName}}. Every RPC delegates to the
+// {{$prev}} implementation, converting messages between versions: replace a
+// method's body to change its behaviour in {{.Next.Version}} only.
+type {{.Next.Receiver}} struct {
+	{{$alias}}.Unimplemented{{.Next.ServiceName}}Server
+	{{$prev}} {{$prevAlias}}.{{.Next.ServiceName}}Server
+}
+
+func {{.Constructor}}({{$prev}} {{$prevAlias}}.{{.Next.ServiceName}}Server) {{$alias}}.{{.Next.ServiceName}}Server {
+	return &{{.Next.Receiver}}{ {{$prev}}: {{$prev}} }
+}
+{{- range .RPCs}}
+{{- $request := printf "%s.%s" $alias .Request}}
+{{- $response := printf "%s.%s" $alias .Response}}
+{{- $prevRequest := printf "%s.%s" $prevAlias .Request}}
+{{- $prevResponse := printf "%s.%s" $prevAlias .Response}}
+{{- if .ClientStreaming}}
+
+func (s *{{$.Next.Receiver}}) {{.Name}}(stream grpc.{{if .ServerStreaming}}Bidi{{else}}Client{{end}}StreamingServer[{{$request}}, {{$response}}]) error {
+	return s.{{$prev}}.{{.Name}}(&versionStream[{{$prevRequest}}, {{$prevResponse}}]{
+		ServerStream: stream,
+		recv: func() (*{{$prevRequest}}, error) {
+			req, err := stream.Recv()
+			if err != nil {
+				return nil, err
+			}
+			{{$prev}}Req := &{{$prevRequest}}{}
+			return {{$prev}}Req, convertMessage(req, {{$prev}}Req)
+		},
+		send: func({{$prev}}Resp *{{$prevResponse}}) error {
+			resp := &{{$response}}{}
+			if err := convertMessage({{$prev}}Resp, resp); err != nil {
+				return err
+			}
+			return stream.{{if .ServerStreaming}}Send{{else}}SendAndClose{{end}}(resp)
+		},
+	})
+}
+{{- else if .ServerStreaming}}
+
+func (s *{{$.Next.Receiver}}) {{.Name}}(req *{{$request}}, stream grpc.ServerStreamingServer[{{$response}}]) error {
+	{{$prev}}Req := &{{$prevRequest}}{}
+	if err := convertMessage(req, {{$prev}}Req); err != nil {
+		return err
+	}
+	return s.{{$prev}}.{{.Name}}({{$prev}}Req, &versionStream[{{$prevRequest}}, {{$prevResponse}}]{
+		ServerStream: stream,
+		send: func({{$prev}}Resp *{{$prevResponse}}) error {
+			resp := &{{$response}}{}
+			if err := convertMessage({{$prev}}Resp, resp); err != nil {
+				return err
+			}
+			return stream.Send(resp)
+		},
+	})
+}
+{{- else}}
+
+func (s *{{$.Next.Receiver}}) {{.Name}}(ctx context.Context, req *{{$request}}) (*{{$response}}, error) {
+	{{$prev}}Req := &{{$prevRequest}}{}
+	if err := convertMessage(req, {{$prev}}Req); err != nil {
+		return nil, err
+	}
+	{{$prev}}Resp, err := s.{{$prev}}.{{.Name}}(ctx, {{$prev}}Req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &{{$response}}{}
+	return resp, convertMessage({{$prev}}Resp, resp)
+}
+{{- end}}
+{{- end}}
+`
+
+// versioningSource holds the helpers delegating handlers share. It is written
+// to api/server/handlers/versioning.go the first time a version is added.
+const versioningSource = `package handlers
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// convertMessage copies from into to through the protobuf wire format, so a
+// message of one API version can be handled by another version's server.
+// Fields keep their values as long as they keep their numbers and types,
+// which ` + "`meower proto breaking`" + ` checks.
+func convertMessage(from, to proto.Message) error {
+	b, err := proto.Marshal(from)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to convert %T: %v", from, err)
+	}
+	if err := proto.Unmarshal(b, to); err != nil {
+		return status.Errorf(codes.Internal, "failed to convert %T to %T: %v", from, to, err)
+	}
+	return nil
+}
+
+// versionStream presents a stream of one API version to the server of
+// another. It implements grpc.ServerStreamingServer, ClientStreamingServer
+// and BidiStreamingServer; recv and send convert each message.
+type versionStream[Req, Res any] struct {
+	grpc.ServerStream
+	recv func() (*Req, error)
+	send func(*Res) error
+}
+
+func (s *versionStream[Req, Res]) Recv() (*Req, error)       { return s.recv() }
+func (s *versionStream[Req, Res]) Send(m *Res) error         { return s.send(m) }
+func (s *versionStream[Req, Res]) SendAndClose(m *Res) error { return s.send(m) }
+`
+
+// delegatingRPC is an rpc of a delegating handler, with Go message type names
+type delegatingRPC struct {
+	Name            string
+	Request         string
+	Response        string
+	ClientStreaming bool
+	ServerStreaming bool
+}
+
+// delegatingHandlerData is the data passed to delegatingHandlerTemplate
+type delegatingHandlerData struct {
+	Prev        *ServiceFiles
+	Next        *ServiceFiles
+	Constructor string
+	RPCs        []delegatingRPC
+}
+
+// HasUnary returns true if any rpc is unary
+func (d delegatingHandlerData) HasUnary() bool {
+	for _, rpc := range d.RPCs {
+		if !rpc.ClientStreaming && !rpc.ServerStreaming {
+			return true
+		}
+	}
+	return false
+}
+
+// HasStreams returns true if any rpc streams in either direction
+func (d delegatingHandlerData) HasStreams() bool {
+	for _, rpc := range d.RPCs {
+		if rpc.ClientStreaming || rpc.ServerStreaming {
+			return true
+		}
+	}
+	return false
+}
+
+// delegatingHandler renders the server of next, delegating to prev
+func delegatingHandler(prev, next *ServiceFiles) ([]byte, error) {
+	messages := prev.Proto.AllMessages()
+	goType := func(rpc, typ string) (string, error) {
+		if _, ok := messages[typ]; !ok {
+			return "", fmt.Errorf("rpc %s uses %s, which is not declared in %s; only messages of the service's own file can be converted between versions", rpc, typ, prev.ProtoPath)
+		}
+		// protoc-gen-go names nested messages Outer_Inner
+		return strings.ReplaceAll(typ, ".", "_"), nil
+	}
+
+	data := delegatingHandlerData{
+		Prev:        prev,
+		Next:        next,
+		Constructor: constructorName(next),
+	}
+	for _, rpc := range prev.Proto.Service(prev.ServiceName).RPCs {
+		request, err := goType(rpc.Name, rpc.Request)
+		if err != nil {
+			return nil, err
+		}
+		response, err := goType(rpc.Name, rpc.Response)
+		if err != nil {
+			return nil, err
+		}
+		data.RPCs = append(data.RPCs, delegatingRPC{
+			Name:            rpc.Name,
+			Request:         request,
+			Response:        response,
+			ClientStreaming: rpc.ClientStreaming,
+			ServerStreaming: rpc.ServerStreaming,
+		})
+	}
+
+	tmpl, err := template.New("delegating handler").Parse(delegatingHandlerTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse delegating handler template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute delegating handler template: %w", err)
+	}
+	content, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format %s: %w", next.HandlerPath, err)
+	}
+	return content, nil
+}
+
+// constructorName returns the constructor of a delegating handler, such as
+// NewMeowServiceV2Server
+func constructorName(service *ServiceFiles) string {
+	return "New" + service.ServiceName + upperVersion(service.Version) + "Server"
+}
+
+// registration is a Register<Service>Server call of a versioned proto package
+type registration struct {
+	stmt    *ast.ExprStmt
+	call    *ast.CallExpr
+	service string
+	path    string
+	version string
+}
+
+// registerServerVersion registers next in server.go next to prev, wrapping
+// the registered prev server. prev is registered too if it was not already.
+func registerServerVersion(src fs.FS, content []byte, prev, next *ServiceFiles) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, serverPath, content, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	offset := func(pos token.Pos) int { return fset.Position(pos).Offset }
+	text := func(node ast.Node) string { return string(content[offset(node.Pos()):offset(node.End())]) }
+
+	var registrations []registration
+	ast.Inspect(file, func(n ast.Node) bool {
+		stmt, ok := n.(*ast.ExprStmt)
+		if !ok {
+			return true
+		}
+		call, ok := stmt.X.(*ast.CallExpr)
+		if !ok || len(call.Args) != 2 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !strings.HasPrefix(sel.Sel.Name, "Register") || !strings.HasSuffix(sel.Sel.Name, "Server") {
+			return true
+		}
+		pkg, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		importPath := importPathOf(file, pkg.Name)
+		if version := packageVersion(path.Base(importPath)); version != "" {
+			registrations = append(registrations, registration{
+				stmt:    stmt,
+				call:    call,
+				service: strings.TrimSuffix(strings.TrimPrefix(sel.Sel.Name, "Register"), "Server"),
+				path:    importPath,
+				version: version,
+			})
+		}
+		return true
+	})
+	if len(registrations) == 0 {
+		return nil, fmt.Errorf("no Register...Server calls found")
+	}
+
+	handlersAlias := "handlers"
+	for _, spec := range file.Imports {
+		if p, _ := strconv.Unquote(spec.Path.Value); strings.HasSuffix(p, "/api/server/handlers") && spec.Name != nil {
+			handlersAlias = spec.Name.Name
+		}
+	}
+
+	// The gRPC server and database arguments of existing registrations, such
+	// as g and db in pbMeowV1.RegisterMeowServiceServer(g, handlers.NewMeowerServer(db))
+	grpcServer, database := text(registrations[0].call.Args[0]), "db"
+	for _, r := range registrations {
+		if call, ok := r.call.Args[1].(*ast.CallExpr); ok && len(call.Args) == 1 {
+			if ident, ok := call.Args[0].(*ast.Ident); ok {
+				database = ident.Name
+				break
+			}
+		}
+	}
+
+	var imports []namedImport
+	alias := func(service *ServiceFiles) string {
+		for _, spec := range file.Imports {
+			if p, _ := strconv.Unquote(spec.Path.Value); p == service.GoPackage() {
+				if spec.Name != nil {
+					return spec.Name.Name
+				}
+				return path.Base(p)
+			}
+		}
+		pkg := service.packageName()
+		name := "pb" + strings.ToUpper(pkg[:1]) + pkg[1:] + upperVersion(service.Version)
+		imports = append(imports, namedImport{name: name, path: service.GoPackage()})
+		return name
+	}
+
+	var edits []edit
+	register := func(version, line string) {
+		var anchor *registration
+		for i, r := range registrations {
+			if r.version == version {
+				anchor = &registrations[i]
+			}
+		}
+		if anchor != nil {
+			indent := string(content[lineStart(content, offset(anchor.stmt.Pos())):offset(anchor.stmt.Pos())])
+			edits = append(edits, edit{offset: lineEnd(content, offset(anchor.stmt.End())), text: indent + line + "\n"})
+			return
+		}
+		last := registrations[len(registrations)-1]
+		indent := string(content[lineStart(content, offset(last.stmt.Pos())):offset(last.stmt.Pos())])
+		comment := fmt.Sprintf("// Register %s services", strings.ToUpper(version))
+		edits = append(edits, edit{offset: lineEnd(content, offset(last.stmt.End())), text: "\n" + indent + comment + "\n" + indent + line + "\n"})
+	}
+
+	var prevServer string
+	for _, r := range registrations {
+		if r.service == prev.ServiceName && r.path == prev.GoPackage() {
+			prevServer = text(r.call.Args[1])
+		}
+	}
+	if prevServer == "" {
+		constructor, err := dbConstructor(src, prev)
+		if err != nil {
+			return nil, err
+		}
+		prevServer = fmt.Sprintf("%s.%s(%s)", handlersAlias, constructor, database)
+		register(prev.Version, fmt.Sprintf("%s.Register%sServer(%s, %s)", alias(prev), prev.ServiceName, grpcServer, prevServer))
+	}
+	nextServer := fmt.Sprintf("%s.%s(%s)", handlersAlias, constructorName(next), prevServer)
+	register(next.Version, fmt.Sprintf("%s.Register%sServer(%s, %s)", alias(next), next.ServiceName, grpcServer, nextServer))
+
+	edits = append(edits, namedImportEdits(fset, file, content, imports)...)
+	return format.Source(applyEdits(content, edits))
+}
+
+// dbConstructor returns the name of the function in a service's handler file
+// that builds its server from a database handle
+func dbConstructor(src fs.FS, service *ServiceFiles) (string, error) {
+	content, err := fs.ReadFile(src, service.HandlerPath)
+	if err != nil {
+		return "", err
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), service.HandlerPath, content, parser.SkipObjectResolution)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", service.HandlerPath, err)
+	}
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Type.Results == nil || len(fn.Type.Results.List) != 1 {
+			continue
+		}
+		result, ok := fn.Type.Results.List[0].Type.(*ast.SelectorExpr)
+		if !ok || result.Sel.Name != service.ServiceName+"Server" {
+			continue
+		}
+		if pkg, ok := result.X.(*ast.Ident); !ok || pkg.Name != service.ProtoAlias {
+			continue
+		}
+		if fn.Type.Params.NumFields() != 1 {
+			return "", fmt.Errorf("%s is not registered and %s does not take a single database argument; register it first", service.Version, fn.Name.Name)
+		}
+		return fn.Name.Name, nil
+	}
+	return "", fmt.Errorf("%s is not registered and %s has no constructor returning %s.%sServer", service.Version, service.HandlerPath, service.ProtoAlias, service.ServiceName)
+}
+
+// registerClientVersion adds a client for every missing version to the web
+// gRPC Client struct and to the literal building it in NewClient
+func registerClientVersion(content []byte, versions ...*ServiceFiles) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, clientPath, content, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	offset := func(pos token.Pos) int { return fset.Position(pos).Offset }
+
+	var fields *ast.FieldList
+	var literal *ast.CompositeLit
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.TypeSpec:
+			if s, ok := n.Type.(*ast.StructType); ok && n.Name.Name == "Client" {
+				fields = s.Fields
+			}
+		case *ast.CompositeLit:
+			if ident, ok := n.Type.(*ast.Ident); ok && ident.Name == "Client" {
+				literal = n
+			}
+		}
+		return true
+	})
+	if fields == nil || literal == nil {
+		return nil, fmt.Errorf("no Client struct and Client literal found")
+	}
+
+	// New fields and values go before the connection, or at the end
+	fieldAt := lineStart(content, offset(fields.Closing))
+	for _, field := range fields.List {
+		if len(field.Names) == 1 && field.Names[0].Name == "conn" {
+			fieldAt = lineStart(content, offset(field.Pos()))
+		}
+	}
+	valueAt, conn := lineStart(content, offset(literal.Rbrace)), "conn"
+	for _, elt := range literal.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "conn" {
+				valueAt = lineStart(content, offset(kv.Pos()))
+				if ident, ok := kv.Value.(*ast.Ident); ok {
+					conn = ident.Name
+				}
+			}
+		}
+	}
+
+	var edits []edit
+	var imports []namedImport
+	for _, service := range versions {
+		alias := protoAlias(service.packageName(), service.Version)
+		for _, spec := range file.Imports {
+			if p, _ := strconv.Unquote(spec.Path.Value); p == service.GoPackage() {
+				alias = path.Base(p)
+				if spec.Name != nil {
+					alias = spec.Name.Name
+				}
+			}
+		}
+
+		registered := false
+		for _, field := range fields.List {
+			sel, ok := field.Type.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != service.ServiceName+"Client" {
+				continue
+			}
+			if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == alias {
+				registered = true
+			}
+		}
+		if registered {
+			continue
+		}
+
+		name := service.ServiceName
+		if service.Version != "v1" {
+			name += upperVersion(service.Version)
+		}
+		imports = append(imports, namedImport{name: alias, path: service.GoPackage()})
+		edits = append(edits,
+			edit{offset: fieldAt, text: fmt.Sprintf("\t%s %s.%sClient\n", name, alias, service.ServiceName)},
+			edit{offset: valueAt, text: fmt.Sprintf("\t\t%s: %s.New%sClient(%s),\n", name, alias, service.ServiceName, conn)},
+		)
+	}
+
+	edits = append(edits, namedImportEdits(fset, file, content, imports)...)
+	return format.Source(applyEdits(content, edits))
+}
+
+// packageName returns the directory of the service's versioned Go packages,
+// such as meow for .../api/proto/meow/v1
+func (f *ServiceFiles) packageName() string {
+	if goPackage := f.GoPackage(); goPackage != "" {
+		return path.Base(path.Dir(goPackage))
+	}
+	return strings.ToLower(f.ServiceName)
+}
+
+// packageVersion returns the API version ending a package name, such as v1
+// for meow.v1, or "" if it is not versioned
+func packageVersion(pkg string) string {
+	version := pkg[strings.LastIndex(pkg, ".")+1:]
+	if validation.NewValidator().Service.ValidateAPIVersion(version) != nil {
+		return ""
+	}
+	return version
+}
+
+// versionNumber returns the major version number of v2, or 0
+func versionNumber(version string) int {
+	n, _ := strconv.Atoi(strings.TrimPrefix(version, "v"))
+	return n
+}
+
+// protoAlias returns the import name of a versioned proto package, such as
+// meowV1
+func protoAlias(name, version string) string {
+	return name + upperVersion(version)
+}
+
+// upperVersion returns version with an upper case V, as used in Go names
+func upperVersion(version string) string {
+	return strings.ToUpper(version)
+}
@@ -0,0 +1,142 @@
+package generators
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/AlyxPink/meower/internal/templates"
+	"github.com/AlyxPink/meower/internal/templates/templatetest"
+)
+
+// templateProjectFS returns the MeowService files of the project template
+// along with the API server and web client registering it
+func templateProjectFS(t *testing.T) fstest.MapFS {
+	t.Helper()
+
+	fsys := templateServiceFS(t)
+	for _, path := range []string{serverPath, clientPath} {
+		content, err := os.ReadFile(filepath.Join("..", "..", "cmd", "meower", "template", filepath.FromSlash(path)))
+		if err != nil {
+			t.Fatalf("failed to read template file: %v", err)
+		}
+		fsys[path] = &fstest.MapFile{Data: content}
+	}
+	return fsys
+}
+
+// withFiles returns a copy of fsys with files added or replaced
+func withFiles(fsys fstest.MapFS, files map[string][]byte) fstest.MapFS {
+	merged := fstest.MapFS{}
+	for path, file := range fsys {
+		merged[path] = file
+	}
+	for path, content := range files {
+		merged[path] = &fstest.MapFile{Data: content}
+	}
+	return merged
+}
+
+// TestVersionGeneratorGolden adds API versions to services and compares the
+// new and edited files against testdata/golden/version. Run with -update to
+// accept changes.
+func TestVersionGeneratorGolden(t *testing.T) {
+	t.Run("MeowService v2", func(t *testing.T) {
+		out := templates.NewMemoryWriter()
+		if _, err := NewVersionGeneratorWithFS(templateProjectFS(t), out).AddVersion("MeowService", "v2"); err != nil {
+			t.Fatalf("AddVersion() error = %v", err)
+		}
+		templatetest.AssertGolden(t, filepath.Join("testdata", "golden", "version", "MeowService"), out.Files)
+	})
+
+	t.Run("MeowService v3", func(t *testing.T) {
+		v2 := templates.NewMemoryWriter()
+		if _, err := NewVersionGeneratorWithFS(templateProjectFS(t), v2).AddVersion("MeowService", "v2"); err != nil {
+			t.Fatalf("AddVersion(v2) error = %v", err)
+		}
+
+		out := templates.NewMemoryWriter()
+		if _, err := NewVersionGeneratorWithFS(withFiles(templateProjectFS(t), v2.Files), out).AddVersion("MeowService", "v3"); err != nil {
+			t.Fatalf("AddVersion(v3) error = %v", err)
+		}
+		templatetest.AssertGolden(t, filepath.Join("testdata", "golden", "version", "MeowServiceV3"), out.Files)
+	})
+
+	// A service from `meower create handler` is not registered in server.go
+	// yet, so both versions are
+	t.Run("generated TimelineService v2", func(t *testing.T) {
+		vars := templates.NewTemplateVars()
+		if err := vars.SetService("TimelineService"); err != nil {
+			t.Fatalf("failed to set service variables: %v", err)
+		}
+		vars.ModulePath = "TEMPLATE_MODULE_PATH"
+
+		methods, err := ParseMethods([]string{"Get", "List:server-stream", "Upload:client-stream", "Chat:bidi"}, "Timeline")
+		if err != nil {
+			t.Fatalf("ParseMethods() error = %v", err)
+		}
+		handler := templates.NewMemoryWriter()
+		generator := NewHandlerGeneratorWithWriter(vars, handler)
+		if err := generator.GenerateProto(methods); err != nil {
+			t.Fatalf("GenerateProto() error = %v", err)
+		}
+		if err := generator.GenerateServerHandler(methods); err != nil {
+			t.Fatalf("GenerateServerHandler() error = %v", err)
+		}
+
+		out := templates.NewMemoryWriter()
+		if _, err := NewVersionGeneratorWithFS(withFiles(templateProjectFS(t), handler.Files), out).AddVersion("TimelineService", "v2"); err != nil {
+			t.Fatalf("AddVersion() error = %v", err)
+		}
+		templatetest.AssertGolden(t, filepath.Join("testdata", "golden", "version", "TimelineService"), out.Files)
+	})
+}
+
+func TestVersionGeneratorFindsVersionedHandlers(t *testing.T) {
+	out := templates.NewMemoryWriter()
+	if _, err := NewVersionGeneratorWithFS(templateProjectFS(t), out).AddVersion("MeowService", "v2"); err != nil {
+		t.Fatalf("AddVersion() error = %v", err)
+	}
+
+	versions, err := findServiceVersions(withFiles(templateProjectFS(t), out.Files), "MeowService")
+	if err != nil {
+		t.Fatalf("findServiceVersions() error = %v", err)
+	}
+
+	want := []struct{ version, handler, receiver string }{
+		{"v1", "api/server/handlers/meow.go", "meowServiceServer"},
+		{"v2", "api/server/handlers/meow_v2.go", "meowServiceV2Server"},
+	}
+	if len(versions) != len(want) {
+		t.Fatalf("found %d versions, want %d", len(versions), len(want))
+	}
+	for i, w := range want {
+		if got := versions[i]; got.Version != w.version || got.HandlerPath != w.handler || got.Receiver != w.receiver {
+			t.Errorf("version %d = %s %s %s, want %s %s %s", i, got.Version, got.HandlerPath, got.Receiver, w.version, w.handler, w.receiver)
+		}
+	}
+}
+
+func TestVersionGeneratorErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		serviceName string
+		version     string
+		wantErr     string
+	}{
+		{name: "invalid version", serviceName: "MeowService", version: "2", wantErr: "API version must be"},
+		{name: "existing version", serviceName: "MeowService", version: "v1", wantErr: "MeowService v1 already exists"},
+		{name: "unknown service", serviceName: "PurrService", version: "v2", wantErr: "service PurrService is not declared"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewVersionGeneratorWithFS(templateProjectFS(t), templates.NewMemoryWriter()).AddVersion(tt.serviceName, tt.version)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,292 @@
+// Package proto parses .proto files without protoc or the buf registry.
+//
+// The parser covers the subset of proto2/proto3 used by Meower projects:
+// packages, imports, options, messages (with nested messages, enums, oneofs,
+// maps and reserved ranges), enums and services. Every node records where it
+// appears in the source so generators can edit files in place and linters can
+// report file:line positions.
+package proto
+
+import "fmt"
+
+// Position is a location in a .proto source file
+type Position struct {
+	Line   int
+	Column int
+	Offset int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Option is an `option name = value;` statement or a `[name = value]` field option.
+// Aggregate values (`{ ... }`) are kept as their raw source text without braces.
+type Option struct {
+	Name  string
+	Value string
+	Pos   Position
+}
+
+// File is a parsed .proto file
+type File struct {
+	Path     string
+	Syntax   string
+	Package  string
+	Imports  []*Import
+	Options  []*Option
+	Messages []*Message
+	Enums    []*Enum
+	Services []*Service
+	// PackagePos is the position of the package statement
+	PackagePos Position
+	// End is the offset just past the last byte of the source
+	End int
+}
+
+// Import is an import statement
+type Import struct {
+	Path   string
+	Public bool
+	Weak   bool
+	Pos    Position
+	// End is the offset just past the terminating semicolon
+	End int
+}
+
+// HasImport reports whether the file imports path
+func (f *File) HasImport(path string) bool {
+	for _, imp := range f.Imports {
+		if imp.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// Option returns the value of a file-level option, or "" if unset
+func (f *File) Option(name string) string {
+	return findOption(f.Options, name)
+}
+
+// Service returns the service with the given name, or nil
+func (f *File) Service(name string) *Service {
+	for _, service := range f.Services {
+		if service.Name == name {
+			return service
+		}
+	}
+	return nil
+}
+
+// Message returns the top-level message with the given name, or nil
+func (f *File) Message(name string) *Message {
+	for _, message := range f.Messages {
+		if message.Name == name {
+			return message
+		}
+	}
+	return nil
+}
+
+// Enum returns the top-level enum with the given name, or nil
+func (f *File) Enum(name string) *Enum {
+	for _, enum := range f.Enums {
+		if enum.Name == name {
+			return enum
+		}
+	}
+	return nil
+}
+
+// AllMessages returns every message in the file, including nested ones,
+// keyed by their name relative to the package (e.g. "Outer.Inner")
+func (f *File) AllMessages() map[string]*Message {
+	messages := make(map[string]*Message)
+	var walk func(prefix string, list []*Message)
+	walk = func(prefix string, list []*Message) {
+		for _, message := range list {
+			name := prefix + message.Name
+			messages[name] = message
+			walk(name+".", message.Messages)
+		}
+	}
+	walk("", f.Messages)
+	return messages
+}
+
+// AllEnums returns every enum in the file, including nested ones, keyed by
+// their name relative to the package
+func (f *File) AllEnums() map[string]*Enum {
+	enums := make(map[string]*Enum)
+	for _, enum := range f.Enums {
+		enums[enum.Name] = enum
+	}
+	for name, message := range f.AllMessages() {
+		for _, enum := range message.Enums {
+			enums[name+"."+enum.Name] = enum
+		}
+	}
+	return enums
+}
+
+// Message is a message definition
+type Message struct {
+	Name     string
+	Comment  string
+	Fields   []*Field
+	Messages []*Message
+	Enums    []*Enum
+	Options  []*Option
+	// ReservedNumbers holds reserved tag ranges, inclusive
+	ReservedNumbers []Range
+	ReservedNames   []string
+	Pos             Position
+	// Close is the position of the closing brace
+	Close Position
+}
+
+// Field returns the field with the given name, or nil
+func (m *Message) Field(name string) *Field {
+	for _, field := range m.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// FieldByNumber returns the field with the given tag number, or nil
+func (m *Message) FieldByNumber(number int) *Field {
+	for _, field := range m.Fields {
+		if field.Number == number {
+			return field
+		}
+	}
+	return nil
+}
+
+// IsReserved reports whether a tag number or field name is reserved
+func (m *Message) IsReserved(number int, name string) bool {
+	for _, r := range m.ReservedNumbers {
+		if number >= r.Start && number <= r.End {
+			return true
+		}
+	}
+	for _, reserved := range m.ReservedNames {
+		if reserved == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Range is an inclusive range of tag numbers
+type Range struct {
+	Start int
+	End   int
+}
+
+// Field is a message field
+type Field struct {
+	Name    string
+	Type    string
+	Number  int
+	Comment string
+	// Label is "repeated", "optional", "required" or "" for singular fields
+	Label string
+	// KeyType is set for map fields, whose Type is the value type
+	KeyType string
+	// Oneof is the name of the enclosing oneof, if any
+	Oneof   string
+	Options []*Option
+	Pos     Position
+}
+
+// Repeated reports whether the field is a repeated (non-map) field
+func (f *Field) Repeated() bool {
+	return f.Label == "repeated"
+}
+
+// IsMap reports whether the field is a map field
+func (f *Field) IsMap() bool {
+	return f.KeyType != ""
+}
+
+// TypeString returns the field's full type as written in source,
+// e.g. "repeated Meow" or "map<string, int32>"
+func (f *Field) TypeString() string {
+	switch {
+	case f.IsMap():
+		return fmt.Sprintf("map<%s, %s>", f.KeyType, f.Type)
+	case f.Label != "":
+		return f.Label + " " + f.Type
+	default:
+		return f.Type
+	}
+}
+
+// Enum is an enum definition
+type Enum struct {
+	Name    string
+	Comment string
+	Values  []*EnumValue
+	Options []*Option
+	Pos     Position
+	Close   Position
+}
+
+// EnumValue is a single enum constant
+type EnumValue struct {
+	Name    string
+	Number  int
+	Comment string
+	Pos     Position
+}
+
+// Service is a service definition
+type Service struct {
+	Name    string
+	Comment string
+	RPCs    []*RPC
+	Options []*Option
+	Pos     Position
+	// Close is the position of the closing brace
+	Close Position
+}
+
+// RPC returns the rpc with the given name, or nil
+func (s *Service) RPC(name string) *RPC {
+	for _, rpc := range s.RPCs {
+		if rpc.Name == name {
+			return rpc
+		}
+	}
+	return nil
+}
+
+// RPC is a service method
+type RPC struct {
+	Name            string
+	Comment         string
+	Request         string
+	Response        string
+	ClientStreaming bool
+	ServerStreaming bool
+	Options         []*Option
+	Pos             Position
+}
+
+// Option returns the value of an rpc option, or "" if unset
+func (r *RPC) Option(name string) string {
+	return findOption(r.Options, name)
+}
+
+func findOption(options []*Option, name string) string {
+	for _, option := range options {
+		if option.Name == name {
+			return option.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,232 @@
+package proto
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Breaking change rule identifiers
+const (
+	RuleServiceNoDelete    = "service-no-delete"
+	RuleRPCNoDelete        = "rpc-no-delete"
+	RuleRPCSameTypes       = "rpc-same-types"
+	RuleRPCSameStreaming   = "rpc-same-streaming"
+	RuleMessageNoDelete    = "message-no-delete"
+	RuleFieldNoDelete      = "field-no-delete"
+	RuleFieldSameNumber    = "field-same-number"
+	RuleFieldSameName      = "field-same-name"
+	RuleFieldSameType      = "field-same-type"
+	RuleFieldSameOneof     = "field-same-oneof"
+	RuleEnumNoDelete       = "enum-no-delete"
+	RuleEnumValueNoDelete  = "enum-value-no-delete"
+	RuleEnumValueSameValue = "enum-value-same-number"
+)
+
+// Breaking compares the current version of a set of .proto files against a
+// previous one and reports changes that break existing clients: deleted
+// services, rpcs, messages, fields and enum values, renumbered fields and
+// changed types. Definitions are matched by fully-qualified name, so moving
+// a message between files of the same package is not a breaking change.
+// Issues point at the current files, or at the previous files for deletions.
+func Breaking(current, previous []*File) []Issue {
+	b := &breaking{
+		current:      index(current),
+		previous:     index(previous),
+		currentTypes: newTypeIndex(current),
+		prevTypes:    newTypeIndex(previous),
+	}
+
+	for _, name := range sortedNames(b.previous.services) {
+		b.compareService(name)
+	}
+	for _, name := range sortedNames(b.previous.messages) {
+		b.compareMessage(name)
+	}
+	for _, name := range sortedNames(b.previous.enums) {
+		b.compareEnum(name)
+	}
+
+	sortIssues(b.issues)
+	return b.issues
+}
+
+// located is a definition together with the file and scope declaring it
+type located[T any] struct {
+	file  *File
+	scope string
+	def   T
+}
+
+// definitions indexes services, messages and enums by fully-qualified name
+type definitions struct {
+	services map[string]located[*Service]
+	messages map[string]located[*Message]
+	enums    map[string]located[*Enum]
+}
+
+func index(files []*File) definitions {
+	defs := definitions{
+		services: make(map[string]located[*Service]),
+		messages: make(map[string]located[*Message]),
+		enums:    make(map[string]located[*Enum]),
+	}
+
+	var walk func(file *File, scope string, messages []*Message)
+	walk = func(file *File, scope string, messages []*Message) {
+		for _, message := range messages {
+			name := joinName(scope, message.Name)
+			defs.messages[joinName(file.Package, name)] = located[*Message]{file: file, scope: name, def: message}
+			for _, enum := range message.Enums {
+				defs.enums[joinName(file.Package, joinName(name, enum.Name))] = located[*Enum]{file: file, scope: name, def: enum}
+			}
+			walk(file, name, message.Messages)
+		}
+	}
+
+	for _, file := range files {
+		for _, service := range file.Services {
+			defs.services[joinName(file.Package, service.Name)] = located[*Service]{file: file, def: service}
+		}
+		for _, enum := range file.Enums {
+			defs.enums[joinName(file.Package, enum.Name)] = located[*Enum]{file: file, def: enum}
+		}
+		walk(file, "", file.Messages)
+	}
+	return defs
+}
+
+func sortedNames[T any](m map[string]T) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type breaking struct {
+	current      definitions
+	previous     definitions
+	currentTypes *typeIndex
+	prevTypes    *typeIndex
+	issues       []Issue
+}
+
+func (b *breaking) report(file *File, pos Position, rule, format string, args ...any) {
+	b.issues = append(b.issues, Issue{Path: file.Path, Pos: pos, Rule: rule, Message: fmt.Sprintf(format, args...)})
+}
+
+func (b *breaking) compareService(name string) {
+	prev := b.previous.services[name]
+	cur, ok := b.current.services[name]
+	if !ok {
+		b.report(prev.file, prev.def.Pos, RuleServiceNoDelete, "service %s was deleted", name)
+		return
+	}
+
+	for _, prevRPC := range prev.def.RPCs {
+		rpc := cur.def.RPC(prevRPC.Name)
+		if rpc == nil {
+			b.report(prev.file, prevRPC.Pos, RuleRPCNoDelete, "rpc %s was deleted from service %s", prevRPC.Name, name)
+			continue
+		}
+
+		prevReq := b.prevTypes.qualify(prev.file.Package, "", prevRPC.Request)
+		prevResp := b.prevTypes.qualify(prev.file.Package, "", prevRPC.Response)
+		req := b.currentTypes.qualify(cur.file.Package, "", rpc.Request)
+		resp := b.currentTypes.qualify(cur.file.Package, "", rpc.Response)
+		if prevReq != req {
+			b.report(cur.file, rpc.Pos, RuleRPCSameTypes, "rpc %s request changed from %s to %s", rpc.Name, prevReq, req)
+		}
+		if prevResp != resp {
+			b.report(cur.file, rpc.Pos, RuleRPCSameTypes, "rpc %s response changed from %s to %s", rpc.Name, prevResp, resp)
+		}
+
+		if prevRPC.ClientStreaming != rpc.ClientStreaming || prevRPC.ServerStreaming != rpc.ServerStreaming {
+			b.report(cur.file, rpc.Pos, RuleRPCSameStreaming, "rpc %s changed from %s to %s", rpc.Name, streamingKind(prevRPC), streamingKind(rpc))
+		}
+	}
+}
+
+func streamingKind(rpc *RPC) string {
+	switch {
+	case rpc.ClientStreaming && rpc.ServerStreaming:
+		return "bidirectional streaming"
+	case rpc.ClientStreaming:
+		return "client streaming"
+	case rpc.ServerStreaming:
+		return "server streaming"
+	}
+	return "unary"
+}
+
+func (b *breaking) compareMessage(name string) {
+	prev := b.previous.messages[name]
+	cur, ok := b.current.messages[name]
+	if !ok {
+		b.report(prev.file, prev.def.Pos, RuleMessageNoDelete, "message %s was deleted", name)
+		return
+	}
+
+	for _, prevField := range prev.def.Fields {
+		field := cur.def.FieldByNumber(prevField.Number)
+		if field == nil {
+			if moved := cur.def.Field(prevField.Name); moved != nil {
+				b.report(cur.file, moved.Pos, RuleFieldSameNumber, "field %s.%s was renumbered from %d to %d", cur.def.Name, moved.Name, prevField.Number, moved.Number)
+			} else if !cur.def.IsReserved(prevField.Number, "") {
+				b.report(cur.file, cur.def.Pos, RuleFieldNoDelete, "field %d (%s) was deleted from %s without reserving its number", prevField.Number, prevField.Name, cur.def.Name)
+			}
+			continue
+		}
+
+		if field.Name != prevField.Name {
+			b.report(cur.file, field.Pos, RuleFieldSameName, "field %d of %s was renamed from %s to %s", field.Number, cur.def.Name, prevField.Name, field.Name)
+		}
+
+		prevType := b.fieldType(b.prevTypes, prev, prevField)
+		curType := b.fieldType(b.currentTypes, cur, field)
+		if prevType != curType {
+			b.report(cur.file, field.Pos, RuleFieldSameType, "field %s.%s changed type from %s to %s", cur.def.Name, field.Name, prevType, curType)
+		}
+
+		if field.Oneof != prevField.Oneof {
+			b.report(cur.file, field.Pos, RuleFieldSameOneof, "field %s.%s moved from oneof %q to %q", cur.def.Name, field.Name, prevField.Oneof, field.Oneof)
+		}
+	}
+}
+
+// fieldType returns the field's type with message and enum names fully qualified
+func (b *breaking) fieldType(types *typeIndex, message located[*Message], field *Field) string {
+	resolved := *field
+	resolved.Type = types.qualify(message.file.Package, message.scope, field.Type)
+	if field.IsMap() {
+		resolved.KeyType = types.qualify(message.file.Package, message.scope, field.KeyType)
+	}
+	return resolved.TypeString()
+}
+
+func (b *breaking) compareEnum(name string) {
+	prev := b.previous.enums[name]
+	cur, ok := b.current.enums[name]
+	if !ok {
+		b.report(prev.file, prev.def.Pos, RuleEnumNoDelete, "enum %s was deleted", name)
+		return
+	}
+
+	for _, prevValue := range prev.def.Values {
+		var value *EnumValue
+		for _, v := range cur.def.Values {
+			if v.Name == prevValue.Name {
+				value = v
+				break
+			}
+		}
+
+		switch {
+		case value == nil:
+			b.report(cur.file, cur.def.Pos, RuleEnumValueNoDelete, "enum value %s was deleted from %s", prevValue.Name, cur.def.Name)
+		case value.Number != prevValue.Number:
+			b.report(cur.file, value.Pos, RuleEnumValueSameValue, "enum value %s changed from %d to %d", value.Name, prevValue.Number, value.Number)
+		}
+	}
+}
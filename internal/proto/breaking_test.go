@@ -0,0 +1,131 @@
+package proto
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBreaking(t *testing.T) {
+	previous := `syntax = "proto3";
+package meow.v1;
+import "google/protobuf/timestamp.proto";
+service MeowService {
+  rpc GetMeow(GetMeowRequest) returns (GetMeowResponse) {}
+  rpc WatchMeows(WatchMeowsRequest) returns (stream WatchMeowsResponse) {}
+}
+message Meow {
+  string id = 1;
+  string content = 2;
+  google.protobuf.Timestamp created_at = 3;
+  Kind kind = 4;
+}
+enum Kind {
+  KIND_UNSPECIFIED = 0;
+  KIND_MEOW = 1;
+}
+message GetMeowRequest { string id = 1; }
+message GetMeowResponse { Meow meow = 1; }
+message WatchMeowsRequest {}
+message WatchMeowsResponse { Meow meow = 1; }
+`
+
+	tests := []struct {
+		name      string
+		current   string
+		wantRules []string
+	}{
+		{
+			name:    "unchanged",
+			current: previous,
+		},
+		{
+			name:    "added field and rpc",
+			current: strings.Replace(strings.Replace(previous, "Kind kind = 4;", "Kind kind = 4;\n  string author = 5;", 1), "service MeowService {", "service MeowService {\n  rpc Like(GetMeowRequest) returns (GetMeowResponse) {}", 1),
+		},
+		{
+			name:    "fully qualified type is the same type",
+			current: strings.Replace(previous, "GetMeowResponse { Meow meow = 1; }", "GetMeowResponse { .meow.v1.Meow meow = 1; }", 1),
+		},
+		{
+			name:      "removed field",
+			current:   strings.Replace(previous, "  string content = 2;\n", "", 1),
+			wantRules: []string{RuleFieldNoDelete},
+		},
+		{
+			name:    "removed field with reserved number",
+			current: strings.Replace(previous, "  string content = 2;\n", "  reserved 2;\n", 1),
+		},
+		{
+			name:      "renumbered field",
+			current:   strings.Replace(previous, "string content = 2;", "string content = 5;", 1),
+			wantRules: []string{RuleFieldSameNumber},
+		},
+		{
+			name:      "renamed field",
+			current:   strings.Replace(previous, "string content = 2;", "string body = 2;", 1),
+			wantRules: []string{RuleFieldSameName},
+		},
+		{
+			name:      "changed field type",
+			current:   strings.Replace(previous, "string content = 2;", "bytes content = 2;", 1),
+			wantRules: []string{RuleFieldSameType},
+		},
+		{
+			name:      "repeated field",
+			current:   strings.Replace(previous, "string content = 2;", "repeated string content = 2;", 1),
+			wantRules: []string{RuleFieldSameType},
+		},
+		{
+			name:      "removed rpc",
+			current:   strings.Replace(previous, "  rpc GetMeow(GetMeowRequest) returns (GetMeowResponse) {}\n", "", 1),
+			wantRules: []string{RuleRPCNoDelete},
+		},
+		{
+			name:      "changed streaming",
+			current:   strings.Replace(previous, "returns (stream WatchMeowsResponse)", "returns (WatchMeowsResponse)", 1),
+			wantRules: []string{RuleRPCSameStreaming},
+		},
+		{
+			name:      "removed message and enum value",
+			current:   strings.Replace(strings.Replace(previous, "message WatchMeowsRequest {}\n", "", 1), "  KIND_MEOW = 1;\n", "", 1),
+			wantRules: []string{RuleEnumValueNoDelete, RuleMessageNoDelete},
+		},
+	}
+
+	prev, err := Parse("meow/v1/meow.proto", []byte(previous))
+	if err != nil {
+		t.Fatalf("Parse(previous) error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cur, err := Parse("meow/v1/meow.proto", []byte(tt.current))
+			if err != nil {
+				t.Fatalf("Parse(current) error = %v", err)
+			}
+
+			var rules []string
+			for _, issue := range Breaking([]*File{cur}, []*File{prev}) {
+				rules = append(rules, issue.Rule)
+			}
+			if strings.Join(rules, ",") != strings.Join(tt.wantRules, ",") {
+				t.Errorf("Breaking() rules = %v, want %v", rules, tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestBreakingMovedBetweenFiles(t *testing.T) {
+	previous, err := Parse("meow/v1/meow.proto", []byte("package meow.v1;\nmessage Meow { string id = 1; }\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	moved, err := Parse("meow/v1/types.proto", []byte("package meow.v1;\nmessage Meow { string id = 1; }\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if issues := Breaking([]*File{moved}, []*File{previous}); len(issues) != 0 {
+		t.Errorf("Breaking() = %v, want no issues", issues)
+	}
+}
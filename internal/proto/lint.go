@@ -0,0 +1,254 @@
+package proto
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/AlyxPink/meower/internal/validation"
+)
+
+// Lint rule identifiers
+const (
+	RulePackageDefined        = "package-defined"
+	RulePackageLowerSnakeCase = "package-lower-snake-case"
+	RulePackageVersionSuffix  = "package-version-suffix"
+	RulePackageDirectoryMatch = "package-directory-match"
+	RuleGoPackageDefined      = "go-package-defined"
+	RuleServiceName           = "service-name"
+	RuleRPCName               = "rpc-pascal-case"
+	RuleRPCRequestName        = "rpc-request-standard-name"
+	RuleRPCResponseName       = "rpc-response-standard-name"
+	RuleMessageName           = "message-pascal-case"
+	RuleFieldName             = "field-lower-snake-case"
+	RuleFieldNumber           = "field-number-valid"
+	RuleFieldUnique           = "field-unique"
+	RuleEnumName              = "enum-pascal-case"
+	RuleEnumValueName         = "enum-value-upper-snake-case"
+	RuleEnumValuePrefix       = "enum-value-prefix"
+	RuleEnumZeroValue         = "enum-zero-value-suffix"
+	RuleTypeDefined           = "type-defined"
+)
+
+var (
+	packagePartRegex    = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	packageVersionRegex = regexp.MustCompile(`^v[0-9]+((alpha|beta)[0-9]*)?$`)
+	pascalCaseRegex     = regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`)
+	upperSnakeCaseRegex = regexp.MustCompile(`^[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$`)
+)
+
+// maxFieldNumber is the largest tag number protobuf allows
+const maxFieldNumber = 536870911
+
+// Issue is a problem reported by Lint or Breaking
+type Issue struct {
+	Path    string
+	Pos     Position
+	Rule    string
+	Message string
+}
+
+func (i Issue) String() string {
+	location := i.Path
+	if i.Pos.Line > 0 {
+		location = fmt.Sprintf("%s:%d:%d", i.Path, i.Pos.Line, i.Pos.Column)
+	}
+	return fmt.Sprintf("%s: [%s] %s", location, i.Rule, i.Message)
+}
+
+// sortIssues orders issues by file and position
+func sortIssues(issues []Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Path != issues[j].Path {
+			return issues[i].Path < issues[j].Path
+		}
+		return issues[i].Pos.Offset < issues[j].Pos.Offset
+	})
+}
+
+// Lint checks files against Meower's protobuf conventions. File paths are
+// expected to be relative to the proto root (api/proto), so that a file in
+// meow/v1 declares package meow.v1. Service names follow the same rules as
+// `meower create handler`.
+func Lint(files []*File) []Issue {
+	l := &linter{types: newTypeIndex(files)}
+	for _, file := range files {
+		l.lintFile(file)
+	}
+	sortIssues(l.issues)
+	return l.issues
+}
+
+type linter struct {
+	types  *typeIndex
+	issues []Issue
+}
+
+func (l *linter) report(file *File, pos Position, rule, format string, args ...any) {
+	l.issues = append(l.issues, Issue{Path: file.Path, Pos: pos, Rule: rule, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *linter) lintFile(file *File) {
+	validator := validation.NewValidator()
+
+	if file.Package == "" {
+		l.report(file, Position{}, RulePackageDefined, "file does not declare a package")
+	} else {
+		parts := strings.Split(file.Package, ".")
+		for _, part := range parts {
+			if !packagePartRegex.MatchString(part) {
+				l.report(file, file.PackagePos, RulePackageLowerSnakeCase, "package %q must be lower_snake_case", file.Package)
+				break
+			}
+		}
+		if !packageVersionRegex.MatchString(parts[len(parts)-1]) {
+			l.report(file, file.PackagePos, RulePackageVersionSuffix, "package %q must end with a version such as .v1", file.Package)
+		}
+		if dir, want := path.Dir(file.Path), strings.ReplaceAll(file.Package, ".", "/"); dir != want {
+			l.report(file, file.PackagePos, RulePackageDirectoryMatch, "package %q must be declared in directory %s, not %s", file.Package, want, dir)
+		}
+	}
+
+	if file.Option("go_package") == "" {
+		l.report(file, Position{}, RuleGoPackageDefined, "file does not set option go_package")
+	}
+
+	for _, service := range file.Services {
+		if err := validator.Service.ValidateGRPCServiceName(service.Name); err != nil {
+			l.report(file, service.Pos, RuleServiceName, "%s", validationMessage(err))
+		}
+		for _, rpc := range service.RPCs {
+			if err := validator.Service.ValidateMethodName(rpc.Name); err != nil {
+				l.report(file, rpc.Pos, RuleRPCName, "rpc %q must be PascalCase", rpc.Name)
+			}
+			if want := rpc.Name + "Request"; baseName(rpc.Request) != want {
+				l.report(file, rpc.Pos, RuleRPCRequestName, "rpc %s request should be named %s, not %s", rpc.Name, want, rpc.Request)
+			}
+			if want := rpc.Name + "Response"; baseName(rpc.Response) != want {
+				l.report(file, rpc.Pos, RuleRPCResponseName, "rpc %s response should be named %s, not %s", rpc.Name, want, rpc.Response)
+			}
+			for _, typ := range []string{rpc.Request, rpc.Response} {
+				if !l.types.resolves(file.Package, "", typ) {
+					l.report(file, rpc.Pos, RuleTypeDefined, "rpc %s uses undefined message %s", rpc.Name, typ)
+				}
+			}
+		}
+	}
+
+	for _, enum := range file.Enums {
+		l.lintEnum(file, enum)
+	}
+	for _, message := range file.Messages {
+		l.lintMessage(file, "", message)
+	}
+}
+
+func (l *linter) lintMessage(file *File, scope string, message *Message) {
+	validator := validation.NewValidator()
+
+	if !pascalCaseRegex.MatchString(message.Name) {
+		l.report(file, message.Pos, RuleMessageName, "message %q must be PascalCase", message.Name)
+	}
+	scope = joinName(scope, message.Name)
+
+	names := make(map[string]bool)
+	numbers := make(map[int]string)
+	for _, field := range message.Fields {
+		if err := validator.Service.ValidateFieldName(field.Name); err != nil {
+			l.report(file, field.Pos, RuleFieldName, "field %s.%s must be lower_snake_case", message.Name, field.Name)
+		}
+
+		switch {
+		case field.Number < 1 || field.Number > maxFieldNumber:
+			l.report(file, field.Pos, RuleFieldNumber, "field %s.%s number %d is out of range 1-%d", message.Name, field.Name, field.Number, maxFieldNumber)
+		case field.Number >= 19000 && field.Number <= 19999:
+			l.report(file, field.Pos, RuleFieldNumber, "field %s.%s number %d is reserved for the protobuf implementation", message.Name, field.Name, field.Number)
+		case message.IsReserved(field.Number, field.Name):
+			l.report(file, field.Pos, RuleFieldNumber, "field %s.%s uses a reserved name or number", message.Name, field.Name)
+		}
+
+		if names[field.Name] {
+			l.report(file, field.Pos, RuleFieldUnique, "field name %s is declared more than once in %s", field.Name, message.Name)
+		}
+		if other, ok := numbers[field.Number]; ok {
+			l.report(file, field.Pos, RuleFieldUnique, "field %s reuses number %d of field %s in %s", field.Name, field.Number, other, message.Name)
+		}
+		names[field.Name] = true
+		numbers[field.Number] = field.Name
+
+		for _, typ := range []string{field.KeyType, field.Type} {
+			if typ != "" && !isScalar(typ) && !l.types.resolves(file.Package, scope, typ) {
+				l.report(file, field.Pos, RuleTypeDefined, "field %s.%s uses undefined type %s", message.Name, field.Name, typ)
+			}
+		}
+	}
+
+	for _, enum := range message.Enums {
+		l.lintEnum(file, enum)
+	}
+	for _, nested := range message.Messages {
+		l.lintMessage(file, scope, nested)
+	}
+}
+
+func (l *linter) lintEnum(file *File, enum *Enum) {
+	if !pascalCaseRegex.MatchString(enum.Name) {
+		l.report(file, enum.Pos, RuleEnumName, "enum %q must be PascalCase", enum.Name)
+	}
+
+	prefix := upperSnakeCase(enum.Name) + "_"
+	for i, value := range enum.Values {
+		if !upperSnakeCaseRegex.MatchString(value.Name) {
+			l.report(file, value.Pos, RuleEnumValueName, "enum value %s must be UPPER_SNAKE_CASE", value.Name)
+		}
+		if !strings.HasPrefix(value.Name, prefix) {
+			l.report(file, value.Pos, RuleEnumValuePrefix, "enum value %s must be prefixed with %s", value.Name, prefix)
+		}
+		if i == 0 && (value.Number != 0 || !strings.HasSuffix(value.Name, "_UNSPECIFIED")) {
+			l.report(file, value.Pos, RuleEnumZeroValue, "first value of enum %s must be %sUNSPECIFIED = 0", enum.Name, prefix)
+		}
+	}
+}
+
+// validationMessage returns the human part of a validation error
+func validationMessage(err error) string {
+	if verr, ok := err.(validation.ValidationError); ok {
+		return verr.Message
+	}
+	return err.Error()
+}
+
+// upperSnakeCase converts PascalCase to UPPER_SNAKE_CASE
+func upperSnakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			prev := name[i-1]
+			next := byte(0)
+			if i+1 < len(name) {
+				next = name[i+1]
+			}
+			// Split before an upper case letter that follows a lower case
+			// letter or digit, or that starts a word after an acronym
+			if prev >= 'a' && prev <= 'z' || prev >= '0' && prev <= '9' || (prev >= 'A' && prev <= 'Z' && next >= 'a' && next <= 'z') {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToUpper(b.String())
+}
+
+// baseName returns the last component of a possibly qualified type name
+func baseName(typ string) string {
+	return typ[strings.LastIndex(typ, ".")+1:]
+}
+
+func joinName(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
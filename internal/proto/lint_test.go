@@ -0,0 +1,130 @@
+package proto
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLintTemplateProtos(t *testing.T) {
+	files, err := ParseDir(os.DirFS(filepath.Join("..", "..", "cmd", "meower", "template", "api", "proto")), ".")
+	if err != nil {
+		t.Fatalf("ParseDir() error = %v", err)
+	}
+
+	for _, issue := range Lint(files) {
+		t.Errorf("template proto does not pass lint: %s", issue)
+	}
+}
+
+func TestLint(t *testing.T) {
+	valid := `syntax = "proto3";
+package meow.v1;
+option go_package = "example.com/api/proto/meow/v1";
+service MeowService {
+  rpc GetMeow(GetMeowRequest) returns (GetMeowResponse) {}
+}
+message Meow {
+  string id = 1;
+  Visibility visibility = 2;
+  enum Visibility {
+    VISIBILITY_UNSPECIFIED = 0;
+    VISIBILITY_PUBLIC = 1;
+  }
+}
+message GetMeowRequest { string id = 1; }
+message GetMeowResponse { Meow meow = 1; }
+`
+
+	tests := []struct {
+		name      string
+		path      string
+		src       string
+		wantRules []string
+	}{
+		{
+			name: "valid file",
+			path: "meow/v1/meow.proto",
+			src:  valid,
+		},
+		{
+			name:      "package without version",
+			path:      "meow/meow.proto",
+			src:       strings.Replace(strings.Replace(valid, "meow.v1", "meow", 1), "/v1\"", "\"", 1),
+			wantRules: []string{RulePackageVersionSuffix},
+		},
+		{
+			name:      "package in wrong directory",
+			path:      "cat/v1/meow.proto",
+			src:       valid,
+			wantRules: []string{RulePackageDirectoryMatch},
+		},
+		{
+			name:      "missing go_package",
+			path:      "meow/v1/meow.proto",
+			src:       strings.Replace(valid, `option go_package = "example.com/api/proto/meow/v1";`, "", 1),
+			wantRules: []string{RuleGoPackageDefined},
+		},
+		{
+			name:      "service without Service suffix",
+			path:      "meow/v1/meow.proto",
+			src:       strings.Replace(valid, "MeowService", "Meows", 1),
+			wantRules: []string{RuleServiceName},
+		},
+		{
+			name:      "non-standard request name and undefined type",
+			path:      "meow/v1/meow.proto",
+			src:       strings.Replace(valid, "rpc GetMeow(GetMeowRequest)", "rpc GetMeow(MeowQuery)", 1),
+			wantRules: []string{RuleRPCRequestName, RuleTypeDefined},
+		},
+		{
+			name:      "camelCase field and duplicate number",
+			path:      "meow/v1/meow.proto",
+			src:       strings.Replace(valid, "string id = 1;\n  Visibility", "string id = 1;\n  string createdAt = 1;\n  Visibility", 1),
+			wantRules: []string{RuleFieldName, RuleFieldUnique},
+		},
+		{
+			name:      "enum zero value",
+			path:      "meow/v1/meow.proto",
+			src:       strings.Replace(valid, "VISIBILITY_UNSPECIFIED = 0;\n    VISIBILITY_PUBLIC = 1;", "VISIBILITY_PUBLIC = 0;\n    PRIVATE = 1;", 1),
+			wantRules: []string{RuleEnumZeroValue, RuleEnumValuePrefix},
+		},
+		{
+			name:      "reserved field number",
+			path:      "meow/v1/meow.proto",
+			src:       strings.Replace(valid, "message Meow {\n", "message Meow {\n  reserved 2;\n", 1),
+			wantRules: []string{RuleFieldNumber},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := Parse(tt.path, []byte(tt.src))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			var rules []string
+			for _, issue := range Lint([]*File{file}) {
+				rules = append(rules, issue.Rule)
+			}
+			if strings.Join(rules, ",") != strings.Join(tt.wantRules, ",") {
+				t.Errorf("Lint() rules = %v, want %v", rules, tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestUpperSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"Visibility": "VISIBILITY",
+		"MeowKind":   "MEOW_KIND",
+		"HTTPMethod": "HTTP_METHOD",
+	}
+	for input, expected := range tests {
+		if got := upperSnakeCase(input); got != expected {
+			t.Errorf("upperSnakeCase(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
@@ -0,0 +1,884 @@
+package proto
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ParseError is a syntax error at a position in a .proto file
+type ParseError struct {
+	Path    string
+	Pos     Position
+	Message string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s:%s: %s", e.Path, e.Pos, e.Message)
+}
+
+// ParseFile reads and parses a .proto file from disk
+func ParseFile(path string) (*File, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(path, src)
+}
+
+// ParseDir parses every .proto file under dir in fsys. File paths are
+// slash-separated and relative to dir, and files are returned in path order.
+func ParseDir(fsys fs.FS, dir string) ([]*File, error) {
+	sources := make(map[string][]byte)
+	err := fs.WalkDir(fsys, dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".proto") {
+			return err
+		}
+		src, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+		rel := strings.TrimPrefix(strings.TrimPrefix(path, dir), "/")
+		sources[rel] = src
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ParseSources(sources)
+}
+
+// ParseSources parses .proto sources keyed by path, returning them in path order
+func ParseSources(sources map[string][]byte) ([]*File, error) {
+	paths := make([]string, 0, len(sources))
+	for path := range sources {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var files []*File
+	for _, path := range paths {
+		file, err := Parse(filepath.ToSlash(path), sources[path])
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// Parse parses the source of a .proto file. path is only used in errors.
+func Parse(path string, src []byte) (*File, error) {
+	tokens, err := tokenize(path, src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{path: path, tokens: tokens}
+	file, err := p.parseFile()
+	if err != nil {
+		return nil, err
+	}
+	file.Path = path
+	file.End = len(src)
+	return file, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenSymbol
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	// value is the unquoted content of string tokens
+	value string
+	pos   Position
+	// end is the offset just past the token
+	end int
+	// comment holds the comments directly preceding the token
+	comment string
+}
+
+// tokenize splits src into tokens, attaching comments to the following token
+func tokenize(path string, src []byte) ([]token, error) {
+	var tokens []token
+	var comments []string
+	line, col := 1, 1
+	i := 0
+
+	advance := func(n int) {
+		for k := 0; k < n && i < len(src); k++ {
+			if src[i] == '\n' {
+				line++
+				col = 1
+			} else {
+				col++
+			}
+			i++
+		}
+	}
+
+	for i < len(src) {
+		c := src[i]
+		pos := Position{Line: line, Column: col, Offset: i}
+
+		switch {
+		case c == '\n':
+			// A blank line detaches comments from the next declaration
+			if i+1 < len(src) && src[i+1] == '\n' {
+				comments = nil
+			}
+			advance(1)
+
+		case unicode.IsSpace(rune(c)):
+			advance(1)
+
+		case c == '/' && i+1 < len(src) && src[i+1] == '/':
+			end := i
+			for end < len(src) && src[end] != '\n' {
+				end++
+			}
+			comments = append(comments, strings.TrimSpace(string(src[i+2:end])))
+			advance(end - i)
+
+		case c == '/' && i+1 < len(src) && src[i+1] == '*':
+			end := strings.Index(string(src[i+2:]), "*/")
+			if end < 0 {
+				return nil, &ParseError{Path: path, Pos: pos, Message: "unterminated block comment"}
+			}
+			body := string(src[i+2 : i+2+end])
+			for _, l := range strings.Split(body, "\n") {
+				l = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(l), "*"))
+				if l != "" {
+					comments = append(comments, l)
+				}
+			}
+			advance(end + 4)
+
+		case c == '"' || c == '\'':
+			end := i + 1
+			var value strings.Builder
+			for end < len(src) && src[end] != c {
+				if src[end] == '\\' && end+1 < len(src) {
+					end++
+				}
+				if src[end] == '\n' {
+					return nil, &ParseError{Path: path, Pos: pos, Message: "unterminated string"}
+				}
+				value.WriteByte(src[end])
+				end++
+			}
+			if end >= len(src) {
+				return nil, &ParseError{Path: path, Pos: pos, Message: "unterminated string"}
+			}
+			tokens = append(tokens, token{kind: tokenString, text: string(src[i : end+1]), value: value.String(), pos: pos, end: end + 1, comment: strings.Join(comments, "\n")})
+			comments = nil
+			advance(end + 1 - i)
+
+		case isIdentStart(c):
+			end := i
+			for end < len(src) && isIdentPart(src[end]) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(src[i:end]), pos: pos, end: end, comment: strings.Join(comments, "\n")})
+			comments = nil
+			advance(end - i)
+
+		case c >= '0' && c <= '9' || (c == '-' || c == '+') && i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9':
+			end := i + 1
+			for end < len(src) && (isIdentPart(src[end]) || src[end] == '.' || ((src[end] == '-' || src[end] == '+') && (src[end-1] == 'e' || src[end-1] == 'E'))) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: string(src[i:end]), pos: pos, end: end, comment: strings.Join(comments, "\n")})
+			comments = nil
+			advance(end - i)
+
+		case strings.ContainsRune("{}()[]<>;=,:-/", rune(c)):
+			tokens = append(tokens, token{kind: tokenSymbol, text: string(c), pos: pos, end: i + 1, comment: strings.Join(comments, "\n")})
+			comments = nil
+			advance(1)
+
+		default:
+			return nil, &ParseError{Path: path, Pos: pos, Message: fmt.Sprintf("unexpected character %q", c)}
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, pos: Position{Line: line, Column: col, Offset: len(src)}, end: len(src)})
+	return tokens, nil
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c == '.' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || c >= '0' && c <= '9'
+}
+
+type parser struct {
+	path   string
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) errorf(t token, format string, args ...any) error {
+	return &ParseError{Path: p.path, Pos: t.pos, Message: fmt.Sprintf(format, args...)}
+}
+
+// accept consumes the next token if its text matches
+func (p *parser) accept(text string) bool {
+	if t := p.peek(); t.kind != tokenString && t.text == text {
+		p.next()
+		return true
+	}
+	return false
+}
+
+// expect consumes the next token, failing unless its text matches
+func (p *parser) expect(text string) (token, error) {
+	t := p.next()
+	if t.kind == tokenString || t.text != text {
+		return t, p.errorf(t, "expected %q, found %q", text, t.text)
+	}
+	return t, nil
+}
+
+func (p *parser) ident() (token, error) {
+	t := p.next()
+	if t.kind != tokenIdent {
+		return t, p.errorf(t, "expected identifier, found %q", t.text)
+	}
+	return t, nil
+}
+
+func (p *parser) str() (token, error) {
+	t := p.next()
+	if t.kind != tokenString {
+		return t, p.errorf(t, "expected string, found %q", t.text)
+	}
+	return t, nil
+}
+
+func (p *parser) number() (int, token, error) {
+	t := p.next()
+	if t.kind != tokenNumber {
+		return 0, t, p.errorf(t, "expected number, found %q", t.text)
+	}
+	n, err := strconv.ParseInt(t.text, 0, 64)
+	if err != nil {
+		return 0, t, p.errorf(t, "invalid number %q", t.text)
+	}
+	return int(n), t, nil
+}
+
+func (p *parser) parseFile() (*File, error) {
+	file := &File{}
+
+	for {
+		t := p.peek()
+		if t.kind == tokenEOF {
+			return file, nil
+		}
+
+		switch t.text {
+		case ";":
+			p.next()
+		case "syntax", "edition":
+			p.next()
+			if _, err := p.expect("="); err != nil {
+				return nil, err
+			}
+			value, err := p.str()
+			if err != nil {
+				return nil, err
+			}
+			file.Syntax = value.value
+			if _, err := p.expect(";"); err != nil {
+				return nil, err
+			}
+		case "package":
+			p.next()
+			name, err := p.ident()
+			if err != nil {
+				return nil, err
+			}
+			file.Package = name.text
+			file.PackagePos = t.pos
+			if _, err := p.expect(";"); err != nil {
+				return nil, err
+			}
+		case "import":
+			p.next()
+			imp := &Import{Pos: t.pos}
+			imp.Public = p.accept("public")
+			if !imp.Public {
+				imp.Weak = p.accept("weak")
+			}
+			path, err := p.str()
+			if err != nil {
+				return nil, err
+			}
+			imp.Path = path.value
+			end, err := p.expect(";")
+			if err != nil {
+				return nil, err
+			}
+			imp.End = end.end
+			file.Imports = append(file.Imports, imp)
+		case "option":
+			option, err := p.parseOptionStatement()
+			if err != nil {
+				return nil, err
+			}
+			file.Options = append(file.Options, option)
+		case "message":
+			message, err := p.parseMessage()
+			if err != nil {
+				return nil, err
+			}
+			file.Messages = append(file.Messages, message)
+		case "enum":
+			enum, err := p.parseEnum()
+			if err != nil {
+				return nil, err
+			}
+			file.Enums = append(file.Enums, enum)
+		case "service":
+			service, err := p.parseService()
+			if err != nil {
+				return nil, err
+			}
+			file.Services = append(file.Services, service)
+		case "extend":
+			if err := p.skipDeclaration(); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, p.errorf(t, "unexpected %q", t.text)
+		}
+	}
+}
+
+// parseOptionStatement parses `option name = value;`
+func (p *parser) parseOptionStatement() (*Option, error) {
+	start, err := p.expect("option")
+	if err != nil {
+		return nil, err
+	}
+	option, err := p.parseOption()
+	if err != nil {
+		return nil, err
+	}
+	option.Pos = start.pos
+	if _, err := p.expect(";"); err != nil {
+		return nil, err
+	}
+	return option, nil
+}
+
+// parseOption parses `name = value`, where name may be a parenthesized extension
+func (p *parser) parseOption() (*Option, error) {
+	var name strings.Builder
+	start := p.peek()
+	for {
+		t := p.peek()
+		if t.text == "=" || t.kind == tokenEOF {
+			break
+		}
+		if t.kind == tokenString {
+			return nil, p.errorf(t, "unexpected string in option name")
+		}
+		name.WriteString(p.next().text)
+	}
+	if name.Len() == 0 {
+		return nil, p.errorf(start, "expected option name")
+	}
+	if _, err := p.expect("="); err != nil {
+		return nil, err
+	}
+
+	value, err := p.parseConstant()
+	if err != nil {
+		return nil, err
+	}
+	return &Option{Name: name.String(), Value: value, Pos: start.pos}, nil
+}
+
+// parseConstant parses a scalar constant or an aggregate `{ ... }` value
+func (p *parser) parseConstant() (string, error) {
+	t := p.next()
+	switch {
+	case t.text == "{" && t.kind == tokenSymbol:
+		depth := 1
+		var parts []string
+		for depth > 0 {
+			inner := p.next()
+			if inner.kind == tokenEOF {
+				return "", p.errorf(inner, "unterminated option value")
+			}
+			if inner.kind == tokenSymbol && inner.text == "{" {
+				depth++
+			}
+			if inner.kind == tokenSymbol && inner.text == "}" {
+				depth--
+				if depth == 0 {
+					break
+				}
+			}
+			parts = append(parts, inner.text)
+		}
+		return joinAggregate(parts), nil
+	case t.text == "-" && t.kind == tokenSymbol:
+		n := p.next()
+		return "-" + n.text, nil
+	case t.kind == tokenString:
+		value := t.value
+		// Adjacent string literals are concatenated
+		for p.peek().kind == tokenString {
+			value += p.next().value
+		}
+		return value, nil
+	case t.kind == tokenIdent || t.kind == tokenNumber:
+		return t.text, nil
+	}
+	return "", p.errorf(t, "expected constant, found %q", t.text)
+}
+
+// joinAggregate rebuilds the text of an aggregate option value from its tokens
+func joinAggregate(parts []string) string {
+	var b strings.Builder
+	for i, part := range parts {
+		if i > 0 && part != ":" && part != "," && part != ";" && parts[i-1] != "{" {
+			b.WriteByte(' ')
+		}
+		b.WriteString(part)
+	}
+	return b.String()
+}
+
+// parseBracketOptions parses `[name = value, ...]` if present
+func (p *parser) parseBracketOptions() ([]*Option, error) {
+	if !p.accept("[") {
+		return nil, nil
+	}
+	var options []*Option
+	for {
+		option, err := p.parseOption()
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, option)
+		if p.accept("]") {
+			return options, nil
+		}
+		if _, err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) parseMessage() (*Message, error) {
+	start, err := p.expect("message")
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	message := &Message{Name: name.text, Comment: start.comment, Pos: start.pos}
+
+	if _, err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	if err := p.parseMessageBody(message, ""); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// parseMessageBody parses message (or oneof) members up to the closing brace
+func (p *parser) parseMessageBody(message *Message, oneof string) error {
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == tokenEOF:
+			return p.errorf(t, "unexpected end of file in message %s", message.Name)
+		case t.text == "}" && t.kind == tokenSymbol:
+			p.next()
+			if oneof == "" {
+				message.Close = t.pos
+			}
+			return nil
+		case t.text == ";":
+			p.next()
+		case t.text == "option":
+			option, err := p.parseOptionStatement()
+			if err != nil {
+				return err
+			}
+			if oneof == "" {
+				message.Options = append(message.Options, option)
+			}
+		case t.text == "message" && oneof == "":
+			nested, err := p.parseMessage()
+			if err != nil {
+				return err
+			}
+			message.Messages = append(message.Messages, nested)
+		case t.text == "enum" && oneof == "":
+			enum, err := p.parseEnum()
+			if err != nil {
+				return err
+			}
+			message.Enums = append(message.Enums, enum)
+		case t.text == "oneof" && oneof == "":
+			p.next()
+			name, err := p.ident()
+			if err != nil {
+				return err
+			}
+			if _, err := p.expect("{"); err != nil {
+				return err
+			}
+			if err := p.parseMessageBody(message, name.text); err != nil {
+				return err
+			}
+		case t.text == "reserved":
+			if err := p.parseReserved(message); err != nil {
+				return err
+			}
+		case t.text == "extensions" || t.text == "extend":
+			if err := p.skipDeclaration(); err != nil {
+				return err
+			}
+		default:
+			field, err := p.parseField(oneof)
+			if err != nil {
+				return err
+			}
+			message.Fields = append(message.Fields, field)
+		}
+	}
+}
+
+// parseField parses a normal or map field
+func (p *parser) parseField(oneof string) (*Field, error) {
+	start := p.peek()
+	field := &Field{Oneof: oneof, Comment: start.comment, Pos: start.pos}
+
+	if start.text == "repeated" || start.text == "optional" || start.text == "required" {
+		field.Label = p.next().text
+	}
+
+	if p.accept("map") {
+		if _, err := p.expect("<"); err != nil {
+			return nil, err
+		}
+		key, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(","); err != nil {
+			return nil, err
+		}
+		value, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(">"); err != nil {
+			return nil, err
+		}
+		field.KeyType = key.text
+		field.Type = value.text
+	} else {
+		typ, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		if typ.text == "group" {
+			return nil, p.errorf(typ, "groups are not supported")
+		}
+		field.Type = typ.text
+	}
+
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	field.Name = name.text
+
+	if _, err := p.expect("="); err != nil {
+		return nil, err
+	}
+	number, _, err := p.number()
+	if err != nil {
+		return nil, err
+	}
+	field.Number = number
+
+	if field.Options, err = p.parseBracketOptions(); err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(";"); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+// parseReserved parses `reserved 1, 2 to 5, 9 to max;` or `reserved "a", "b";`
+func (p *parser) parseReserved(message *Message) error {
+	if _, err := p.expect("reserved"); err != nil {
+		return err
+	}
+	for {
+		t := p.peek()
+		if t.kind == tokenString {
+			p.next()
+			message.ReservedNames = append(message.ReservedNames, t.value)
+		} else {
+			start, _, err := p.number()
+			if err != nil {
+				return err
+			}
+			r := Range{Start: start, End: start}
+			if p.accept("to") {
+				if p.accept("max") {
+					r.End = 536870911
+				} else if r.End, _, err = p.number(); err != nil {
+					return err
+				}
+			}
+			message.ReservedNumbers = append(message.ReservedNumbers, r)
+		}
+		if p.accept(";") {
+			return nil
+		}
+		if _, err := p.expect(","); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *parser) parseEnum() (*Enum, error) {
+	start, err := p.expect("enum")
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	enum := &Enum{Name: name.text, Comment: start.comment, Pos: start.pos}
+
+	if _, err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == tokenEOF:
+			return nil, p.errorf(t, "unexpected end of file in enum %s", enum.Name)
+		case t.text == "}" && t.kind == tokenSymbol:
+			p.next()
+			enum.Close = t.pos
+			return enum, nil
+		case t.text == ";":
+			p.next()
+		case t.text == "option":
+			option, err := p.parseOptionStatement()
+			if err != nil {
+				return nil, err
+			}
+			enum.Options = append(enum.Options, option)
+		case t.text == "reserved":
+			if err := p.skipDeclaration(); err != nil {
+				return nil, err
+			}
+		default:
+			valueName, err := p.ident()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect("="); err != nil {
+				return nil, err
+			}
+			negative := p.accept("-")
+			number, _, err := p.number()
+			if err != nil {
+				return nil, err
+			}
+			if negative {
+				number = -number
+			}
+			if _, err := p.parseBracketOptions(); err != nil {
+				return nil, err
+			}
+			if _, err := p.expect(";"); err != nil {
+				return nil, err
+			}
+			enum.Values = append(enum.Values, &EnumValue{Name: valueName.text, Number: number, Comment: valueName.comment, Pos: valueName.pos})
+		}
+	}
+}
+
+func (p *parser) parseService() (*Service, error) {
+	start, err := p.expect("service")
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	service := &Service{Name: name.text, Comment: start.comment, Pos: start.pos}
+
+	if _, err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == tokenEOF:
+			return nil, p.errorf(t, "unexpected end of file in service %s", service.Name)
+		case t.text == "}" && t.kind == tokenSymbol:
+			p.next()
+			service.Close = t.pos
+			return service, nil
+		case t.text == ";":
+			p.next()
+		case t.text == "option":
+			option, err := p.parseOptionStatement()
+			if err != nil {
+				return nil, err
+			}
+			service.Options = append(service.Options, option)
+		case t.text == "rpc":
+			rpc, err := p.parseRPC()
+			if err != nil {
+				return nil, err
+			}
+			service.RPCs = append(service.RPCs, rpc)
+		default:
+			return nil, p.errorf(t, "unexpected %q in service %s", t.text, service.Name)
+		}
+	}
+}
+
+// parseRPC parses `rpc Name([stream] Req) returns ([stream] Resp);` or with an options block
+func (p *parser) parseRPC() (*RPC, error) {
+	start, err := p.expect("rpc")
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	rpc := &RPC{Name: name.text, Comment: start.comment, Pos: start.pos}
+
+	parseType := func() (string, bool, error) {
+		if _, err := p.expect("("); err != nil {
+			return "", false, err
+		}
+		stream := false
+		typ, err := p.ident()
+		if err != nil {
+			return "", false, err
+		}
+		// "stream" is only a keyword when another type follows it
+		if typ.text == "stream" && p.peek().kind == tokenIdent {
+			stream = true
+			if typ, err = p.ident(); err != nil {
+				return "", false, err
+			}
+		}
+		if _, err := p.expect(")"); err != nil {
+			return "", false, err
+		}
+		return typ.text, stream, nil
+	}
+
+	if rpc.Request, rpc.ClientStreaming, err = parseType(); err != nil {
+		return nil, err
+	}
+	if _, err := p.expect("returns"); err != nil {
+		return nil, err
+	}
+	if rpc.Response, rpc.ServerStreaming, err = parseType(); err != nil {
+		return nil, err
+	}
+
+	if p.accept(";") {
+		return rpc, nil
+	}
+	if _, err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == tokenEOF:
+			return nil, p.errorf(t, "unexpected end of file in rpc %s", rpc.Name)
+		case t.text == "}" && t.kind == tokenSymbol:
+			p.next()
+			p.accept(";")
+			return rpc, nil
+		case t.text == ";":
+			p.next()
+		case t.text == "option":
+			option, err := p.parseOptionStatement()
+			if err != nil {
+				return nil, err
+			}
+			rpc.Options = append(rpc.Options, option)
+		default:
+			return nil, p.errorf(t, "unexpected %q in rpc %s", t.text, rpc.Name)
+		}
+	}
+}
+
+// skipDeclaration skips a statement up to its semicolon, or a block up to its closing brace
+func (p *parser) skipDeclaration() error {
+	depth := 0
+	for {
+		t := p.next()
+		switch {
+		case t.kind == tokenEOF:
+			return p.errorf(t, "unexpected end of file")
+		case t.kind == tokenSymbol && t.text == "{":
+			depth++
+		case t.kind == tokenSymbol && t.text == "}":
+			depth--
+			if depth == 0 {
+				return nil
+			}
+		case t.kind == tokenSymbol && t.text == ";" && depth == 0:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package proto
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseTemplateProtos(t *testing.T) {
+	dir := filepath.Join("..", "..", "cmd", "meower", "template", "api", "proto")
+	files, err := ParseDir(os.DirFS(dir), ".")
+	if err != nil {
+		t.Fatalf("ParseDir() error = %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("ParseDir() found no .proto files in the template")
+	}
+
+	for _, file := range files {
+		if file.Syntax != "proto3" {
+			t.Errorf("%s: Syntax = %q, want proto3", file.Path, file.Syntax)
+		}
+		if !strings.HasPrefix(file.Option("go_package"), "TEMPLATE_MODULE_PATH/api/proto/") {
+			t.Errorf("%s: go_package = %q", file.Path, file.Option("go_package"))
+		}
+		for _, service := range file.Services {
+			for _, rpc := range service.RPCs {
+				if file.Message(rpc.Request) == nil || file.Message(rpc.Response) == nil {
+					t.Errorf("%s: rpc %s references undefined messages", file.Path, rpc.Name)
+				}
+			}
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	src := `// Meow service
+syntax = "proto3";
+
+package meow.v1;
+
+import "google/protobuf/timestamp.proto";
+import public "google/api/annotations.proto";
+
+option go_package = "example.com/meow/api/proto/meow/v1";
+
+// MeowService manages meows.
+service MeowService {
+  // CreateMeow posts a meow.
+  rpc CreateMeow(CreateMeowRequest) returns (CreateMeowResponse) {
+    option (google.api.http) = {
+      post: "/api/v1/meows"
+      body: "*"
+    };
+  }
+  rpc WatchMeows(WatchMeowsRequest) returns (stream Meow);
+  rpc Chat(stream Meow) returns (stream Meow) {}
+}
+
+/* A meow */
+message Meow {
+  reserved 4, 6 to 8;
+  reserved "author";
+
+  string id = 1;
+  string content = 2 [deprecated = true];
+  google.protobuf.Timestamp created_at = 3;
+  map<string, int32> reactions = 5;
+  oneof parent {
+    string reply_to = 9;
+    string repost_of = 10;
+  }
+
+  enum Visibility {
+    VISIBILITY_UNSPECIFIED = 0;
+    VISIBILITY_PUBLIC = 1;
+  }
+  message Stats {
+    int64 likes = 1;
+  }
+}
+
+message CreateMeowRequest { string content = 1; }
+message CreateMeowResponse { Meow meow = 1; }
+message WatchMeowsRequest {}
+`
+
+	file, err := Parse("meow.proto", []byte(src))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if file.Package != "meow.v1" {
+		t.Errorf("Package = %q, want meow.v1", file.Package)
+	}
+	if len(file.Imports) != 2 || !file.HasImport("google/protobuf/timestamp.proto") || !file.Imports[1].Public {
+		t.Errorf("Imports = %+v", file.Imports)
+	}
+	if got := src[file.Imports[0].Pos.Offset:file.Imports[0].End]; got != `import "google/protobuf/timestamp.proto";` {
+		t.Errorf("import source = %q", got)
+	}
+
+	service := file.Service("MeowService")
+	if service == nil {
+		t.Fatal("MeowService not found")
+	}
+	if service.Comment != "MeowService manages meows." {
+		t.Errorf("service Comment = %q", service.Comment)
+	}
+	if got := src[service.Close.Offset]; got != '}' {
+		t.Errorf("service Close points at %q, want '}'", got)
+	}
+
+	create := service.RPC("CreateMeow")
+	if create == nil || create.Request != "CreateMeowRequest" || create.Response != "CreateMeowResponse" {
+		t.Fatalf("CreateMeow = %+v", create)
+	}
+	if want := `post: "/api/v1/meows" body: "*"`; create.Option("(google.api.http)") != want {
+		t.Errorf("CreateMeow http option = %q, want %q", create.Option("(google.api.http)"), want)
+	}
+	if watch := service.RPC("WatchMeows"); watch.ClientStreaming || !watch.ServerStreaming {
+		t.Errorf("WatchMeows streaming = %v/%v, want false/true", watch.ClientStreaming, watch.ServerStreaming)
+	}
+	if chat := service.RPC("Chat"); !chat.ClientStreaming || !chat.ServerStreaming {
+		t.Errorf("Chat streaming = %v/%v, want true/true", chat.ClientStreaming, chat.ServerStreaming)
+	}
+
+	meow := file.Message("Meow")
+	if meow == nil {
+		t.Fatal("Meow not found")
+	}
+	if meow.Comment != "A meow" {
+		t.Errorf("Meow Comment = %q", meow.Comment)
+	}
+	if len(meow.Fields) != 6 {
+		t.Fatalf("Meow has %d fields, want 6", len(meow.Fields))
+	}
+	if f := meow.Field("created_at"); f.Type != "google.protobuf.Timestamp" || f.Number != 3 {
+		t.Errorf("created_at = %+v", f)
+	}
+	if f := meow.Field("reactions"); !f.IsMap() || f.TypeString() != "map<string, int32>" {
+		t.Errorf("reactions = %+v", f)
+	}
+	if f := meow.FieldByNumber(10); f == nil || f.Name != "repost_of" || f.Oneof != "parent" {
+		t.Errorf("field 10 = %+v", f)
+	}
+	if f := meow.Field("content"); len(f.Options) != 1 || f.Options[0].Name != "deprecated" {
+		t.Errorf("content options = %+v", f.Options)
+	}
+	if !meow.IsReserved(7, "") || !meow.IsReserved(0, "author") || meow.IsReserved(5, "id") {
+		t.Errorf("reserved = %v %v", meow.ReservedNumbers, meow.ReservedNames)
+	}
+
+	messages := file.AllMessages()
+	if _, ok := messages["Meow.Stats"]; !ok {
+		t.Errorf("AllMessages() missing Meow.Stats: %v", messages)
+	}
+	if enum := file.AllEnums()["Meow.Visibility"]; enum == nil || len(enum.Values) != 2 {
+		t.Errorf("AllEnums() Meow.Visibility = %+v", enum)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		wantErr string
+	}{
+		{
+			name:    "missing semicolon",
+			src:     "syntax = \"proto3\"\npackage a;",
+			wantErr: "x.proto:2:1: expected \";\"",
+		},
+		{
+			name:    "unterminated message",
+			src:     "message A {\n  string id = 1;\n",
+			wantErr: "unexpected end of file in message A",
+		},
+		{
+			name:    "field without number",
+			src:     "message A { string id; }",
+			wantErr: "expected \"=\"",
+		},
+		{
+			name:    "unterminated comment",
+			src:     "/* comment",
+			wantErr: "unterminated block comment",
+		},
+		{
+			name:    "garbage in service",
+			src:     "service S { message A {} }",
+			wantErr: "unexpected \"message\" in service S",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse("x.proto", []byte(tt.src))
+			if err == nil {
+				t.Fatal("Parse() error = nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse() error = %q, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package proto
+
+import "strings"
+
+// scalarTypes are the protobuf scalar value types
+var scalarTypes = map[string]bool{
+	"double": true, "float": true, "bool": true, "string": true, "bytes": true,
+	"int32": true, "int64": true, "uint32": true, "uint64": true,
+	"sint32": true, "sint64": true, "fixed32": true, "fixed64": true,
+	"sfixed32": true, "sfixed64": true,
+}
+
+func isScalar(typ string) bool {
+	return scalarTypes[typ]
+}
+
+// typeIndex holds the fully-qualified names of every message and enum in a
+// set of files, so type references can be resolved with protobuf scoping rules
+type typeIndex struct {
+	names map[string]bool
+}
+
+func newTypeIndex(files []*File) *typeIndex {
+	index := &typeIndex{names: make(map[string]bool)}
+	for _, file := range files {
+		for name := range file.AllMessages() {
+			index.names[joinName(file.Package, name)] = true
+		}
+		for name := range file.AllEnums() {
+			index.names[joinName(file.Package, name)] = true
+		}
+	}
+	return index
+}
+
+// resolve returns the fully-qualified name of typ as referenced from scope
+// (a message path such as "Outer.Inner", or "" at file level) in package pkg.
+// Scalars resolve to themselves. Well-known google.protobuf types are assumed
+// to exist since their files are not part of the project. It returns "" if
+// the type cannot be found.
+func (idx *typeIndex) resolve(pkg, scope, typ string) string {
+	if isScalar(typ) {
+		return typ
+	}
+	if strings.HasPrefix(typ, ".") {
+		typ = typ[1:]
+		if idx.names[typ] || strings.HasPrefix(typ, "google.protobuf.") {
+			return typ
+		}
+		return ""
+	}
+
+	// Search from the innermost scope outwards: pkg.Outer.Inner.T, pkg.Outer.T,
+	// pkg.T, then each enclosing package
+	candidates := joinName(pkg, scope)
+	for {
+		name := joinName(candidates, typ)
+		if idx.names[name] {
+			return name
+		}
+		if candidates == "" {
+			break
+		}
+		if i := strings.LastIndex(candidates, "."); i >= 0 {
+			candidates = candidates[:i]
+		} else {
+			candidates = ""
+		}
+	}
+
+	if strings.HasPrefix(typ, "google.protobuf.") {
+		return typ
+	}
+	return ""
+}
+
+// Resolver resolves type references across a set of files
+type Resolver struct {
+	index *typeIndex
+}
+
+// NewResolver indexes the messages and enums declared in files
+func NewResolver(files []*File) *Resolver {
+	return &Resolver{index: newTypeIndex(files)}
+}
+
+// Resolve returns the fully-qualified name of typ as referenced from scope
+// (a message path such as "Outer.Inner", or "" at file level) in package pkg,
+// or "" if it cannot be found. Scalars resolve to themselves.
+func (r *Resolver) Resolve(pkg, scope, typ string) string {
+	return r.index.resolve(pkg, scope, typ)
+}
+
+// resolves reports whether typ can be resolved from scope
+func (idx *typeIndex) resolves(pkg, scope, typ string) bool {
+	return idx.resolve(pkg, scope, typ) != ""
+}
+
+// qualify resolves typ. Unknown types are assumed to live in pkg, so an
+// unresolved reference compares equal to the definition it used to name.
+func (idx *typeIndex) qualify(pkg, scope, typ string) string {
+	if name := idx.resolve(pkg, scope, typ); name != "" {
+		return name
+	}
+	if strings.HasPrefix(typ, ".") {
+		return typ[1:]
+	}
+	return joinName(pkg, typ)
+}
@@ -0,0 +1,224 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LintSeverity indicates how serious a lint issue is
+type LintSeverity int
+
+const (
+	// LintWarning is reported but does not fail the lint run
+	LintWarning LintSeverity = iota
+	// LintError fails the lint run
+	LintError
+)
+
+func (s LintSeverity) String() string {
+	if s == LintError {
+		return "error"
+	}
+	return "warning"
+}
+
+// Lint rule identifiers
+const (
+	RuleUnknownPlaceholder    = "unknown-placeholder"
+	RuleUnreplacedPlaceholder = "unreplaced-placeholder"
+	RuleDevModeLeftover       = "dev-mode-leftover"
+	RuleHiddenFileDropped     = "hidden-file-dropped"
+)
+
+// DevModeModuleName is the module name dev-mode.sh substitutes for TEMPLATE_PROJECT_NAME
+const DevModeModuleName = "myapp"
+
+var (
+	placeholderRegex   = regexp.MustCompile(`TEMPLATE_[A-Z0-9_]*[A-Z0-9]`)
+	goTemplateVarRegex = regexp.MustCompile(`\{\{\.[A-Za-z]+\}\}`)
+	devModeNameRegex   = regexp.MustCompile(`\b` + DevModeModuleName + `\b`)
+)
+
+// LintIssue describes a single problem found in the template tree
+type LintIssue struct {
+	Path     string
+	Line     int
+	Rule     string
+	Severity LintSeverity
+	Message  string
+}
+
+func (i LintIssue) String() string {
+	location := i.Path
+	if i.Line > 0 {
+		location = fmt.Sprintf("%s:%d", i.Path, i.Line)
+	}
+	return fmt.Sprintf("%s: %s [%s] %s", location, i.Severity, i.Rule, i.Message)
+}
+
+// LintReport collects the issues found by a lint run
+type LintReport struct {
+	Issues       []LintIssue
+	FilesScanned int
+}
+
+// HasErrors returns true if any issue has error severity
+func (r *LintReport) HasErrors() bool {
+	return len(r.Errors()) > 0
+}
+
+// Errors returns the issues with error severity
+func (r *LintReport) Errors() []LintIssue {
+	return r.filter(LintError)
+}
+
+// Warnings returns the issues with warning severity
+func (r *LintReport) Warnings() []LintIssue {
+	return r.filter(LintWarning)
+}
+
+func (r *LintReport) filter(severity LintSeverity) []LintIssue {
+	var issues []LintIssue
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// TemplateLinter checks an embedded template tree against AllPlaceholders and
+// the replacements a given TemplateVars would apply.
+//
+// It walks the tree exactly like OptimizedProcessor does during `meower new`,
+// so anything it reports is something a generated project would actually see:
+// - TEMPLATE_ tokens that are not in AllPlaceholders
+// - placeholders that have no replacement for the given TemplateVars
+// - references to the dev-mode module name left behind by dev-mode.sh
+// - hidden files that shouldSkipEmbedded silently drops
+type TemplateLinter struct {
+	fsys         fs.FS
+	processor    *OptimizedProcessor
+	replacements map[string]string
+}
+
+// NewTemplateLinter creates a linter for the given template file system
+func NewTemplateLinter(fsys fs.FS, vars *TemplateVars) *TemplateLinter {
+	return &TemplateLinter{
+		fsys:         fsys,
+		processor:    NewOptimizedProcessor(vars),
+		replacements: vars.ToReplacementMap(),
+	}
+}
+
+// Lint scans the template tree and returns every issue found
+func (l *TemplateLinter) Lint() (*LintReport, error) {
+	report := &LintReport{}
+
+	err := fs.WalkDir(l.fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if l.processor.shouldSkipEmbedded(path, d) {
+			if strings.HasPrefix(d.Name(), ".") && l.processor.cleanPath(path) != "" {
+				report.Issues = append(report.Issues, LintIssue{
+					Path:     path,
+					Rule:     RuleHiddenFileDropped,
+					Severity: LintWarning,
+					Message:  "hidden file is embedded but will not be copied to generated projects",
+				})
+			}
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() || l.processor.cleanPath(path) == "" {
+			return nil
+		}
+
+		content, err := fs.ReadFile(l.fsys, path)
+		if err != nil {
+			return fmt.Errorf("failed to read embedded file %s: %w", path, err)
+		}
+
+		// Binary files (lockfiles, images) are copied as-is and never contain placeholders
+		if bytes.IndexByte(content, 0) >= 0 {
+			return nil
+		}
+
+		report.FilesScanned++
+		report.Issues = append(report.Issues, l.lintContent(path, string(content))...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		if report.Issues[i].Path != report.Issues[j].Path {
+			return report.Issues[i].Path < report.Issues[j].Path
+		}
+		return report.Issues[i].Line < report.Issues[j].Line
+	})
+
+	return report, nil
+}
+
+// lintContent checks the content of a single text file
+func (l *TemplateLinter) lintContent(path, content string) []LintIssue {
+	var issues []LintIssue
+
+	for i, line := range strings.Split(content, "\n") {
+		lineNum := i + 1
+
+		for _, placeholder := range placeholderRegex.FindAllString(line, -1) {
+			switch {
+			case !isKnownPlaceholder(placeholder):
+				issues = append(issues, LintIssue{
+					Path:     path,
+					Line:     lineNum,
+					Rule:     RuleUnknownPlaceholder,
+					Severity: LintError,
+					Message:  fmt.Sprintf("unknown placeholder %s", placeholder),
+				})
+			case l.replacements[placeholder] == "":
+				issues = append(issues, l.unreplacedIssue(path, lineNum, placeholder))
+			}
+		}
+
+		for _, variable := range goTemplateVarRegex.FindAllString(line, -1) {
+			if l.replacements[variable] == "" {
+				issues = append(issues, l.unreplacedIssue(path, lineNum, variable))
+			}
+		}
+
+		if devModeNameRegex.MatchString(line) {
+			issues = append(issues, LintIssue{
+				Path:     path,
+				Line:     lineNum,
+				Rule:     RuleDevModeLeftover,
+				Severity: LintError,
+				Message:  fmt.Sprintf("references dev-mode module name %q (run template-mode before committing)", DevModeModuleName),
+			})
+		}
+	}
+
+	return issues
+}
+
+func (l *TemplateLinter) unreplacedIssue(path string, line int, placeholder string) LintIssue {
+	return LintIssue{
+		Path:     path,
+		Line:     line,
+		Rule:     RuleUnreplacedPlaceholder,
+		Severity: LintError,
+		Message:  fmt.Sprintf("%s would be left unreplaced for the given template variables", placeholder),
+	}
+}
@@ -0,0 +1,144 @@
+package templates
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestTemplateLinter_Lint(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      fstest.MapFS
+		service    string
+		wantRules  []string
+		wantErrors bool
+	}{
+		{
+			name: "clean template",
+			files: fstest.MapFS{
+				"template/api/go.mod.template": {Data: []byte("module TEMPLATE_MODULE_PATH/api\n")},
+				"template/README.md":           {Data: []byte("# TEMPLATE_PROJECT_NAME_CAMEL\n")},
+			},
+			wantRules:  nil,
+			wantErrors: false,
+		},
+		{
+			name: "unknown placeholder",
+			files: fstest.MapFS{
+				"template/main.go": {Data: []byte(`import "TEMPLATE_MODUL_PATH/api"`)},
+			},
+			wantRules:  []string{RuleUnknownPlaceholder},
+			wantErrors: true,
+		},
+		{
+			name: "service placeholder without service vars",
+			files: fstest.MapFS{
+				"template/main.go": {Data: []byte("// TEMPLATE_SERVICE_NAME handler\n")},
+			},
+			wantRules:  []string{RuleUnreplacedPlaceholder},
+			wantErrors: true,
+		},
+		{
+			name: "service placeholder with service vars",
+			files: fstest.MapFS{
+				"template/main.go": {Data: []byte("// TEMPLATE_SERVICE_NAME handler\n")},
+			},
+			service:    "UserService",
+			wantRules:  nil,
+			wantErrors: false,
+		},
+		{
+			name: "unreplaced go template variable",
+			files: fstest.MapFS{
+				"template/main.go": {Data: []byte("// {{.ServiceName}}\n")},
+			},
+			wantRules:  []string{RuleUnreplacedPlaceholder},
+			wantErrors: true,
+		},
+		{
+			name: "dev mode leftover",
+			files: fstest.MapFS{
+				"template/api/go.mod.template": {Data: []byte("module myapp/api\n")},
+			},
+			wantRules:  []string{RuleDevModeLeftover},
+			wantErrors: true,
+		},
+		{
+			name: "hidden file dropped",
+			files: fstest.MapFS{
+				"template/web/.dockerignore":   {Data: []byte("node_modules\n")},
+				"template/web/public/.gitkeep": {Data: []byte("")},
+			},
+			wantRules:  []string{RuleHiddenFileDropped},
+			wantErrors: false,
+		},
+		{
+			name: "binary files are not scanned",
+			files: fstest.MapFS{
+				"template/web/bun.lockb": {Data: []byte("TEMPLATE_BOGUS\x00myapp")},
+			},
+			wantRules:  nil,
+			wantErrors: false,
+		},
+		{
+			name: "files outside template are ignored",
+			files: fstest.MapFS{
+				"other/main.go": {Data: []byte("TEMPLATE_BOGUS myapp")},
+			},
+			wantRules:  nil,
+			wantErrors: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vars := NewTemplateVars()
+			if err := vars.SetProject("my-app", "github.com/user/my-app"); err != nil {
+				t.Fatalf("SetProject() error = %v", err)
+			}
+			if tt.service != "" {
+				if err := vars.SetService(tt.service); err != nil {
+					t.Fatalf("SetService() error = %v", err)
+				}
+			}
+
+			report, err := NewTemplateLinter(tt.files, vars).Lint()
+			if err != nil {
+				t.Fatalf("Lint() error = %v", err)
+			}
+
+			var gotRules []string
+			for _, issue := range report.Issues {
+				gotRules = append(gotRules, issue.Rule)
+			}
+
+			if len(gotRules) != len(tt.wantRules) {
+				t.Fatalf("Lint() rules = %v, want %v (issues: %v)", gotRules, tt.wantRules, report.Issues)
+			}
+			for i := range gotRules {
+				if gotRules[i] != tt.wantRules[i] {
+					t.Errorf("Lint() rule[%d] = %s, want %s", i, gotRules[i], tt.wantRules[i])
+				}
+			}
+
+			if report.HasErrors() != tt.wantErrors {
+				t.Errorf("HasErrors() = %v, want %v", report.HasErrors(), tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestLintIssue_String(t *testing.T) {
+	issue := LintIssue{
+		Path:     "template/main.go",
+		Line:     3,
+		Rule:     RuleUnknownPlaceholder,
+		Severity: LintError,
+		Message:  "unknown placeholder TEMPLATE_FOO",
+	}
+
+	want := "template/main.go:3: error [unknown-placeholder] unknown placeholder TEMPLATE_FOO"
+	if got := issue.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,300 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Mode describes which state the template directory is in.
+//
+// Go's go:embed silently leaves out directories that contain a go.mod file,
+// so the template is committed in embed mode (go.mod.template files) and only
+// switched to dev mode (working go.mod files) while contributors iterate on it.
+type Mode string
+
+const (
+	// ModeEmbed means go.mod files are stored as .template files and the tree can be embedded
+	ModeEmbed Mode = "embed"
+	// ModeDev means working go.mod files exist so the template can be built and run
+	ModeDev Mode = "dev"
+	// ModeMixed means some modules are in dev mode and others are not
+	ModeMixed Mode = "mixed"
+)
+
+// ModuleDirs lists the template directories that are standalone Go modules
+var ModuleDirs = []string{"api", "web"}
+
+// devModeFiles lists the files that only exist in dev mode; each one is
+// stored with templateSuffix appended in embed mode
+var devModeFiles = []string{"go.mod", "go.sum"}
+
+const templateSuffix = ".template"
+
+// DevModeVars returns the TemplateVars applied to go.mod.template files in dev mode.
+// Only the project name is substituted: TEMPLATE_MODULE_PATH stays literal
+// because the template sources import it verbatim, and API versions are never
+// part of a go.mod file.
+func DevModeVars() *TemplateVars {
+	return &TemplateVars{
+		ProjectName:      DevModeModuleName,
+		ProjectNameUpper: strings.ToUpper(DevModeModuleName),
+		ProjectNameCamel: toPascalCase(DevModeModuleName),
+	}
+}
+
+// isGeneratedFile reports whether a file is produced by codegen and must not be embedded
+func isGeneratedFile(name string) bool {
+	return strings.HasSuffix(name, ".pb.go") ||
+		strings.HasSuffix(name, "_templ.go") ||
+		(strings.HasPrefix(name, "query.") && strings.HasSuffix(name, ".sql.go"))
+}
+
+// ModuleStatus describes the mode-related state of a single template module
+type ModuleStatus struct {
+	Dir         string
+	HasGoMod    bool
+	HasTemplate bool
+	// OutOfSync is true when a dev-mode go.mod differs from its .template
+	// counterpart, i.e. dependency changes have not been converted back yet.
+	OutOfSync bool
+	// TemplateHasDevName is true when go.mod.template references the dev-mode
+	// module name, i.e. a working go.mod was copied over it without conversion.
+	TemplateHasDevName bool
+}
+
+// ModeStatus is the overall state of the template directory
+type ModeStatus struct {
+	Mode           Mode
+	Modules        []ModuleStatus
+	GeneratedFiles []string
+	// Committed lists tracked files that only belong in dev mode, meaning the
+	// tree was committed (or staged) in the wrong mode.
+	Committed []string
+	// GitChecked is false when the template is not inside a git work tree
+	GitChecked bool
+}
+
+// CommittedInWrongMode returns true if the tracked tree contains dev-mode
+// files or a go.mod.template that still uses the dev-mode module name
+func (s *ModeStatus) CommittedInWrongMode() bool {
+	if len(s.Committed) > 0 {
+		return true
+	}
+	for _, module := range s.Modules {
+		if module.TemplateHasDevName {
+			return true
+		}
+	}
+	return false
+}
+
+// ModeManager switches the template directory between dev and embed mode.
+// It replaces the dev-mode.sh and template-mode.sh scripts and shares its
+// placeholder handling with TemplateVars.
+type ModeManager struct {
+	templateDir string
+	vars        *TemplateVars
+}
+
+// NewModeManager creates a mode manager for the given template directory
+func NewModeManager(templateDir string) *ModeManager {
+	return &ModeManager{
+		templateDir: templateDir,
+		vars:        DevModeVars(),
+	}
+}
+
+// EnableDev creates working go.mod and go.sum files from their .template
+// counterparts and removes stale generated templ files. It returns a
+// human-readable list of the actions taken.
+func (m *ModeManager) EnableDev() ([]string, error) {
+	var actions []string
+	replacer := m.vars.Replacer()
+
+	for _, dir := range ModuleDirs {
+		moduleDir := filepath.Join(m.templateDir, dir)
+
+		for _, working := range devModeFiles {
+			template := working + templateSuffix
+			content, err := os.ReadFile(filepath.Join(moduleDir, template))
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return actions, fmt.Errorf("failed to read %s: %w", template, err)
+			}
+
+			if working == "go.mod" {
+				content = []byte(replacer.Replace(string(content)))
+			}
+
+			if err := os.WriteFile(filepath.Join(moduleDir, working), content, 0o644); err != nil {
+				return actions, fmt.Errorf("failed to write %s: %w", working, err)
+			}
+			actions = append(actions, fmt.Sprintf("created %s/%s", dir, working))
+		}
+	}
+
+	removed, err := m.removeGenerated(func(name string) bool {
+		return strings.HasSuffix(name, "_templ.go")
+	})
+	actions = append(actions, removed...)
+
+	return actions, err
+}
+
+// EnableEmbed converts working go.mod and go.sum files back to .template
+// files and removes every generated file so the tree is ready for go:embed.
+func (m *ModeManager) EnableEmbed() ([]string, error) {
+	var actions []string
+	reverse := m.vars.ReverseReplacer()
+
+	for _, dir := range ModuleDirs {
+		moduleDir := filepath.Join(m.templateDir, dir)
+
+		for _, working := range devModeFiles {
+			template := working + templateSuffix
+			workingPath := filepath.Join(moduleDir, working)
+			content, err := os.ReadFile(workingPath)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return actions, fmt.Errorf("failed to read %s: %w", working, err)
+			}
+
+			if working == "go.mod" {
+				content = []byte(reverse.Replace(string(content)))
+			}
+
+			if err := os.WriteFile(filepath.Join(moduleDir, template), content, 0o644); err != nil {
+				return actions, fmt.Errorf("failed to write %s: %w", template, err)
+			}
+			if err := os.Remove(workingPath); err != nil {
+				return actions, fmt.Errorf("failed to remove %s: %w", working, err)
+			}
+			actions = append(actions, fmt.Sprintf("converted %s/%s to %s", dir, working, template))
+		}
+	}
+
+	removed, err := m.removeGenerated(isGeneratedFile)
+	actions = append(actions, removed...)
+
+	return actions, err
+}
+
+// Status inspects the template directory and reports its current mode
+func (m *ModeManager) Status() (*ModeStatus, error) {
+	status := &ModeStatus{}
+	reverse := m.vars.ReverseReplacer()
+	devModules := 0
+
+	for _, dir := range ModuleDirs {
+		moduleDir := filepath.Join(m.templateDir, dir)
+		module := ModuleStatus{Dir: dir}
+
+		goMod, err := os.ReadFile(filepath.Join(moduleDir, "go.mod"))
+		module.HasGoMod = err == nil
+
+		template, err := os.ReadFile(filepath.Join(moduleDir, "go.mod"+templateSuffix))
+		module.HasTemplate = err == nil
+		module.TemplateHasDevName = module.HasTemplate && devModeNameRegex.Match(template)
+
+		if module.HasGoMod {
+			devModules++
+			if module.HasTemplate {
+				module.OutOfSync = !bytes.Equal([]byte(reverse.Replace(string(goMod))), template)
+			}
+		}
+
+		status.Modules = append(status.Modules, module)
+	}
+
+	switch devModules {
+	case 0:
+		status.Mode = ModeEmbed
+	case len(ModuleDirs):
+		status.Mode = ModeDev
+	default:
+		status.Mode = ModeMixed
+	}
+
+	err := filepath.WalkDir(m.templateDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == "node_modules" {
+			return fs.SkipDir
+		}
+		if !d.IsDir() && isGeneratedFile(d.Name()) {
+			rel, _ := filepath.Rel(m.templateDir, path)
+			status.GeneratedFiles = append(status.GeneratedFiles, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan template directory: %w", err)
+	}
+
+	tracked, err := m.trackedFiles()
+	if err == nil {
+		status.GitChecked = true
+		status.Committed = committedInWrongMode(tracked)
+	}
+
+	return status, nil
+}
+
+// trackedFiles lists the files git tracks (committed or staged) under the template directory
+func (m *ModeManager) trackedFiles() ([]string, error) {
+	cmd := exec.Command("git", "ls-files", "--", ".")
+	cmd.Dir = m.templateDir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// committedInWrongMode filters tracked paths down to files that only belong in dev mode
+func committedInWrongMode(tracked []string) []string {
+	var wrong []string
+	for _, path := range tracked {
+		name := filepath.Base(path)
+		if slices.Contains(devModeFiles, name) || isGeneratedFile(name) {
+			wrong = append(wrong, path)
+		}
+	}
+	return wrong
+}
+
+// removeGenerated deletes generated files matching the given predicate
+func (m *ModeManager) removeGenerated(match func(name string) bool) ([]string, error) {
+	var actions []string
+
+	err := filepath.WalkDir(m.templateDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == "node_modules" {
+			return fs.SkipDir
+		}
+		if d.IsDir() || !match(d.Name()) {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		rel, _ := filepath.Rel(m.templateDir, path)
+		actions = append(actions, fmt.Sprintf("removed %s", rel))
+		return nil
+	})
+
+	return actions, err
+}
@@ -0,0 +1,195 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestModeManager_RoundTrip(t *testing.T) {
+	templateDir := t.TempDir()
+	goModTemplate := "module TEMPLATE_MODULE_PATH/api\n\n// TEMPLATE_PROJECT_NAME_UPPER TEMPLATE_PROJECT_NAME\n"
+
+	for _, dir := range ModuleDirs {
+		writeTestFile(t, filepath.Join(templateDir, dir, "go.mod.template"), goModTemplate)
+		writeTestFile(t, filepath.Join(templateDir, dir, "go.sum.template"), "sum\n")
+	}
+	writeTestFile(t, filepath.Join(templateDir, "web", "views", "home_templ.go"), "package views\n")
+
+	manager := NewModeManager(templateDir)
+
+	status, err := manager.Status()
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if status.Mode != ModeEmbed {
+		t.Errorf("Status().Mode = %s, want %s", status.Mode, ModeEmbed)
+	}
+
+	if _, err := manager.EnableDev(); err != nil {
+		t.Fatalf("EnableDev() error = %v", err)
+	}
+
+	goMod, err := os.ReadFile(filepath.Join(templateDir, "api", "go.mod"))
+	if err != nil {
+		t.Fatalf("EnableDev() did not create go.mod: %v", err)
+	}
+	want := "module TEMPLATE_MODULE_PATH/api\n\n// MYAPP myapp\n"
+	if string(goMod) != want {
+		t.Errorf("dev go.mod = %q, want %q", goMod, want)
+	}
+	if _, err := os.Stat(filepath.Join(templateDir, "web", "views", "home_templ.go")); !os.IsNotExist(err) {
+		t.Errorf("EnableDev() did not remove generated templ file")
+	}
+
+	status, err = manager.Status()
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if status.Mode != ModeDev {
+		t.Errorf("Status().Mode = %s, want %s", status.Mode, ModeDev)
+	}
+	for _, module := range status.Modules {
+		if module.OutOfSync {
+			t.Errorf("module %s reported out of sync right after EnableDev()", module.Dir)
+		}
+	}
+
+	// Simulate a dependency change made in dev mode
+	writeTestFile(t, filepath.Join(templateDir, "api", "go.mod"), string(goMod)+"require example.com/myapp/dep v1.0.0\n")
+	writeTestFile(t, filepath.Join(templateDir, "api", "proto", "meow.pb.go"), "package proto\n")
+
+	status, err = manager.Status()
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if !status.Modules[0].OutOfSync {
+		t.Errorf("Status() did not detect go.mod drift")
+	}
+	if len(status.GeneratedFiles) != 1 {
+		t.Errorf("Status().GeneratedFiles = %v, want 1 file", status.GeneratedFiles)
+	}
+
+	if _, err := manager.EnableEmbed(); err != nil {
+		t.Fatalf("EnableEmbed() error = %v", err)
+	}
+
+	template, err := os.ReadFile(filepath.Join(templateDir, "api", "go.mod.template"))
+	if err != nil {
+		t.Fatalf("EnableEmbed() did not write go.mod.template: %v", err)
+	}
+	want = goModTemplate + "require example.com/TEMPLATE_PROJECT_NAME/dep v1.0.0\n"
+	if string(template) != want {
+		t.Errorf("embedded go.mod.template = %q, want %q", template, want)
+	}
+
+	for _, path := range []string{"api/go.mod", "api/go.sum", "web/go.mod", "api/proto/meow.pb.go"} {
+		if _, err := os.Stat(filepath.Join(templateDir, path)); !os.IsNotExist(err) {
+			t.Errorf("EnableEmbed() left %s behind", path)
+		}
+	}
+
+	status, err = manager.Status()
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if status.Mode != ModeEmbed {
+		t.Errorf("Status().Mode = %s, want %s", status.Mode, ModeEmbed)
+	}
+}
+
+func TestModeManager_MixedAndLeakedTemplate(t *testing.T) {
+	templateDir := t.TempDir()
+	writeTestFile(t, filepath.Join(templateDir, "api", "go.mod"), "module TEMPLATE_MODULE_PATH/api\n")
+	writeTestFile(t, filepath.Join(templateDir, "web", "go.mod.template"), "module myapp/web\n")
+
+	status, err := NewModeManager(templateDir).Status()
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+
+	if status.Mode != ModeMixed {
+		t.Errorf("Status().Mode = %s, want %s", status.Mode, ModeMixed)
+	}
+	if !status.CommittedInWrongMode() {
+		t.Errorf("CommittedInWrongMode() = false, want true for go.mod.template using the dev-mode name")
+	}
+}
+
+func TestCommittedInWrongMode(t *testing.T) {
+	tracked := []string{
+		"api/go.mod.template",
+		"api/go.sum",
+		"api/db/query.meows.sql",
+		"api/db/query.meows.sql.go",
+		"web/views/home.templ",
+		"web/views/home_templ.go",
+	}
+
+	got := committedInWrongMode(tracked)
+	want := []string{"api/go.sum", "api/db/query.meows.sql.go", "web/views/home_templ.go"}
+
+	if len(got) != len(want) {
+		t.Fatalf("committedInWrongMode() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("committedInWrongMode()[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestTemplateVars_Replacer(t *testing.T) {
+	vars := NewTemplateVars()
+	if err := vars.SetProject("my-app", "github.com/user/my-app"); err != nil {
+		t.Fatalf("SetProject() error = %v", err)
+	}
+
+	// Run several times since map iteration order is random
+	for i := 0; i < 20; i++ {
+		got := vars.Replacer().Replace("TEMPLATE_PROJECT_NAME_UPPER TEMPLATE_PROJECT_NAME TEMPLATE_MODULE_PATH/api")
+		want := "MY_APP my-app github.com/user/my-app/api"
+		if got != want {
+			t.Fatalf("Replacer().Replace() = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestTemplateVars_ReverseReplacer(t *testing.T) {
+	// Without an API version, like DevModeVars
+	vars := &TemplateVars{}
+	if err := vars.SetProject("myapp", "github.com/user/myapp"); err != nil {
+		t.Fatalf("SetProject() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "module path", input: "module github.com/user/myapp/api", want: "module TEMPLATE_MODULE_PATH/api"},
+		{name: "project name", input: "// MYAPP myapp", want: "// TEMPLATE_PROJECT_NAME_UPPER TEMPLATE_PROJECT_NAME"},
+		{name: "path element", input: "require example.com/myapp/dep v1.0.0", want: "require example.com/TEMPLATE_PROJECT_NAME/dep v1.0.0"},
+		{name: "longer name", input: "require github.com/x/myapplication v1.0.0", want: "require github.com/x/myapplication v1.0.0"},
+		{name: "hyphenated name", input: "require github.com/x/myapp-tools v1.0.0", want: "require github.com/x/myapp-tools v1.0.0"},
+		{name: "longer module path", input: "require github.com/user/myapp2 v1.0.0", want: "require github.com/user/myapp2 v1.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := vars.ReverseReplacer().Replace(tt.input); got != tt.want {
+				t.Errorf("ReverseReplacer().Replace() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,8 +3,6 @@ package templates
 import (
 	"fmt"
 	"io/fs"
-	"os"
-	"path/filepath"
 	"strings"
 )
 
@@ -16,22 +14,19 @@ type OptimizedProcessor struct {
 
 // NewOptimizedProcessor creates a new optimized template processor
 func NewOptimizedProcessor(vars *TemplateVars) *OptimizedProcessor {
-	replacements := vars.ToReplacementMap()
-
-	// Build replacer pairs for strings.Replacer
-	var pairs []string
-	for key, value := range replacements {
-		pairs = append(pairs, key, value)
-	}
-
 	return &OptimizedProcessor{
 		vars:     vars,
-		replacer: strings.NewReplacer(pairs...),
+		replacer: vars.Replacer(),
 	}
 }
 
 // ProcessEmbeddedFiles processes embedded template files with optimized string replacement
 func (op *OptimizedProcessor) ProcessEmbeddedFiles(destDir string) error {
+	return op.RenderEmbeddedFiles(NewDiskWriter(destDir))
+}
+
+// RenderEmbeddedFiles processes embedded template files into the given writer
+func (op *OptimizedProcessor) RenderEmbeddedFiles(w Writer) error {
 	return fs.WalkDir(EmbeddedFiles, ".", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
@@ -54,19 +49,17 @@ func (op *OptimizedProcessor) ProcessEmbeddedFiles(destDir string) error {
 		// Handle .template files (rename them to remove .template extension)
 		cleanPath = strings.TrimSuffix(cleanPath, ".template")
 
-		destPath := filepath.Join(destDir, cleanPath)
-
 		if d.IsDir() {
-			return os.MkdirAll(destPath, 0o755)
+			return w.MkdirAll(cleanPath)
 		}
 
 		// Process file with optimized replacement
-		return op.processFileOptimized(path, destPath)
+		return op.processFileOptimized(w, path, cleanPath)
 	})
 }
 
 // processFileOptimized processes a single file with optimized string replacement
-func (op *OptimizedProcessor) processFileOptimized(srcPath, destPath string) error {
+func (op *OptimizedProcessor) processFileOptimized(w Writer, srcPath, destPath string) error {
 	// Read file content
 	content, err := EmbeddedFiles.ReadFile(srcPath)
 	if err != nil {
@@ -76,17 +69,8 @@ func (op *OptimizedProcessor) processFileOptimized(srcPath, destPath string) err
 	// Apply all replacements in a single pass using strings.Replacer
 	processedContent := op.replacer.Replace(string(content))
 
-	// Create destination directory if needed
-	if err := os.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
-		return fmt.Errorf("failed to create directory for %s: %w", destPath, err)
-	}
-
 	// Write processed file
-	if err := os.WriteFile(destPath, []byte(processedContent), 0o644); err != nil {
-		return fmt.Errorf("failed to write file %s: %w", destPath, err)
-	}
-
-	return nil
+	return w.WriteFile(destPath, []byte(processedContent))
 }
 
 // cleanPath extracts the clean destination path from embedded path
@@ -163,6 +147,11 @@ func NewOptimizedProcessorWithStats(vars *TemplateVars) *OptimizedProcessorWithS
 
 // ProcessEmbeddedFiles processes files while tracking statistics
 func (ops *OptimizedProcessorWithStats) ProcessEmbeddedFiles(destDir string) error {
+	return ops.RenderEmbeddedFiles(NewDiskWriter(destDir))
+}
+
+// RenderEmbeddedFiles processes files into the given writer while tracking statistics
+func (ops *OptimizedProcessorWithStats) RenderEmbeddedFiles(w Writer) error {
 	return fs.WalkDir(EmbeddedFiles, ".", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
@@ -188,14 +177,12 @@ func (ops *OptimizedProcessorWithStats) ProcessEmbeddedFiles(destDir string) err
 		// Handle .template files
 		cleanPath = strings.TrimSuffix(cleanPath, ".template")
 
-		destPath := filepath.Join(destDir, cleanPath)
-
 		if d.IsDir() {
-			return os.MkdirAll(destPath, 0o755)
+			return w.MkdirAll(cleanPath)
 		}
 
 		// Process file and track stats
-		if err := ops.processFileWithStats(path, destPath); err != nil {
+		if err := ops.processFileWithStats(w, path, cleanPath); err != nil {
 			return err
 		}
 
@@ -205,7 +192,7 @@ func (ops *OptimizedProcessorWithStats) ProcessEmbeddedFiles(destDir string) err
 }
 
 // processFileWithStats processes a file and updates statistics
-func (ops *OptimizedProcessorWithStats) processFileWithStats(srcPath, destPath string) error {
+func (ops *OptimizedProcessorWithStats) processFileWithStats(w Writer, srcPath, destPath string) error {
 	// Read file content
 	content, err := EmbeddedFiles.ReadFile(srcPath)
 	if err != nil {
@@ -223,16 +210,7 @@ func (ops *OptimizedProcessorWithStats) processFileWithStats(srcPath, destPath s
 		ops.Stats.Replacements++
 	}
 
-	// Create directory and write file
-	if err := os.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
-		return fmt.Errorf("failed to create directory for %s: %w", destPath, err)
-	}
-
-	if err := os.WriteFile(destPath, []byte(processedContent), 0o644); err != nil {
-		return fmt.Errorf("failed to write file %s: %w", destPath, err)
-	}
-
-	return nil
+	return w.WriteFile(destPath, []byte(processedContent))
 }
 
 // GetStats returns the current processing statistics
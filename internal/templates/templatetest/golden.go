@@ -0,0 +1,128 @@
+package templatetest
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// update rewrites golden trees instead of comparing against them:
+//
+//	go test ./cmd/meower ./internal/generators -run Golden -update
+var update = flag.Bool("update", false, "rewrite golden files in testdata/golden")
+
+// AssertGolden compares rendered files against the golden tree in dir.
+// Missing, unexpected and changed files are all reported. With -update the
+// golden tree is replaced by the rendered files, so generator changes show up
+// as a reviewable diff.
+func AssertGolden(tb testing.TB, dir string, files map[string][]byte) {
+	tb.Helper()
+
+	if *update {
+		writeGolden(tb, dir, files)
+		return
+	}
+
+	golden := readGolden(tb, dir)
+
+	for _, path := range sortedKeys(files) {
+		want, ok := golden[path]
+		if !ok {
+			tb.Errorf("%s: not in golden tree %s", path, dir)
+			continue
+		}
+		if !bytes.Equal(files[path], want) {
+			tb.Errorf("%s: differs from golden file\n%s", path, firstDifference(want, files[path]))
+		}
+	}
+
+	for _, path := range sortedKeys(golden) {
+		if _, ok := files[path]; !ok {
+			tb.Errorf("%s: in golden tree %s but was not generated", path, dir)
+		}
+	}
+
+	if tb.Failed() {
+		tb.Log("run the test with -update to accept the new output")
+	}
+}
+
+// readGolden loads every file under dir, keyed by slash-separated relative path
+func readGolden(tb testing.TB, dir string) map[string][]byte {
+	tb.Helper()
+
+	golden := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		golden[filepath.ToSlash(rel)] = content
+		return nil
+	})
+	if err != nil {
+		tb.Fatalf("failed to read golden tree %s (run with -update to create it): %v", dir, err)
+	}
+	return golden
+}
+
+// writeGolden replaces the golden tree in dir with files
+func writeGolden(tb testing.TB, dir string, files map[string][]byte) {
+	tb.Helper()
+
+	if err := os.RemoveAll(dir); err != nil {
+		tb.Fatalf("failed to remove golden tree %s: %v", dir, err)
+	}
+	for path, content := range files {
+		dest := filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+			tb.Fatalf("failed to create %s: %v", filepath.Dir(dest), err)
+		}
+		if err := os.WriteFile(dest, content, 0o644); err != nil {
+			tb.Fatalf("failed to write %s: %v", dest, err)
+		}
+	}
+	tb.Logf("updated golden tree %s (%d files)", dir, len(files))
+}
+
+// firstDifference describes the first line that differs between want and got
+func firstDifference(want, got []byte) string {
+	wantLines := strings.Split(string(want), "\n")
+	gotLines := strings.Split(string(got), "\n")
+
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g || i >= len(wantLines) || i >= len(gotLines) {
+			return fmt.Sprintf("line %d:\n  want: %s\n  got:  %s", i+1, w, g)
+		}
+	}
+	return "files differ"
+}
+
+func sortedKeys(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
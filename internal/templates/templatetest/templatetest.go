@@ -0,0 +1,43 @@
+// Package templatetest provides test helpers for the embedded project template.
+package templatetest
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/AlyxPink/meower/internal/templates"
+)
+
+// AssertLintClean lints the template tree in fsys and fails the test for every
+// error-level issue. Warnings are logged so they show up with `go test -v`.
+func AssertLintClean(tb testing.TB, fsys fs.FS, vars *templates.TemplateVars) {
+	tb.Helper()
+
+	report, err := templates.NewTemplateLinter(fsys, vars).Lint()
+	if err != nil {
+		tb.Fatalf("failed to lint template: %v", err)
+	}
+
+	if report.FilesScanned == 0 {
+		tb.Fatalf("template lint scanned no files, is the template embedded?")
+	}
+
+	for _, issue := range report.Warnings() {
+		tb.Log(issue.String())
+	}
+	for _, issue := range report.Errors() {
+		tb.Error(issue.String())
+	}
+}
+
+// ProjectVars returns TemplateVars configured like `meower new` would for a
+// sample project, failing the test if they cannot be set.
+func ProjectVars(tb testing.TB) *templates.TemplateVars {
+	tb.Helper()
+
+	vars := templates.NewTemplateVars()
+	if err := vars.SetProject("test-project", "github.com/test/test-project"); err != nil {
+		tb.Fatalf("failed to set project variables: %v", err)
+	}
+	return vars
+}
@@ -3,6 +3,7 @@ package templates
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"unicode"
 )
@@ -176,6 +177,104 @@ func (tv *TemplateVars) ToReplacementMap() map[string]string {
 	return replacements
 }
 
+// Replacer builds a strings.Replacer from ToReplacementMap.
+// Longer placeholders are registered first so TEMPLATE_PROJECT_NAME_UPPER is
+// never consumed by its TEMPLATE_PROJECT_NAME prefix.
+func (tv *TemplateVars) Replacer() *strings.Replacer {
+	return newLongestFirstReplacer(tv.ToReplacementMap())
+}
+
+// ReverseReplacer builds a TokenReplacer that turns concrete values back
+// into their TEMPLATE_ placeholders. Only TEMPLATE_ constants are reversed,
+// so Go template syntax aliases and the hardcoded module path are left alone.
+func (tv *TemplateVars) ReverseReplacer() *TokenReplacer {
+	reversed := make(map[string]string)
+	for placeholder, value := range tv.ToReplacementMap() {
+		if value != "" && strings.HasPrefix(placeholder, "TEMPLATE_") {
+			reversed[value] = placeholder
+		}
+	}
+	return newTokenReplacer(reversed)
+}
+
+// TokenReplacer replaces whole tokens only: a match can't be preceded or
+// followed by a letter, digit, '_' or '-'. Unlike strings.Replacer, it leaves
+// "myapp" alone in "github.com/x/myapplication" or "myapp-tools".
+type TokenReplacer struct {
+	// keys are the tokens to replace, longest first
+	keys         []string
+	replacements map[string]string
+}
+
+func newTokenReplacer(replacements map[string]string) *TokenReplacer {
+	keys := make([]string, 0, len(replacements))
+	for key := range replacements {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return &TokenReplacer{keys: keys, replacements: replacements}
+}
+
+// Replace returns a copy of s with every whole token replaced
+func (r *TokenReplacer) Replace(s string) string {
+	var out strings.Builder
+	for i := 0; i < len(s); {
+		if i == 0 || !isTokenByte(s[i-1]) {
+			if key := r.tokenAt(s, i); key != "" {
+				out.WriteString(r.replacements[key])
+				i += len(key)
+				continue
+			}
+		}
+		out.WriteByte(s[i])
+		i++
+	}
+	return out.String()
+}
+
+// tokenAt returns the longest key starting at s[i] and ending a token, or ""
+func (r *TokenReplacer) tokenAt(s string, i int) string {
+	for _, key := range r.keys {
+		end := i + len(key)
+		if strings.HasPrefix(s[i:], key) && (end == len(s) || !isTokenByte(s[end])) {
+			return key
+		}
+	}
+	return ""
+}
+
+// isTokenByte reports whether b continues a name, such as a module path
+// element or an identifier
+func isTokenByte(b byte) bool {
+	return b == '_' || b == '-' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
+
+// newLongestFirstReplacer orders replacement pairs by descending key length,
+// since strings.Replacer resolves overlapping matches in argument order.
+func newLongestFirstReplacer(replacements map[string]string) *strings.Replacer {
+	keys := make([]string, 0, len(replacements))
+	for key := range replacements {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	pairs := make([]string, 0, len(keys)*2)
+	for _, key := range keys {
+		pairs = append(pairs, key, replacements[key])
+	}
+	return strings.NewReplacer(pairs...)
+}
+
 // Validation functions
 func validateProjectName(name string) error {
 	if name == "" {
@@ -0,0 +1,75 @@
+package templates
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Writer is the destination generators write files to. DiskWriter is used by
+// the CLI; MemoryWriter lets tests render a project without touching disk.
+type Writer interface {
+	// MkdirAll creates a directory and any missing parents
+	MkdirAll(path string) error
+	// WriteFile writes content to path, creating parent directories as needed
+	WriteFile(path string, content []byte) error
+}
+
+// DiskWriter writes files relative to a root directory on disk
+type DiskWriter struct {
+	Root string
+}
+
+// NewDiskWriter creates a writer rooted at dir
+func NewDiskWriter(dir string) *DiskWriter {
+	return &DiskWriter{Root: dir}
+}
+
+// MkdirAll creates a directory under the writer's root
+func (w *DiskWriter) MkdirAll(path string) error {
+	return os.MkdirAll(filepath.Join(w.Root, path), 0o755)
+}
+
+// WriteFile writes a file under the writer's root
+func (w *DiskWriter) WriteFile(path string, content []byte) error {
+	destPath := filepath.Join(w.Root, path)
+	if err := os.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", destPath, err)
+	}
+	if err := os.WriteFile(destPath, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", destPath, err)
+	}
+	return nil
+}
+
+// MemoryWriter collects written files in memory, keyed by slash-separated path
+type MemoryWriter struct {
+	Files map[string][]byte
+}
+
+// NewMemoryWriter creates an empty in-memory writer
+func NewMemoryWriter() *MemoryWriter {
+	return &MemoryWriter{Files: make(map[string][]byte)}
+}
+
+// MkdirAll is a no-op: directories are implied by the files they contain
+func (w *MemoryWriter) MkdirAll(path string) error {
+	return nil
+}
+
+// WriteFile stores a copy of content under path
+func (w *MemoryWriter) WriteFile(path string, content []byte) error {
+	w.Files[filepath.ToSlash(filepath.Clean(path))] = append([]byte(nil), content...)
+	return nil
+}
+
+// Paths returns the written paths in sorted order
+func (w *MemoryWriter) Paths() []string {
+	paths := make([]string, 0, len(w.Files))
+	for path := range w.Files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
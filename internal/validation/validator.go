@@ -13,6 +13,15 @@ var (
 	// Service name validation
 	serviceNameRegex = regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`)
 
+	// RPC method name validation
+	methodNameRegex = regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`)
+
+	// API major version validation (v1, v2, ...)
+	apiVersionRegex = regexp.MustCompile(`^v[1-9][0-9]*$`)
+
+	// Protobuf field name validation (lower_snake_case)
+	fieldNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
 	// Module path validation (supports SourceHut format with ~ character)
 	modulePathRegex = regexp.MustCompile(`^[a-zA-Z0-9.-]+(/[a-zA-Z0-9.~-]+)*$`)
 )
@@ -153,6 +162,148 @@ func (v *ServiceValidator) ValidateServiceName(name string) error {
 	return nil
 }
 
+// ValidateGRPCServiceName validates the name of a gRPC service in a Meower
+// project. It is stricter than ValidateServiceName so generated code and
+// `meower proto lint` agree on the same conventions:
+// - PascalCase, letters and numbers only
+// - ends with "Service" (e.g. UserService, PostService)
+// - at least 8 characters (e.g. MyService)
+func (v *ServiceValidator) ValidateGRPCServiceName(name string) error {
+	fail := func(rule, message string) error {
+		return ValidationError{Field: "service name", Value: name, Rule: rule, Message: message}
+	}
+
+	if name == "" {
+		return fail("required", "service name cannot be empty")
+	}
+
+	// Must end with "Service" for consistency and clarity
+	if !strings.HasSuffix(name, "Service") {
+		return fail("suffix", "service name must end with 'Service' (e.g. UserService, PostService, AuthService)")
+	}
+
+	// Minimum length check (at least "XService" = 8 chars)
+	if len(name) < 8 {
+		return fail("length", "service name too short (minimum 8 characters: e.g., 'MyService')")
+	}
+
+	if !serviceNameRegex.MatchString(name) {
+		return fail("format", "service name must be PascalCase and can only contain letters and numbers")
+	}
+
+	return nil
+}
+
+// ValidateMethodName validates an RPC method name
+func (v *ServiceValidator) ValidateMethodName(name string) error {
+	if name == "" {
+		return ValidationError{
+			Field:   "method name",
+			Value:   name,
+			Rule:    "required",
+			Message: "method name cannot be empty",
+		}
+	}
+
+	if !methodNameRegex.MatchString(name) {
+		return ValidationError{
+			Field:   "method name",
+			Value:   name,
+			Rule:    "format",
+			Message: "method name must be PascalCase (e.g. Create, List, Publish)",
+		}
+	}
+
+	return nil
+}
+
+// ValidateStreamKind validates the streaming mode of an RPC method spec
+// such as "List:server-stream". An empty kind means a unary RPC.
+func (v *ServiceValidator) ValidateStreamKind(kind string) error {
+	switch kind {
+	case "", "server-stream", "client-stream", "bidi":
+		return nil
+	}
+
+	return ValidationError{
+		Field:   "stream kind",
+		Value:   kind,
+		Rule:    "format",
+		Message: "stream kind must be one of server-stream, client-stream or bidi",
+	}
+}
+
+// ValidateAPIVersion validates an API major version such as v2, used as the
+// last component of a service's protobuf package
+func (v *ServiceValidator) ValidateAPIVersion(version string) error {
+	if apiVersionRegex.MatchString(version) {
+		return nil
+	}
+
+	return ValidationError{
+		Field:   "API version",
+		Value:   version,
+		Rule:    "format",
+		Message: "API version must be v followed by a major version number (e.g. v2)",
+	}
+}
+
+// protoScalarTypes lists the protobuf scalar types accepted in custom RPC fields,
+// plus "timestamp" as shorthand for google.protobuf.Timestamp
+var protoScalarTypes = map[string]bool{
+	"string": true, "bytes": true, "bool": true,
+	"int32": true, "int64": true, "uint32": true, "uint64": true,
+	"sint32": true, "sint64": true, "fixed32": true, "fixed64": true,
+	"double": true, "float": true, "timestamp": true,
+}
+
+// ValidateFieldName validates a protobuf field name in a custom RPC definition
+func (v *ServiceValidator) ValidateFieldName(name string) error {
+	if name == "" {
+		return ValidationError{
+			Field:   "field name",
+			Value:   name,
+			Rule:    "required",
+			Message: "field name cannot be empty",
+		}
+	}
+
+	if !fieldNameRegex.MatchString(name) || strings.HasSuffix(name, "_") || strings.Contains(name, "__") {
+		return ValidationError{
+			Field:   "field name",
+			Value:   name,
+			Rule:    "format",
+			Message: "field name must be lower_snake_case (e.g. id, meow_id)",
+		}
+	}
+
+	return nil
+}
+
+// ValidateFieldType validates a field type in a custom RPC definition. The
+// type must be a protobuf scalar, "timestamp", or one of the given message types.
+func (v *ServiceValidator) ValidateFieldType(typ string, messageTypes ...string) error {
+	if protoScalarTypes[typ] {
+		return nil
+	}
+	for _, message := range messageTypes {
+		if typ == message {
+			return nil
+		}
+	}
+
+	allowed := "a protobuf scalar (string, int64, bool, ...) or timestamp"
+	if len(messageTypes) > 0 {
+		allowed += " or " + strings.Join(messageTypes, ", ")
+	}
+	return ValidationError{
+		Field:   "field type",
+		Value:   typ,
+		Rule:    "format",
+		Message: "field type must be " + allowed,
+	}
+}
+
 // ValidateHTTPMethods validates HTTP method list
 func (v *ServiceValidator) ValidateHTTPMethods(methods []string) error {
 	if len(methods) == 0 {
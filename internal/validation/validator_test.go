@@ -397,6 +397,210 @@ func TestServiceValidator_ValidateServiceName(t *testing.T) {
 	}
 }
 
+func TestServiceValidator_ValidateGRPCServiceName(t *testing.T) {
+	validator := &ServiceValidator{}
+
+	tests := []struct {
+		name        string
+		serviceName string
+		expectError bool
+	}{
+		{name: "valid service name", serviceName: "UserService", expectError: false},
+		{name: "shortest valid name", serviceName: "MyService", expectError: false},
+		{name: "valid with numbers", serviceName: "Order2Service", expectError: false},
+		{name: "empty name", serviceName: "", expectError: true},
+		{name: "missing suffix", serviceName: "UserManager", expectError: true},
+		{name: "too short", serviceName: "Service", expectError: true},
+		{name: "lowercase start", serviceName: "userService", expectError: true},
+		{name: "contains underscore", serviceName: "User_Service", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateGRPCServiceName(tt.serviceName)
+
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error for service name '%s' but got none", tt.serviceName)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error for service name '%s' but got: %v", tt.serviceName, err)
+			}
+		})
+	}
+}
+
+func TestServiceValidator_ValidateMethodName(t *testing.T) {
+	validator := &ServiceValidator{}
+
+	tests := []struct {
+		name        string
+		methodName  string
+		expectError bool
+	}{
+		{
+			name:        "valid CRUD method",
+			methodName:  "Create",
+			expectError: false,
+		},
+		{
+			name:        "valid custom method",
+			methodName:  "Publish2",
+			expectError: false,
+		},
+		{
+			name:        "empty name",
+			methodName:  "",
+			expectError: true,
+		},
+		{
+			name:        "lowercase",
+			methodName:  "list",
+			expectError: true,
+		},
+		{
+			name:        "contains separator",
+			methodName:  "List:bidi",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateMethodName(tt.methodName)
+
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error for method name '%s' but got none", tt.methodName)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error for method name '%s' but got: %v", tt.methodName, err)
+			}
+		})
+	}
+}
+
+func TestServiceValidator_ValidateStreamKind(t *testing.T) {
+	validator := &ServiceValidator{}
+
+	tests := []struct {
+		kind        string
+		expectError bool
+	}{
+		{kind: "", expectError: false},
+		{kind: "server-stream", expectError: false},
+		{kind: "client-stream", expectError: false},
+		{kind: "bidi", expectError: false},
+		{kind: "stream", expectError: true},
+		{kind: "Bidi", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			err := validator.ValidateStreamKind(tt.kind)
+
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error for stream kind '%s' but got none", tt.kind)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error for stream kind '%s' but got: %v", tt.kind, err)
+			}
+		})
+	}
+}
+
+func TestServiceValidator_ValidateAPIVersion(t *testing.T) {
+	validator := &ServiceValidator{}
+
+	tests := []struct {
+		version     string
+		expectError bool
+	}{
+		{version: "v1", expectError: false},
+		{version: "v2", expectError: false},
+		{version: "v10", expectError: false},
+		{version: "", expectError: true},
+		{version: "2", expectError: true},
+		{version: "V2", expectError: true},
+		{version: "v0", expectError: true},
+		{version: "v2beta1", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			err := validator.ValidateAPIVersion(tt.version)
+
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error for API version '%s' but got none", tt.version)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error for API version '%s' but got: %v", tt.version, err)
+			}
+		})
+	}
+}
+
+func TestServiceValidator_ValidateFieldName(t *testing.T) {
+	validator := &ServiceValidator{}
+
+	tests := []struct {
+		fieldName   string
+		expectError bool
+	}{
+		{fieldName: "id", expectError: false},
+		{fieldName: "meow_id", expectError: false},
+		{fieldName: "page2", expectError: false},
+		{fieldName: "", expectError: true},
+		{fieldName: "MeowID", expectError: true},
+		{fieldName: "meow-id", expectError: true},
+		{fieldName: "_id", expectError: true},
+		{fieldName: "id_", expectError: true},
+		{fieldName: "meow__id", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fieldName, func(t *testing.T) {
+			err := validator.ValidateFieldName(tt.fieldName)
+
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error for field name '%s' but got none", tt.fieldName)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error for field name '%s' but got: %v", tt.fieldName, err)
+			}
+		})
+	}
+}
+
+func TestServiceValidator_ValidateFieldType(t *testing.T) {
+	validator := &ServiceValidator{}
+
+	tests := []struct {
+		name         string
+		fieldType    string
+		messageTypes []string
+		expectError  bool
+	}{
+		{name: "scalar", fieldType: "string", expectError: false},
+		{name: "timestamp shorthand", fieldType: "timestamp", expectError: false},
+		{name: "known message", fieldType: "Meow", messageTypes: []string{"Meow"}, expectError: false},
+		{name: "unknown message", fieldType: "User", messageTypes: []string{"Meow"}, expectError: true},
+		{name: "unknown scalar", fieldType: "int", expectError: true},
+		{name: "empty", fieldType: "", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateFieldType(tt.fieldType, tt.messageTypes...)
+
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error for field type '%s' but got none", tt.fieldType)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error for field type '%s' but got: %v", tt.fieldType, err)
+			}
+		})
+	}
+}
+
 func TestServiceValidator_ValidateHTTPMethods(t *testing.T) {
 	validator := &ServiceValidator{}
 
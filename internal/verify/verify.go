@@ -0,0 +1,333 @@
+// Package verify checks that a generated Meower project actually compiles.
+//
+// A freshly generated project is missing everything that codegen produces:
+// templ components, protobuf stubs and sqlc queries. The Verifier runs those
+// generators, then `go build` and `go vet` for each module, and collects the
+// outcome of every step into a Report.
+package verify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Tool versions, kept in sync with api/Dockerfile and web/Dockerfile in the template
+const (
+	ProtocGenGoVersion     = "v1.36.6"
+	ProtocGenGoGRPCVersion = "v1.5.1"
+	SQLCVersion            = "v1.29.0"
+)
+
+// DefaultStepTimeout bounds each individual verification step
+const DefaultStepTimeout = 5 * time.Minute
+
+// Status is the outcome of a single verification step
+type Status string
+
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"
+	StatusSkipped Status = "skipped"
+)
+
+// StepResult records the outcome of one verification step
+type StepResult struct {
+	Module   string
+	Name     string
+	Command  string
+	Status   Status
+	Duration time.Duration
+	Output   string
+	Err      error
+}
+
+// Report collects the results of a verification run
+type Report struct {
+	Results  []StepResult
+	Duration time.Duration
+}
+
+// Failed returns true if any step failed
+func (r *Report) Failed() bool {
+	for _, result := range r.Results {
+		if result.Status == StatusFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// Counts returns the number of passed, failed and skipped steps
+func (r *Report) Counts() (passed, failed, skipped int) {
+	for _, result := range r.Results {
+		switch result.Status {
+		case StatusPassed:
+			passed++
+		case StatusFailed:
+			failed++
+		case StatusSkipped:
+			skipped++
+		}
+	}
+	return passed, failed, skipped
+}
+
+// step is a single command executed during verification
+type step struct {
+	name    string
+	codegen bool // a failed codegen step skips the builds that depend on its output
+	command func(v *Verifier, ctx context.Context) (*exec.Cmd, error)
+}
+
+// module is a Go module inside the generated project
+type module struct {
+	name      string
+	dependsOn []string
+	steps     []step
+}
+
+// Verifier runs codegen, builds and vets for every module of a generated project
+type Verifier struct {
+	projectDir string
+	timeout    time.Duration
+	toolDir    string
+	plan       []module
+	out        io.Writer
+}
+
+// NewVerifier creates a verifier for the project at projectDir
+func NewVerifier(projectDir string) *Verifier {
+	return &Verifier{
+		projectDir: projectDir,
+		timeout:    DefaultStepTimeout,
+		plan:       defaultPlan(),
+		out:        io.Discard,
+	}
+}
+
+// WithTimeout overrides the per-step timeout
+func (v *Verifier) WithTimeout(timeout time.Duration) *Verifier {
+	v.timeout = timeout
+	return v
+}
+
+// WithOutput sets where warnings are written; they are discarded by default
+func (v *Verifier) WithOutput(out io.Writer) *Verifier {
+	v.out = out
+	return v
+}
+
+// defaultPlan returns the verification plan for a generated project. The web
+// module imports the api module's protobuf stubs through a replace directive,
+// so it depends on api.
+func defaultPlan() []module {
+	goChecks := []step{
+		{name: "go build ./...", command: goCommand("build", "./...")},
+		{name: "go vet ./...", command: goCommand("vet", "./...")},
+	}
+
+	return []module{
+		{
+			name: "api",
+			steps: append([]step{
+				{name: "sqlc generate", codegen: true, command: (*Verifier).sqlcCommand},
+				{name: "protoc", codegen: true, command: (*Verifier).protocCommand},
+			}, goChecks...),
+		},
+		{
+			name:      "web",
+			dependsOn: []string{"api"},
+			steps: append([]step{
+				{name: "templ generate", codegen: true, command: (*Verifier).templCommand},
+			}, goChecks...),
+		},
+	}
+}
+
+// Run executes the verification plan and returns a report. Every command is
+// bound to ctx, so cancelling it stops the step that is currently running.
+// An error is only returned if verification could not be attempted at all;
+// failing steps are recorded in the report instead.
+func (v *Verifier) Run(ctx context.Context) (*Report, error) {
+	if _, err := exec.LookPath("go"); err != nil {
+		return nil, fmt.Errorf("go toolchain not found in PATH: %w", err)
+	}
+
+	toolDir, err := os.MkdirTemp("", "meower-verify-tools-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tool directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(toolDir); err != nil {
+			fmt.Fprintf(v.out, "Warning: failed to remove %s: %v\n", toolDir, err)
+		}
+	}()
+	v.toolDir = toolDir
+
+	start := time.Now()
+	report := &Report{}
+	codegenFailed := make(map[string]bool)
+
+	for _, mod := range v.plan {
+		blocked := codegenFailed[mod.name]
+		for _, dep := range mod.dependsOn {
+			blocked = blocked || codegenFailed[dep]
+		}
+
+		for _, s := range mod.steps {
+			if blocked && !s.codegen {
+				report.Results = append(report.Results, StepResult{
+					Module: mod.name,
+					Name:   s.name,
+					Status: StatusSkipped,
+					Output: "skipped because code generation failed",
+				})
+				continue
+			}
+
+			result := v.runStep(ctx, mod.name, s)
+			report.Results = append(report.Results, result)
+
+			if s.codegen && result.Status == StatusFailed {
+				codegenFailed[mod.name] = true
+				blocked = true
+			}
+		}
+	}
+
+	report.Duration = time.Since(start)
+	return report, nil
+}
+
+// runStep executes a single step inside the module directory
+func (v *Verifier) runStep(ctx context.Context, moduleName string, s step) StepResult {
+	result := StepResult{Module: moduleName, Name: s.name}
+	start := time.Now()
+	defer func() { result.Duration = time.Since(start) }()
+
+	// The timeout also covers preparing the command, e.g. installing plugins
+	stepCtx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+
+	cmd, err := s.command(v, stepCtx)
+	if err != nil {
+		result.Status = StatusFailed
+		result.Err = err
+		if errors.Is(stepCtx.Err(), context.DeadlineExceeded) {
+			result.Err = fmt.Errorf("timed out after %s: %w", v.timeout, err)
+		}
+		return result
+	}
+
+	if cmd.Dir == "" {
+		cmd.Dir = filepath.Join(v.projectDir, moduleName)
+	}
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	result.Command = strings.Join(cmd.Args, " ")
+	err = cmd.Run()
+	result.Output = strings.TrimSpace(output.String())
+
+	switch {
+	case errors.Is(stepCtx.Err(), context.DeadlineExceeded):
+		result.Status = StatusFailed
+		result.Err = fmt.Errorf("timed out after %s", v.timeout)
+	case err != nil:
+		result.Status = StatusFailed
+		result.Err = err
+	default:
+		result.Status = StatusPassed
+	}
+
+	return result
+}
+
+// goCommand builds a step command running the go tool with the given arguments
+func goCommand(args ...string) func(v *Verifier, ctx context.Context) (*exec.Cmd, error) {
+	return func(v *Verifier, ctx context.Context) (*exec.Cmd, error) {
+		return exec.CommandContext(ctx, "go", args...), nil
+	}
+}
+
+// templCommand runs templ from PATH, or through the version pinned in web/go.mod
+func (v *Verifier) templCommand(ctx context.Context) (*exec.Cmd, error) {
+	if path, err := exec.LookPath("templ"); err == nil {
+		return exec.CommandContext(ctx, path, "generate"), nil
+	}
+	return exec.CommandContext(ctx, "go", "run", "github.com/a-h/templ/cmd/templ", "generate"), nil
+}
+
+// sqlcCommand runs sqlc from PATH, falling back to `go run` (requires cgo)
+func (v *Verifier) sqlcCommand(ctx context.Context) (*exec.Cmd, error) {
+	if path, err := exec.LookPath("sqlc"); err == nil {
+		return exec.CommandContext(ctx, path, "generate", "-f", "db/sqlc.yaml"), nil
+	}
+	return exec.CommandContext(ctx, "go", "run", "github.com/sqlc-dev/sqlc/cmd/sqlc@"+SQLCVersion, "generate", "-f", "db/sqlc.yaml"), nil
+}
+
+// protocCommand mirrors scripts/generate_protobuf.sh. The Go plugins are
+// installed into a temporary GOBIN when they are not already in PATH.
+func (v *Verifier) protocCommand(ctx context.Context) (*exec.Cmd, error) {
+	protoc, err := exec.LookPath("protoc")
+	if err != nil {
+		return nil, fmt.Errorf("protoc not found in PATH (install protobuf, or use the development-api docker image)")
+	}
+
+	plugins := map[string]string{
+		"protoc-gen-go":      "google.golang.org/protobuf/cmd/protoc-gen-go@" + ProtocGenGoVersion,
+		"protoc-gen-go-grpc": "google.golang.org/grpc/cmd/protoc-gen-go-grpc@" + ProtocGenGoGRPCVersion,
+	}
+	for binary, pkg := range plugins {
+		if _, err := exec.LookPath(binary); err == nil {
+			continue
+		}
+		install := exec.CommandContext(ctx, "go", "install", pkg)
+		install.Env = append(os.Environ(), "GOBIN="+v.toolDir)
+		if output, err := install.CombinedOutput(); err != nil {
+			return nil, fmt.Errorf("failed to install %s: %w\n%s", binary, err, output)
+		}
+	}
+
+	protoDir := filepath.Join("api", "proto")
+	var protoFiles []string
+	err = filepath.WalkDir(filepath.Join(v.projectDir, protoDir), func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(path, ".proto") {
+			rel, err := filepath.Rel(v.projectDir, path)
+			if err != nil {
+				return err
+			}
+			protoFiles = append(protoFiles, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find proto files: %w", err)
+	}
+
+	args := append([]string{
+		"--proto_path=" + protoDir,
+		"--go_out=" + protoDir,
+		"--go_opt=paths=source_relative",
+		"--go-grpc_out=" + protoDir,
+		"--go-grpc_opt=paths=source_relative",
+	}, protoFiles...)
+
+	cmd := exec.CommandContext(ctx, protoc, args...)
+	cmd.Dir = v.projectDir
+	cmd.Env = append(os.Environ(), "PATH="+v.toolDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return cmd, nil
+}
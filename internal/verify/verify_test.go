@@ -0,0 +1,161 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func passing() func(v *Verifier, ctx context.Context) (*exec.Cmd, error) {
+	return func(v *Verifier, ctx context.Context) (*exec.Cmd, error) {
+		return exec.CommandContext(ctx, "go", "version"), nil
+	}
+}
+
+func failing() func(v *Verifier, ctx context.Context) (*exec.Cmd, error) {
+	return func(v *Verifier, ctx context.Context) (*exec.Cmd, error) {
+		return nil, errors.New("tool not found")
+	}
+}
+
+func hanging() func(v *Verifier, ctx context.Context) (*exec.Cmd, error) {
+	return func(v *Verifier, ctx context.Context) (*exec.Cmd, error) {
+		return exec.CommandContext(ctx, "sleep", "60"), nil
+	}
+}
+
+func TestVerifier_Run(t *testing.T) {
+	tests := []struct {
+		name     string
+		plan     []module
+		expected []Status
+		failed   bool
+	}{
+		{
+			name: "all steps pass",
+			plan: []module{
+				{name: "api", steps: []step{
+					{name: "codegen", codegen: true, command: passing()},
+					{name: "build", command: passing()},
+				}},
+			},
+			expected: []Status{StatusPassed, StatusPassed},
+			failed:   false,
+		},
+		{
+			name: "build failure does not skip later steps",
+			plan: []module{
+				{name: "api", steps: []step{
+					{name: "build", command: failing()},
+					{name: "vet", command: passing()},
+				}},
+			},
+			expected: []Status{StatusFailed, StatusPassed},
+			failed:   true,
+		},
+		{
+			name: "codegen failure skips builds in the module",
+			plan: []module{
+				{name: "api", steps: []step{
+					{name: "sqlc", codegen: true, command: failing()},
+					{name: "protoc", codegen: true, command: passing()},
+					{name: "build", command: passing()},
+				}},
+			},
+			expected: []Status{StatusFailed, StatusPassed, StatusSkipped},
+			failed:   true,
+		},
+		{
+			name: "codegen failure skips builds in dependent modules",
+			plan: []module{
+				{name: "api", steps: []step{
+					{name: "protoc", codegen: true, command: failing()},
+				}},
+				{name: "web", dependsOn: []string{"api"}, steps: []step{
+					{name: "templ", codegen: true, command: passing()},
+					{name: "build", command: passing()},
+				}},
+			},
+			expected: []Status{StatusFailed, StatusPassed, StatusSkipped},
+			failed:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectDir := t.TempDir()
+			for _, mod := range tt.plan {
+				if err := os.Mkdir(filepath.Join(projectDir, mod.name), 0o755); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			verifier := NewVerifier(projectDir)
+			verifier.plan = tt.plan
+
+			report, err := verifier.Run(context.Background())
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			if len(report.Results) != len(tt.expected) {
+				t.Fatalf("got %d results, want %d", len(report.Results), len(tt.expected))
+			}
+			for i, result := range report.Results {
+				if result.Status != tt.expected[i] {
+					t.Errorf("step %q: status = %s, want %s", result.Name, result.Status, tt.expected[i])
+				}
+			}
+
+			if report.Failed() != tt.failed {
+				t.Errorf("Failed() = %v, want %v", report.Failed(), tt.failed)
+			}
+		})
+	}
+}
+
+func TestVerifier_RunTimeout(t *testing.T) {
+	if _, err := exec.LookPath("sleep"); err != nil {
+		t.Skip("sleep not found in PATH")
+	}
+
+	projectDir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(projectDir, "api"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	verifier := NewVerifier(projectDir).WithTimeout(100 * time.Millisecond)
+	verifier.plan = []module{
+		{name: "api", steps: []step{{name: "hang", command: hanging()}}},
+	}
+
+	start := time.Now()
+	report, err := verifier.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Run() took %s, want the hanging step killed after its timeout", elapsed)
+	}
+	if report.Results[0].Status != StatusFailed {
+		t.Errorf("status = %s, want %s", report.Results[0].Status, StatusFailed)
+	}
+}
+
+func TestReport_Counts(t *testing.T) {
+	report := &Report{Results: []StepResult{
+		{Status: StatusPassed},
+		{Status: StatusPassed},
+		{Status: StatusFailed},
+		{Status: StatusSkipped},
+	}}
+
+	passed, failed, skipped := report.Counts()
+	if passed != 2 || failed != 1 || skipped != 1 {
+		t.Errorf("Counts() = %d, %d, %d, want 2, 1, 1", passed, failed, skipped)
+	}
+}